@@ -1,15 +1,27 @@
 package main
 
 import (
+	"flag"
 	"log"
 
 	"mygo/internal/pkg/game"
+	"mygo/internal/pkg/world"
 
 	"github.com/hajimehoshi/ebiten/v2"
 )
 
 func main() {
-	g := game.NewGame()
+	sandbox := flag.Bool("sandbox", false, "play in sandbox mode without runs and permadeath")
+	seed := flag.Int64("seed", 0, "world seed, 0 picks a random seed")
+	death := flag.String("death", "drop", "what happens to the inventory on death in sandbox mode: drop or clear")
+	flag.Parse()
+
+	deathMode, ok := world.ParseDeathMode(*death)
+	if !ok {
+		log.Fatalf("unknown death mode %q, expected drop or clear", *death)
+	}
+
+	g := game.NewGameWithOptions(game.Options{Sandbox: *sandbox, Seed: *seed, DeathMode: deathMode})
 	ebiten.SetWindowSize(800, 600)
 	ebiten.SetWindowTitle("My Go - 2D Sandbox Roguelike")
 	if err := ebiten.RunGame(g); err != nil {
@@ -1,5 +1,10 @@
 package entity
 
+import (
+	"encoding/json"
+	"fmt"
+)
+
 const (
 	BlockSize = 32
 )
@@ -10,10 +15,24 @@ type BlockType int
 // 注意：GrassBlock已合并到DirtBlock中，此处仅为保持向后兼容性
 const (
 	StoneBlock BlockType = iota
-	DirtBlock  // 合并了草方块，草地只是显示不同，实际是泥土方块
+	DirtBlock            // 合并了草方块，草地只是显示不同，实际是泥土方块
 	// GrassBlock = DirtBlock  // 注释掉这个定义，避免switch语句中的重复
 	WoodBlock
 	LeavesBlock
+	LadderBlock        // 梯子，可攀爬
+	VineBlock          // 藤蔓，生成在树冠下方和洞穴墙壁上
+	RopeBlock          // 绳索，只能向下延伸放置
+	SpikeBlock         // 尖刺，接触时造成伤害
+	PlanksBlock        // 木板
+	CraftingTableBlock // 工作台，右键打开3x3合成网格
+	ChestBlock         // 箱子，自带物品容器，右键打开
+	IronOreBlock       // 铁矿石，生成在较深的地下
+	CoalOreBlock       // 煤矿石，挖掘后掉落煤炭
+	SmoothStoneBlock   // 平滑石头，由石头在熔炉中烧制
+	FurnaceBlock       // 熔炉，右键打开，消耗燃料烧制物品
+	TorchBlock         // 火把，没有碰撞体积，需要附着在实心方块上
+	ArenaBarrierBlock  // 竞技场屏障，首领战期间封住出口，无法破坏
+	PerkShrineBlock    // 天赋祭坛，生成在洞穴中，右键选择一个天赋后消失
 )
 
 // 为了保持向后兼容性，定义一个常量指向DirtBlock
@@ -21,10 +40,143 @@ const (
 	GrassBlock BlockType = DirtBlock
 )
 
+// BlockProperties 方块属性
+type BlockProperties struct {
+	Name          string
+	Solid         bool         // 是否具有碰撞体积
+	Climbable     bool         // 是否可以攀爬
+	ContactDamage int          // 接触时造成的伤害，0表示无害
+	ContactEffect StatusEffect // 接触并受伤时附加的状态效果
+	Hardness      float64      // 硬度，决定挖掘所需时间（徒手每点约1秒）
+	Tool          ToolKind     // 适合挖掘该方块的工具种类
+	ContainerSize int          // 方块自带物品容器的槽数量，0表示没有容器
+	Unbreakable   bool         // 无法被挖掘或炸毁
+}
+
+// blockProperties 所有方块类型的属性表
+var blockProperties = map[BlockType]BlockProperties{
+	StoneBlock:         {Name: "Stone", Solid: true, Hardness: 1.5, Tool: ToolPickaxe},
+	DirtBlock:          {Name: "Dirt", Solid: true, Hardness: 0.5, Tool: ToolShovel},
+	WoodBlock:          {Name: "Wood", Solid: true, Hardness: 1.0, Tool: ToolAxe},
+	LeavesBlock:        {Name: "Leaves", Solid: true, Hardness: 0.2},
+	LadderBlock:        {Name: "Ladder", Climbable: true, Hardness: 0.4, Tool: ToolAxe},
+	VineBlock:          {Name: "Vine", Climbable: true, Hardness: 0.1},
+	RopeBlock:          {Name: "Rope", Climbable: true, Hardness: 0.1},
+	SpikeBlock:         {Name: "Spike", ContactDamage: 4, ContactEffect: StatusEffect{Type: EffectPoison, Level: 1, Duration: 240}, Hardness: 1.0, Tool: ToolPickaxe},
+	PlanksBlock:        {Name: "Planks", Solid: true, Hardness: 1.0, Tool: ToolAxe},
+	CraftingTableBlock: {Name: "Crafting Table", Solid: true, Hardness: 1.2, Tool: ToolAxe},
+	ChestBlock:         {Name: "Chest", Solid: true, Hardness: 1.2, Tool: ToolAxe, ContainerSize: ChestSlotCount},
+	IronOreBlock:       {Name: "Iron Ore", Solid: true, Hardness: 3.0, Tool: ToolPickaxe},
+	CoalOreBlock:       {Name: "Coal Ore", Solid: true, Hardness: 2.0, Tool: ToolPickaxe},
+	SmoothStoneBlock:   {Name: "Smooth Stone", Solid: true, Hardness: 2.0, Tool: ToolPickaxe},
+	FurnaceBlock:       {Name: "Furnace", Solid: true, Hardness: 2.0, Tool: ToolPickaxe},
+	TorchBlock:         {Name: "Torch", Hardness: 0.1},
+	ArenaBarrierBlock:  {Name: "Arena Barrier", Solid: true, Unbreakable: true},
+	PerkShrineBlock:    {Name: "Perk Shrine", Solid: true, Unbreakable: true},
+}
+
+// blockNames 方块在数据文件（如物品属性）中使用的名称
+var blockNames = map[BlockType]string{
+	StoneBlock:         "stone",
+	DirtBlock:          "dirt",
+	WoodBlock:          "wood",
+	LeavesBlock:        "leaves",
+	LadderBlock:        "ladder",
+	VineBlock:          "vine",
+	RopeBlock:          "rope",
+	SpikeBlock:         "spike",
+	PlanksBlock:        "planks",
+	CraftingTableBlock: "crafting_table",
+	ChestBlock:         "chest",
+	IronOreBlock:       "iron_ore",
+	CoalOreBlock:       "coal_ore",
+	SmoothStoneBlock:   "smooth_stone",
+	FurnaceBlock:       "furnace",
+	TorchBlock:         "torch",
+	ArenaBarrierBlock:  "arena_barrier",
+	PerkShrineBlock:    "perk_shrine",
+}
+
+// GetBlockName 获取方块类型的名称
+func GetBlockName(blockType BlockType) string {
+	if name, exists := blockNames[blockType]; exists {
+		return name
+	}
+	return "unknown"
+}
+
+// ParseBlockName 根据名称查找方块类型
+func ParseBlockName(name string) (BlockType, bool) {
+	for blockType, blockName := range blockNames {
+		if blockName == name {
+			return blockType, true
+		}
+	}
+	return StoneBlock, false
+}
+
+// MarshalJSON 方块类型按名称保存（例如存档），新增方块不会改变已有存档的含义
+func (t BlockType) MarshalJSON() ([]byte, error) {
+	return json.Marshal(GetBlockName(t))
+}
+
+// UnmarshalJSON 根据名称读取方块类型，旧存档中按编号保存的方块类型也可以读取
+func (t *BlockType) UnmarshalJSON(data []byte) error {
+	var id int
+	if err := json.Unmarshal(data, &id); err == nil {
+		if _, ok := blockNames[BlockType(id)]; !ok {
+			return fmt.Errorf("unknown block %d", id)
+		}
+		*t = BlockType(id)
+		return nil
+	}
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		return err
+	}
+	blockType, ok := ParseBlockName(name)
+	if !ok {
+		return fmt.Errorf("unknown block %q", name)
+	}
+	*t = blockType
+	return nil
+}
+
+// GetBlockProperties 获取方块类型的属性，未知类型按实心方块处理
+func GetBlockProperties(blockType BlockType) BlockProperties {
+	if props, exists := blockProperties[blockType]; exists {
+		return props
+	}
+	return BlockProperties{Name: "Unknown", Solid: true, Hardness: 1.0}
+}
+
+// IsSolid 返回方块类型是否具有碰撞体积
+func (t BlockType) IsSolid() bool {
+	return GetBlockProperties(t).Solid
+}
+
+// IsClimbable 返回方块类型是否可以攀爬
+func (t BlockType) IsClimbable() bool {
+	return GetBlockProperties(t).Climbable
+}
+
+// IsSolidBlockAt 检查世界中指定网格位置是否有实心方块
+func IsSolidBlockAt(world World, x, y int) bool {
+	block, exists := world.GetBlock(x, y)
+	return exists && block.Type.IsSolid()
+}
+
+// IsClimbableBlockAt 检查世界中指定网格位置是否有可攀爬的方块
+func IsClimbableBlockAt(world World, x, y int) bool {
+	block, exists := world.GetBlock(x, y)
+	return exists && block.Type.IsClimbable()
+}
+
 // Block represents a block in the game world
 type Block struct {
-	X, Y   float64
-	Type   BlockType  // 方块类型
+	X, Y      float64
+	Type      BlockType  // 方块类型
+	Container *Container // 方块自带的物品容器（如箱子），没有容器时为nil
 }
 
 // NewBlock creates a new block at the specified grid position
@@ -38,11 +190,17 @@ func NewBlock(x, y int) *Block {
 
 // NewBlockWithType 创建指定类型的方块
 func NewBlockWithType(x, y int, blockType BlockType) *Block {
-	return &Block{
+	block := &Block{
 		X: float64(x * BlockSize),
 		Y: float64(y * BlockSize),
 		Type: blockType,
 	}
+	
+	// 带容器的方块（如箱子）创建自己的物品容器
+	if size := GetBlockProperties(blockType).ContainerSize; size > 0 {
+		block.Container = NewContainer(size)
+	}
+	return block
 }
 
 // GetPosition returns the world position of the block
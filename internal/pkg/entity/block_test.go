@@ -1,6 +1,7 @@
 package entity
 
 import (
+	"encoding/json"
 	"testing"
 )
 
@@ -72,4 +73,46 @@ func TestBlockGetType(t *testing.T) {
 	if blockType != GrassBlock {
 		t.Errorf("Expected Type=GrassBlock, got %v", blockType)
 	}
-}
\ No newline at end of file
+}
+func TestBlockProperties(t *testing.T) {
+	// 普通方块是实心的，不可攀爬
+	for _, blockType := range []BlockType{StoneBlock, DirtBlock, WoodBlock, LeavesBlock} {
+		if !blockType.IsSolid() {
+			t.Errorf("Expected block type %v to be solid", blockType)
+		}
+		if blockType.IsClimbable() {
+			t.Errorf("Expected block type %v not to be climbable", blockType)
+		}
+	}
+
+	// 梯子、藤蔓、绳索可以攀爬且没有碰撞体积
+	for _, blockType := range []BlockType{LadderBlock, VineBlock, RopeBlock} {
+		if blockType.IsSolid() {
+			t.Errorf("Expected block type %v not to be solid", blockType)
+		}
+		if !blockType.IsClimbable() {
+			t.Errorf("Expected block type %v to be climbable", blockType)
+		}
+	}
+}
+
+func TestBlockTypeJSON(t *testing.T) {
+	data, err := json.Marshal(FurnaceBlock)
+	if err != nil || string(data) != `"furnace"` {
+		t.Fatalf("Expected block type to be saved by name, got %s (%v)", data, err)
+	}
+
+	var blockType BlockType
+	if err := json.Unmarshal(data, &blockType); err != nil || blockType != FurnaceBlock {
+		t.Errorf("Expected block type to round-trip by name, got %v (%v)", blockType, err)
+	}
+	// 旧存档按编号保存方块类型
+	if err := json.Unmarshal([]byte("3"), &blockType); err != nil || blockType != LeavesBlock {
+		t.Errorf("Expected numeric block type to be read, got %v (%v)", blockType, err)
+	}
+	for _, bad := range []string{`"no_such_block"`, "999", "true"} {
+		if err := json.Unmarshal([]byte(bad), &blockType); err == nil {
+			t.Errorf("Expected error for %s", bad)
+		}
+	}
+}
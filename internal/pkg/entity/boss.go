@@ -0,0 +1,361 @@
+package entity
+
+import "math"
+
+const (
+	BossHurtDuration        = 6   // 受伤后不会再被命中的帧数
+	BossPhaseTransition     = 60  // 进入新阶段时停顿的帧数，期间无敌
+	BossKnockbackResist     = 0.2 // 首领受到的击退相对于普通生物的比例
+	BossContactKnockback    = 6.0 // 玩家碰到首领或被攻击命中时击退的速度
+	BossChargeWindup        = 30  // 冲撞前蓄力的帧数
+	BossChargeDuration      = 60  // 冲撞的最长帧数，撞到墙时提前结束
+	BossChargeSpeed         = 3.0 // 冲撞速度相对于阶段移动速度的倍数
+	BossSlamRadius          = 4   // 砸地冲击波的水平范围（格）
+	BossSlamTimeout         = 180 // 砸地跳起后最多等待落地的帧数
+	BossVolleyShots         = 3   // 齐射的投射物数量
+	BossVolleyInterval      = 15  // 齐射中两次发射之间的帧数
+	BossVolleySpeed         = 9.0 // 齐射投射物的初速度
+	BossProjectileKnockback = 4.0 // 齐射投射物命中玩家时的击退速度
+	BossSummonCount         = 2   // 一次召唤的史莱姆数量
+	BossSummonDuration      = 30  // 召唤时停顿的帧数
+)
+
+// BossWorld 首领的攻击模式需要的世界操作（由world.World实现）
+type BossWorld interface {
+	World
+	AddProjectile(projectile *Projectile)
+	SpawnMob(kind MobKind, x, y int) *Mob
+}
+
+// AttackPattern 首领的一种攻击模式：从开始起每帧调用一次（PatternTimer为已经执行的帧数），
+// 设置首领的速度并对玩家造成伤害，返回攻击是否结束
+type AttackPattern func(b *Boss, player *Player) bool
+
+// bossPatterns 可以在首领数据文件中通过名称指定的攻击模式
+var bossPatterns = map[string]AttackPattern{
+	"charge": ChargePattern,
+	"slam":   SlamPattern,
+	"volley": VolleyPattern,
+	"summon": SummonPattern,
+}
+
+// RegisterBossPattern 注册新的攻击模式，之后加载的首领数据文件可以通过名称引用它
+func RegisterBossPattern(name string, pattern AttackPattern) {
+	bossPatterns[name] = pattern
+}
+
+// Boss 首领：生命值降到阶段阈值以下时进入下一阶段，按阶段的攻击模式列表轮流攻击，
+// 两次攻击之间走向玩家；身体接触也会伤害玩家
+type Boss struct {
+	Name              string
+	X, Y              float64 // 中心位置
+	VX, VY            float64
+	OnGround          bool
+	Blocked           bool // 上一帧水平移动是否被方块挡住
+	Facing            int  // 朝向：-1=左, 1=右
+	Health, MaxHealth int
+	Dead              bool
+	HurtTimer         int    // 剩余的受伤闪烁帧数，期间不会被攻击命中
+	Phase             int    // 当前阶段的序号
+	TransitionTimer   int    // 剩余的阶段转换帧数，期间不移动、不攻击、不受伤害
+	Pattern           string // 正在执行的攻击模式，空表示没有在攻击
+	PatternTimer      int    // 当前攻击模式已经执行的帧数
+	PatternIndex      int    // 下一次攻击在当前阶段攻击模式列表中的序号
+	AttackTimer       int    // 距离下一次攻击的帧数
+	World             BossWorld
+	props             BossProperties
+}
+
+// NewBoss 在指定位置（中心）创建首领注册表中的首领
+func NewBoss(name string, x, y float64) (*Boss, bool) {
+	props, exists := Bosses.Get(name)
+	if !exists {
+		return nil, false
+	}
+	return &Boss{
+		Name:        name,
+		X:           x,
+		Y:           y,
+		Facing:      1,
+		Health:      props.MaxHealth,
+		MaxHealth:   props.MaxHealth,
+		AttackTimer: props.Phases[0].Cooldown,
+		props:       props,
+	}, true
+}
+
+// Props 获取首领的属性
+func (b *Boss) Props() BossProperties {
+	return b.props
+}
+
+// CurrentPhase 获取当前阶段
+func (b *Boss) CurrentPhase() BossPhase {
+	return b.props.Phases[b.Phase]
+}
+
+// SetWorld 设置世界引用
+func (b *Boss) SetWorld(world BossWorld) {
+	b.World = world
+}
+
+// GetPosition 获取首领的中心位置
+func (b *Boss) GetPosition() (float64, float64) {
+	return b.X, b.Y
+}
+
+// Hitbox 获取首领的碰撞箱
+func (b *Boss) Hitbox() Hitbox {
+	return NewHitbox(b.X, b.Y, b.props.Width, b.props.Height)
+}
+
+// GridPos 获取首领脚所在的网格位置
+func (b *Boss) GridPos() GridPos {
+	return GridPos{
+		X: int(math.Floor(b.X / BlockSize)),
+		Y: int(math.Ceil((b.Y+b.props.Height/2)/BlockSize)) - 1,
+	}
+}
+
+// Fits 检查首领当前位置是否没有与实心方块重叠
+func (b *Boss) Fits() bool {
+	return b.World == nil || !boxCollides(b.World, b.X, b.Y, b.props.Width, b.props.Height)
+}
+
+// GetHealth 获取当前生命值和最大生命值
+func (b *Boss) GetHealth() (int, int) {
+	return b.Health, b.MaxHealth
+}
+
+// HealthRatio 获取剩余生命值的比例
+func (b *Boss) HealthRatio() float64 {
+	return float64(b.Health) / float64(b.MaxHealth)
+}
+
+// Hit 被武器、投射物或冲刺攻击命中：受到伤害，只被轻微击退；
+// 受伤闪烁和阶段转换期间无敌，生命值降到阈值以下时进入下一阶段；返回是否命中
+func (b *Boss) Hit(damage int, knockbackX, knockbackY float64) bool {
+	if b.Dead || damage <= 0 || b.HurtTimer > 0 || b.TransitionTimer > 0 {
+		return false
+	}
+	b.Health -= damage
+	b.HurtTimer = BossHurtDuration
+	if b.Health <= 0 {
+		b.Health = 0
+		b.Dead = true
+		return true
+	}
+	b.VX += knockbackX * BossKnockbackResist
+	b.updatePhase()
+	return true
+}
+
+// updatePhase 根据剩余生命值进入对应的阶段，进入新阶段时中断当前攻击并停顿一段时间
+func (b *Boss) updatePhase() {
+	next := b.Phase
+	for next+1 < len(b.props.Phases) && b.HealthRatio() <= b.props.Phases[next+1].Threshold {
+		next++
+	}
+	if next == b.Phase {
+		return
+	}
+	b.Phase = next
+	b.TransitionTimer = BossPhaseTransition
+	b.Pattern, b.PatternIndex = "", 0
+	b.AttackTimer = b.CurrentPhase().Cooldown
+}
+
+// Update 更新一帧：阶段转换时停顿，攻击中执行攻击模式，否则走向玩家并在冷却结束后开始下一次攻击
+// 玩家死亡或不存在时原地停留
+func (b *Boss) Update(player *Player) {
+	if b.Dead {
+		return
+	}
+	if b.HurtTimer > 0 {
+		b.HurtTimer--
+	}
+	if b.AttackTimer > 0 {
+		b.AttackTimer--
+	}
+
+	active := player != nil && !player.Dead
+	switch {
+	case b.TransitionTimer > 0:
+		b.TransitionTimer--
+		b.VX = 0
+	case !active:
+		b.Pattern = ""
+		b.VX = 0
+	case b.Pattern != "":
+		b.runPattern(player)
+	default:
+		b.approach(player)
+		// 攻击只在站在地面上时开始
+		if b.AttackTimer == 0 && b.OnGround {
+			b.startPattern()
+		}
+	}
+
+	b.updatePhysics()
+	if active {
+		b.touch(player)
+	}
+}
+
+// startPattern 按顺序选择当前阶段的下一个攻击模式
+func (b *Boss) startPattern() {
+	patterns := b.CurrentPhase().Patterns
+	b.Pattern = patterns[b.PatternIndex%len(patterns)]
+	b.PatternIndex++
+	b.PatternTimer = 0
+}
+
+// runPattern 执行一帧当前的攻击模式，结束后开始冷却
+func (b *Boss) runPattern(player *Player) {
+	pattern, exists := bossPatterns[b.Pattern]
+	if !exists || pattern(b, player) {
+		b.Pattern = ""
+		b.AttackTimer = b.CurrentPhase().Cooldown
+		return
+	}
+	b.PatternTimer++
+}
+
+// approach 两次攻击之间走向玩家，被方块挡住时跳跃
+func (b *Boss) approach(player *Player) {
+	b.Facing = b.directionTo(player)
+	if math.Abs(player.X-b.X) < b.props.Width/2 {
+		b.VX = 0
+		return
+	}
+	b.VX = float64(b.Facing) * b.CurrentPhase().Speed
+	if b.Blocked && b.OnGround {
+		b.VY = -b.props.JumpPower
+		b.OnGround = false
+	}
+}
+
+// touch 玩家碰到首领时受到伤害并被弹开
+func (b *Boss) touch(player *Player) {
+	if !b.Hitbox().Overlaps(player.Hitbox()) {
+		return
+	}
+	direction := float64(b.directionTo(player))
+	player.Hit(b.props.Damage, direction*BossContactKnockback, -BossContactKnockback*KnockbackLift)
+}
+
+// directionTo 获取朝向玩家的水平方向
+func (b *Boss) directionTo(player *Player) int {
+	if player.X < b.X {
+		return -1
+	}
+	return 1
+}
+
+// updatePhysics 应用重力并逐像素移动，碰到实心方块时停下
+func (b *Boss) updatePhysics() {
+	b.VY = math.Min(b.VY+Gravity, MobMaxFallSpeed)
+	if b.World == nil {
+		b.X += b.VX
+		b.Y += b.VY
+		return
+	}
+
+	b.Blocked = false
+	if moveBox(b.World, &b.X, &b.Y, b.props.Width, b.props.Height, b.VX, 0) {
+		b.Blocked = true
+		b.VX = 0
+	}
+	b.OnGround = false
+	if moveBox(b.World, &b.X, &b.Y, b.props.Width, b.props.Height, 0, b.VY) {
+		if b.VY > 0 {
+			b.OnGround = true
+		}
+		b.VY = 0
+	}
+}
+
+// ChargePattern 冲撞：原地蓄力后朝玩家所在的方向高速冲出，撞到墙或冲出一段时间后停下
+func ChargePattern(b *Boss, player *Player) bool {
+	if b.PatternTimer < BossChargeWindup {
+		b.VX = 0
+		b.Facing = b.directionTo(player)
+		return false
+	}
+	if b.PatternTimer > BossChargeWindup && b.Blocked || b.PatternTimer >= BossChargeWindup+BossChargeDuration {
+		b.VX = 0
+		return true
+	}
+	b.VX = float64(b.Facing) * b.CurrentPhase().Speed * BossChargeSpeed
+	return false
+}
+
+// SlamPattern 砸地：朝玩家跳起，落地时产生冲击波，伤害范围内站在地面上的玩家（跳起可以躲开）
+func SlamPattern(b *Boss, player *Player) bool {
+	if b.PatternTimer == 0 {
+		b.Facing = b.directionTo(player)
+		b.VX = float64(b.Facing) * b.CurrentPhase().Speed * 2
+		b.VY = -b.props.JumpPower
+		b.OnGround = false
+		return false
+	}
+	if !b.OnGround {
+		return b.PatternTimer >= BossSlamTimeout
+	}
+
+	b.VX = 0
+	bossBottom := b.Y + b.props.Height/2
+	playerBottom := player.Y + PlayerSize/2
+	if player.OnGround && math.Abs(player.X-b.X) <= b.props.Width/2+BossSlamRadius*BlockSize &&
+		math.Abs(playerBottom-bossBottom) <= BlockSize {
+		direction := float64(b.directionTo(player))
+		player.Hit(b.props.Damage, direction*BossContactKnockback, -BossContactKnockback*KnockbackLift*2)
+	}
+	return true
+}
+
+// VolleyPattern 齐射：站定后朝玩家连续发射几支敌对的箭
+func VolleyPattern(b *Boss, player *Player) bool {
+	b.VX = 0
+	b.Facing = b.directionTo(player)
+	if b.PatternTimer%BossVolleyInterval == 0 && b.PatternTimer < BossVolleyShots*BossVolleyInterval {
+		b.fireAt(player)
+	}
+	return b.PatternTimer >= BossVolleyShots*BossVolleyInterval
+}
+
+// fireAt 从首领上半身朝玩家发射一支敌对投射物
+func (b *Boss) fireAt(player *Player) {
+	if b.World == nil {
+		return
+	}
+	startX, startY := b.X, b.Y-b.props.Height/4
+	dx, dy := player.X-startX, player.Y-startY
+	distance := math.Hypot(dx, dy)
+	if distance == 0 {
+		dx, distance = float64(b.Facing), 1
+	}
+	projectile := NewProjectile(ProjectileArrow, startX, startY, dx/distance*BossVolleySpeed, dy/distance*BossVolleySpeed,
+		b.props.Damage, BossProjectileKnockback)
+	projectile.Hostile = true
+	b.World.AddProjectile(projectile)
+}
+
+// SummonPattern 召唤：停顿片刻，在两侧召唤史莱姆，被方块占据的位置跳过
+func SummonPattern(b *Boss, player *Player) bool {
+	b.VX = 0
+	if b.PatternTimer == 0 && b.World != nil {
+		feet := b.GridPos()
+		offset := int(math.Ceil(b.props.Width/2/BlockSize)) + 1
+		for i := 0; i < BossSummonCount; i++ {
+			x := feet.X + offset + i/2
+			if i%2 == 1 {
+				x = feet.X - offset - i/2
+			}
+			if !IsSolidBlockAt(b.World, x, feet.Y) {
+				b.World.SpawnMob(MobSlime, x, feet.Y)
+			}
+		}
+	}
+	return b.PatternTimer >= BossSummonDuration
+}
@@ -0,0 +1,265 @@
+package entity
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"os"
+	"sort"
+)
+
+const (
+	DefaultArenaWidth  = 24 // 召唤首领时生成的竞技场默认宽度（格）
+	DefaultArenaHeight = 10 // 召唤首领时生成的竞技场默认高度（格）
+)
+
+// BossPhase 首领的一个阶段：生命值比例降到阈值以下时进入，按顺序循环使用攻击模式
+type BossPhase struct {
+	Threshold float64  // 生命值比例不高于该值时进入此阶段，第一阶段为1
+	Speed     float64  // 两次攻击之间追向玩家的速度
+	Cooldown  int      // 一次攻击结束到下一次攻击开始的帧数
+	Patterns  []string // 攻击模式的名称
+}
+
+// BossLoot 首领被击败时的一项掉落
+type BossLoot struct {
+	Item     ItemType
+	Min, Max int
+	Chance   float64 // 掉落的概率，0到1
+}
+
+// BossProperties 首领属性（来自首领数据文件）
+type BossProperties struct {
+	Name          string
+	DisplayName   string
+	MaxHealth     int
+	Width, Height float64
+	JumpPower     float64
+	Damage        int // 接触和攻击模式造成的伤害
+	Sprite        int
+	SummonItem    ItemType // 召唤首领的物品，Air表示不能召唤
+	ArenaWidth    int      // 召唤时生成的竞技场大小（格）
+	ArenaHeight   int
+	Phases        []BossPhase // 按阈值从高到低排列
+	Loot          []BossLoot
+}
+
+// RollLoot 按掉落概率和数量范围随机生成掉落物
+func (p BossProperties) RollLoot(rng *rand.Rand) []ItemStack {
+	stacks := make([]ItemStack, 0, len(p.Loot))
+	for _, loot := range p.Loot {
+		if rng.Float64() >= loot.Chance {
+			continue
+		}
+		stacks = append(stacks, ItemStack{Type: loot.Item, Count: loot.Min + rng.Intn(loot.Max-loot.Min+1)})
+	}
+	return stacks
+}
+
+// BossRegistry 首领注册表，按名称查找首领属性
+type BossRegistry struct {
+	bosses map[string]*BossProperties
+}
+
+// Bosses 全局首领注册表，启动时通过LoadBosses从数据文件加载
+var Bosses = NewBossRegistry()
+
+// NewBossRegistry 创建空的首领注册表
+func NewBossRegistry() *BossRegistry {
+	return &BossRegistry{bosses: make(map[string]*BossProperties)}
+}
+
+// Register 注册首领，同名的首领会被替换
+func (r *BossRegistry) Register(props BossProperties) {
+	r.bosses[props.Name] = &props
+}
+
+// Get 根据名称获取首领属性
+func (r *BossRegistry) Get(name string) (BossProperties, bool) {
+	props, exists := r.bosses[name]
+	if !exists {
+		return BossProperties{}, false
+	}
+	return *props, true
+}
+
+// BySummonItem 获取可以用物品召唤的首领
+func (r *BossRegistry) BySummonItem(itemType ItemType) (BossProperties, bool) {
+	for _, props := range r.All() {
+		if props.SummonItem != Air && props.SummonItem == itemType {
+			return props, true
+		}
+	}
+	return BossProperties{}, false
+}
+
+// All 获取所有首领属性，按名称排序
+func (r *BossRegistry) All() []BossProperties {
+	all := make([]BossProperties, 0, len(r.bosses))
+	for _, props := range r.bosses {
+		all = append(all, *props)
+	}
+	sort.Slice(all, func(i, j int) bool {
+		return all[i].Name < all[j].Name
+	})
+	return all
+}
+
+// bossFile 首领数据文件的格式
+type bossFile struct {
+	Bosses []bossData `json:"bosses"`
+}
+
+// bossData 数据文件中的一个首领
+type bossData struct {
+	Name        string  `json:"name"`
+	DisplayName string  `json:"display_name"`
+	Sprite      string  `json:"sprite"`
+	Health      int     `json:"health"`
+	Width       float64 `json:"width"`
+	Height      float64 `json:"height"`
+	JumpPower   float64 `json:"jump_power"`
+	Damage      int     `json:"damage"`
+	SummonItem  string  `json:"summon_item"`
+	Arena       struct {
+		Width  int `json:"width"`
+		Height int `json:"height"`
+	} `json:"arena"`
+	Phases []struct {
+		Threshold float64  `json:"threshold"`
+		Speed     float64  `json:"speed"`
+		Cooldown  int      `json:"cooldown"`
+		Patterns  []string `json:"patterns"`
+	} `json:"phases"`
+	Loot []struct {
+		Item   string   `json:"item"`
+		Min    int      `json:"min"`
+		Max    int      `json:"max"`
+		Chance *float64 `json:"chance"`
+	} `json:"loot"`
+}
+
+// LoadBosses 从JSON文件加载首领属性，替换全局首领注册表
+// 首领的召唤物品和掉落物引用物品名称，需要先加载物品
+func LoadBosses(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	registry, err := ParseBosses(data)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	Bosses = registry
+	return nil
+}
+
+// ParseBosses 解析JSON格式的首领数据
+func ParseBosses(data []byte) (*BossRegistry, error) {
+	var file bossFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, err
+	}
+
+	registry := NewBossRegistry()
+	for _, entry := range file.Bosses {
+		props, err := entry.toProperties()
+		if err != nil {
+			return nil, fmt.Errorf("boss %q: %w", entry.Name, err)
+		}
+		registry.Register(props)
+	}
+	return registry, nil
+}
+
+// toProperties 把数据文件中的首领转换为首领属性
+func (d bossData) toProperties() (BossProperties, error) {
+	if d.Name == "" {
+		return BossProperties{}, fmt.Errorf("missing name")
+	}
+	props := BossProperties{
+		Name:        d.Name,
+		DisplayName: d.DisplayName,
+		MaxHealth:   d.Health,
+		Width:       d.Width,
+		Height:      d.Height,
+		JumpPower:   d.JumpPower,
+		Damage:      d.Damage,
+		ArenaWidth:  d.Arena.Width,
+		ArenaHeight: d.Arena.Height,
+	}
+	if props.DisplayName == "" {
+		props.DisplayName = d.Name
+	}
+	if props.MaxHealth <= 0 {
+		return props, fmt.Errorf("health must be positive")
+	}
+	if props.Width <= 0 || props.Height <= 0 {
+		return props, fmt.Errorf("size must be positive")
+	}
+	if props.ArenaWidth <= 0 {
+		props.ArenaWidth = DefaultArenaWidth
+	}
+	if props.ArenaHeight <= 0 {
+		props.ArenaHeight = DefaultArenaHeight
+	}
+
+	sprite, exists := SpriteMap[d.Sprite]
+	if !exists {
+		return props, fmt.Errorf("unknown sprite %q", d.Sprite)
+	}
+	props.Sprite = sprite.Index
+
+	if d.SummonItem != "" {
+		item, ok := ParseItemName(d.SummonItem)
+		if !ok {
+			return props, fmt.Errorf("unknown summon item %q", d.SummonItem)
+		}
+		props.SummonItem = item
+	}
+
+	if len(d.Phases) == 0 {
+		return props, fmt.Errorf("missing phases")
+	}
+	for i, phase := range d.Phases {
+		threshold := phase.Threshold
+		if i == 0 {
+			threshold = 1
+		} else if threshold <= 0 || threshold >= props.Phases[i-1].Threshold {
+			return props, fmt.Errorf("phase %d: thresholds must decrease", i+1)
+		}
+		if len(phase.Patterns) == 0 {
+			return props, fmt.Errorf("phase %d: missing patterns", i+1)
+		}
+		for _, pattern := range phase.Patterns {
+			if _, ok := bossPatterns[pattern]; !ok {
+				return props, fmt.Errorf("phase %d: unknown pattern %q", i+1, pattern)
+			}
+		}
+		props.Phases = append(props.Phases, BossPhase{
+			Threshold: threshold,
+			Speed:     phase.Speed,
+			Cooldown:  phase.Cooldown,
+			Patterns:  phase.Patterns,
+		})
+	}
+
+	for _, entry := range d.Loot {
+		item, ok := ParseItemName(entry.Item)
+		if !ok {
+			return props, fmt.Errorf("unknown loot item %q", entry.Item)
+		}
+		loot := BossLoot{Item: item, Min: entry.Min, Max: entry.Max, Chance: 1}
+		if loot.Min <= 0 {
+			loot.Min = 1
+		}
+		if loot.Max < loot.Min {
+			loot.Max = loot.Min
+		}
+		if entry.Chance != nil {
+			loot.Chance = *entry.Chance
+		}
+		props.Loot = append(props.Loot, loot)
+	}
+	return props, nil
+}
@@ -0,0 +1,68 @@
+package entity
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestBossDataFile(t *testing.T) {
+	crown, ok := ParseItemName("slime_crown")
+	if !ok {
+		t.Fatal("Expected slime_crown item in the data file")
+	}
+	king, ok := Bosses.BySummonItem(crown)
+	if !ok || king.Name != "slime_king" {
+		t.Fatalf("Expected slime crown to summon the slime king, got %q", king.Name)
+	}
+	if _, ok := Bosses.BySummonItem(Stone); ok {
+		t.Error("Expected stone not to summon any boss")
+	}
+
+	golem, ok := Bosses.Get("stone_golem")
+	if !ok {
+		t.Fatal("Expected stone golem in the data file")
+	}
+	if golem.SummonItem != Air || len(golem.Phases) != 3 || golem.Phases[0].Threshold != 1 {
+		t.Errorf("Unexpected stone golem properties: %+v", golem)
+	}
+	if golem.ArenaWidth != DefaultArenaWidth || golem.ArenaHeight != DefaultArenaHeight {
+		t.Errorf("Expected default arena size, got %dx%d", golem.ArenaWidth, golem.ArenaHeight)
+	}
+}
+
+func TestParseBossesErrors(t *testing.T) {
+	phases := `"phases": [{"patterns": ["charge"]}]`
+	bad := []string{
+		`{"bosses": [{"sprite": "slime_king", "health": 10, "width": 32, "height": 32, ` + phases + `}]}`,
+		`{"bosses": [{"name": "x", "sprite": "slime_king", "width": 32, "height": 32, ` + phases + `}]}`,
+		`{"bosses": [{"name": "x", "sprite": "slime_king", "health": 10, ` + phases + `}]}`,
+		`{"bosses": [{"name": "x", "sprite": "no_such_sprite", "health": 10, "width": 32, "height": 32, ` + phases + `}]}`,
+		`{"bosses": [{"name": "x", "sprite": "slime_king", "health": 10, "width": 32, "height": 32}]}`,
+		`{"bosses": [{"name": "x", "sprite": "slime_king", "health": 10, "width": 32, "height": 32, "phases": [{"patterns": ["dance"]}]}]}`,
+		`{"bosses": [{"name": "x", "sprite": "slime_king", "health": 10, "width": 32, "height": 32, "phases": [{"patterns": []}]}]}`,
+		`{"bosses": [{"name": "x", "sprite": "slime_king", "health": 10, "width": 32, "height": 32, "phases": [{"patterns": ["slam"]}, {"threshold": 1.5, "patterns": ["slam"]}]}]}`,
+		`{"bosses": [{"name": "x", "sprite": "slime_king", "health": 10, "width": 32, "height": 32, "summon_item": "no_such_item", ` + phases + `}]}`,
+		`{"bosses": [{"name": "x", "sprite": "slime_king", "health": 10, "width": 32, "height": 32, ` + phases + `, "loot": [{"item": "no_such_item"}]}]}`,
+		`not json`,
+	}
+	for _, data := range bad {
+		if _, err := ParseBosses([]byte(data)); err == nil {
+			t.Errorf("Expected error for %s", data)
+		}
+	}
+}
+
+func TestBossRollLoot(t *testing.T) {
+	never := 0.0
+	props := BossProperties{Loot: []BossLoot{
+		{Item: IronIngot, Min: 2, Max: 4, Chance: 1},
+		{Item: Coal, Min: 1, Max: 1, Chance: never},
+	}}
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 20; i++ {
+		loot := props.RollLoot(rng)
+		if len(loot) != 1 || loot[0].Type != IronIngot || loot[0].Count < 2 || loot[0].Count > 4 {
+			t.Fatalf("Unexpected loot %v", loot)
+		}
+	}
+}
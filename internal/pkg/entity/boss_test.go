@@ -0,0 +1,185 @@
+package entity
+
+import "testing"
+
+// bossWorld 记录首领发射的投射物和召唤的生物的模拟世界
+type bossWorld struct {
+	*MockWorld
+	projectiles []*Projectile
+	mobs        []*Mob
+}
+
+func (w *bossWorld) AddProjectile(projectile *Projectile) {
+	projectile.SetWorld(w)
+	w.projectiles = append(w.projectiles, projectile)
+}
+
+func (w *bossWorld) SpawnMob(kind MobKind, x, y int) *Mob {
+	mob := NewMob(kind, (float64(x)+0.5)*BlockSize, float64(y+1)*BlockSize-GetMobProperties(kind).Height/2)
+	mob.SetWorld(w)
+	w.mobs = append(w.mobs, mob)
+	return mob
+}
+
+// newTestBoss 注册一个使用指定阶段的测试首领，并把它放在y=5处的地面上
+func newTestBoss(t *testing.T, phases ...BossPhase) (*Boss, *bossWorld) {
+	Bosses.Register(BossProperties{
+		Name: "test_boss", DisplayName: "Test Boss", MaxHealth: 100, Width: 40, Height: 40,
+		JumpPower: 8, Damage: 2, Phases: phases,
+	})
+	world := &bossWorld{MockWorld: newMobTestWorld()}
+	boss, ok := NewBoss("test_boss", 10*BlockSize, 5*BlockSize-20)
+	if !ok {
+		t.Fatal("Expected the test boss to be registered")
+	}
+	boss.SetWorld(world)
+	return boss, world
+}
+
+func TestNewBossUnknownName(t *testing.T) {
+	if _, ok := NewBoss("no_such_boss", 0, 0); ok {
+		t.Error("Expected unknown bosses not to be created")
+	}
+}
+
+func TestBossPhasesAndInvulnerability(t *testing.T) {
+	boss, _ := newTestBoss(t,
+		BossPhase{Threshold: 1, Cooldown: 30, Patterns: []string{"charge"}},
+		BossPhase{Threshold: 0.5, Cooldown: 20, Patterns: []string{"volley"}},
+	)
+
+	if !boss.Hit(40, 10, -5) || boss.Health != 60 || boss.Phase != 0 {
+		t.Fatalf("Expected the first hit to land in phase 1, health %d phase %d", boss.Health, boss.Phase)
+	}
+	if boss.Hit(10, 0, 0) {
+		t.Error("Expected the boss to be invulnerable while hurt")
+	}
+	if boss.VX != 10*BossKnockbackResist {
+		t.Errorf("Expected reduced knockback, got %v", boss.VX)
+	}
+
+	boss.HurtTimer = 0
+	boss.Pattern = "charge"
+	if !boss.Hit(15, 0, 0) || boss.Phase != 1 {
+		t.Fatalf("Expected the boss to enter phase 2 at half health, phase %d", boss.Phase)
+	}
+	if boss.TransitionTimer != BossPhaseTransition || boss.Pattern != "" || boss.AttackTimer != 20 {
+		t.Error("Expected the phase change to interrupt the attack and pause the boss")
+	}
+	boss.HurtTimer = 0
+	if boss.Hit(10, 0, 0) {
+		t.Error("Expected the boss to be invulnerable during the phase transition")
+	}
+
+	boss.TransitionTimer = 0
+	if !boss.Hit(100, 0, 0) || !boss.Dead || boss.Health != 0 {
+		t.Error("Expected the boss to die")
+	}
+}
+
+func TestBossSchedulerCyclesPatterns(t *testing.T) {
+	boss, world := newTestBoss(t, BossPhase{Threshold: 1, Speed: 1, Cooldown: 10, Patterns: []string{"volley", "summon"}})
+	player := NewPlayer(20*BlockSize, 5*BlockSize-16)
+
+	started := make([]string, 0)
+	for i := 0; i < 200 && len(started) < 3; i++ {
+		previous := boss.Pattern
+		boss.Update(player)
+		if boss.Pattern != "" && boss.Pattern != previous {
+			started = append(started, boss.Pattern)
+		}
+	}
+	if len(started) != 3 || started[0] != "volley" || started[1] != "summon" || started[2] != "volley" {
+		t.Fatalf("Expected patterns to be used in order, got %v", started)
+	}
+	if len(world.projectiles) != BossVolleyShots {
+		t.Fatalf("Expected a volley of %d arrows, got %d", BossVolleyShots, len(world.projectiles))
+	}
+	for _, projectile := range world.projectiles {
+		if !projectile.Hostile || projectile.VX <= 0 {
+			t.Error("Expected hostile arrows aimed at the player")
+		}
+	}
+	if len(world.mobs) != BossSummonCount {
+		t.Errorf("Expected %d summoned slimes, got %d", BossSummonCount, len(world.mobs))
+	}
+}
+
+func TestBossChargeDamagesPlayer(t *testing.T) {
+	boss, _ := newTestBoss(t, BossPhase{Threshold: 1, Speed: 1, Patterns: []string{"charge"}})
+	player := NewPlayer(16*BlockSize, 5*BlockSize-16)
+
+	for i := 0; i < 150 && player.Health == player.MaxHealth; i++ {
+		boss.Update(player)
+	}
+	if player.Health != player.MaxHealth-2 || player.LastDamageSource != DamageAttack {
+		t.Fatalf("Expected the charging boss to hit the player, health %d", player.Health)
+	}
+	if boss.Pattern != "charge" || boss.VX != BossChargeSpeed {
+		t.Errorf("Expected the boss to be charging, pattern %q vx %v", boss.Pattern, boss.VX)
+	}
+	if player.VX <= 0 {
+		t.Error("Expected the player to be knocked away from the boss")
+	}
+}
+
+func TestBossSlamOnlyHitsGroundedPlayer(t *testing.T) {
+	phase := BossPhase{Threshold: 1, Patterns: []string{"slam"}}
+	boss, _ := newTestBoss(t, phase)
+	player := NewPlayer(14*BlockSize, 5*BlockSize-16)
+	player.OnGround = true
+	for i := 0; i < 100 && player.Health == player.MaxHealth; i++ {
+		boss.Update(player)
+	}
+	if player.Health != player.MaxHealth-2 || player.VY >= 0 {
+		t.Errorf("Expected the slam to hit and launch a grounded player, health %d", player.Health)
+	}
+
+	boss, _ = newTestBoss(t, phase)
+	airborne := NewPlayer(14*BlockSize, 2*BlockSize)
+	airborne.OnGround = false
+	for i := 0; i < 100; i++ {
+		boss.Update(airborne)
+	}
+	if airborne.Health != airborne.MaxHealth {
+		t.Error("Expected jumping over the shockwave to avoid damage")
+	}
+}
+
+func TestBossIdlesWithoutPlayer(t *testing.T) {
+	boss, _ := newTestBoss(t, BossPhase{Threshold: 1, Speed: 1, Patterns: []string{"charge"}})
+	x := boss.X
+	for i := 0; i < 60; i++ {
+		boss.Update(nil)
+	}
+	if boss.X != x || boss.Pattern != "" {
+		t.Error("Expected the boss to stay idle without a player")
+	}
+}
+
+func TestSummonBehavior(t *testing.T) {
+	crown, ok := ParseItemName("slime_crown")
+	if !ok {
+		t.Fatal("Expected slime_crown item")
+	}
+	if _, isSummon := GetItemBehavior(crown).(SummonBehavior); !isSummon {
+		t.Error("Expected the slime crown to use the summon behavior")
+	}
+
+	ctx, world := newBehaviorContext(ItemStack{Type: crown, Count: 1}, 3, 3)
+	if !(SummonBehavior{}).UseInAir(ctx) || ctx.Stack.Count != 0 {
+		t.Fatal("Expected the crown to be consumed")
+	}
+	if len(world.summoned) != 1 || world.summoned[0] != "slime_king" {
+		t.Errorf("Expected the slime king to be summoned, got %v", world.summoned)
+	}
+
+	ctx.Stack = &ItemStack{Type: crown, Count: 1}
+	if (SummonBehavior{}).UseInAir(ctx) || ctx.Stack.Count != 1 {
+		t.Error("Expected summoning to fail while a boss fight is in progress")
+	}
+	ctx.Stack = &ItemStack{Type: Stone, Count: 1}
+	if (SummonBehavior{}).UseInAir(ctx) {
+		t.Error("Expected items that summon nothing to do nothing")
+	}
+}
@@ -0,0 +1,110 @@
+package entity
+
+import "math/rand"
+
+const (
+	// ChestSlotCount 箱子的槽数量（与主物品栏相同的9x3）
+	ChestSlotCount = 27
+)
+
+// Container 方块自带的物品容器，例如箱子
+type Container struct {
+	Slots []ItemStack
+}
+
+// NewContainer 创建指定槽数量的空容器
+func NewContainer(size int) *Container {
+	slots := make([]ItemStack, size)
+	for i := range slots {
+		slots[i] = ItemStack{Type: Air, Count: 0}
+	}
+	return &Container{Slots: slots}
+}
+
+// Size 获取容器的槽数量
+func (c *Container) Size() int {
+	return len(c.Slots)
+}
+
+// GetSlot 获取指定槽位的物品
+func (c *Container) GetSlot(slot int) ItemStack {
+	if slot < 0 || slot >= len(c.Slots) {
+		return ItemStack{Type: Air, Count: 0}
+	}
+	return c.Slots[slot]
+}
+
+// SetSlot 设置指定槽位的物品
+func (c *Container) SetSlot(slot int, item ItemStack) {
+	if slot < 0 || slot >= len(c.Slots) {
+		return
+	}
+	c.Slots[slot] = item
+}
+
+// AddStack 把物品堆叠放入容器，返回放不下的剩余数量
+func (c *Container) AddStack(stack ItemStack) int {
+	return addStackToSlots(c.Slots, stack)
+}
+
+// CountItem 统计容器中某种物品的总数
+func (c *Container) CountItem(itemType ItemType) int {
+	total := 0
+	for _, stack := range c.Slots {
+		if stack.Type == itemType {
+			total += stack.Count
+		}
+	}
+	return total
+}
+
+// IsEmpty 检查容器是否没有任何物品
+func (c *Container) IsEmpty() bool {
+	for _, stack := range c.Slots {
+		if stack.Type != Air && stack.Count > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Clear 清空容器，返回被清空的物品堆叠（不包括空槽位）
+func (c *Container) Clear() []ItemStack {
+	removed := make([]ItemStack, 0)
+	for i := range c.Slots {
+		if c.Slots[i].Type != Air && c.Slots[i].Count > 0 {
+			removed = append(removed, c.Slots[i])
+		}
+		c.Slots[i] = ItemStack{Type: Air, Count: 0}
+	}
+	return removed
+}
+
+// Fill 把战利品分散放入容器的随机空槽位（供生成的建筑和地牢使用）
+// 没有空槽位时改为叠加，返回放不下的物品
+func (c *Container) Fill(loot []ItemStack, rng *rand.Rand) []ItemStack {
+	leftovers := make([]ItemStack, 0)
+	for _, stack := range loot {
+		if stack.Type == Air || stack.Count <= 0 {
+			continue
+		}
+		if IsTool(stack.Type) && stack.Durability <= 0 {
+			stack.Durability = NewToolStack(stack.Type).Durability
+		}
+
+		empty := make([]int, 0, len(c.Slots))
+		for i, slot := range c.Slots {
+			if slot.Type == Air {
+				empty = append(empty, i)
+			}
+		}
+		if len(empty) > 0 && stack.Count <= GetMaxStackSize(stack.Type) {
+			c.Slots[empty[rng.Intn(len(empty))]] = stack
+			continue
+		}
+		if stack.Count = c.AddStack(stack); stack.Count > 0 {
+			leftovers = append(leftovers, stack)
+		}
+	}
+	return leftovers
+}
@@ -0,0 +1,79 @@
+package entity
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestChestBlockHasContainer(t *testing.T) {
+	chest := NewBlockWithType(0, 0, ChestBlock)
+	if chest.Container == nil || chest.Container.Size() != ChestSlotCount {
+		t.Fatal("Expected chest block to own a container")
+	}
+	if NewBlockWithType(0, 0, StoneBlock).Container != nil {
+		t.Error("Expected stone block to have no container")
+	}
+	if item, ok := GetBlockItem(ChestBlock); !ok || item != Chest {
+		t.Error("Expected chest block to drop a chest item")
+	}
+}
+
+func TestContainerAddStackAndClear(t *testing.T) {
+	container := NewContainer(2)
+	if !container.IsEmpty() {
+		t.Fatal("Expected new container to be empty")
+	}
+
+	if leftover := container.AddStack(ItemStack{Type: Stone, Count: 100}); leftover != 0 {
+		t.Errorf("Expected 100 stone to fit in two slots, got %d left", leftover)
+	}
+	if leftover := container.AddStack(ItemStack{Type: Dirt, Count: 5}); leftover != 5 {
+		t.Errorf("Expected dirt not to fit, got %d left", leftover)
+	}
+	if container.CountItem(Stone) != 100 {
+		t.Errorf("Expected 100 stone, got %d", container.CountItem(Stone))
+	}
+
+	removed := container.Clear()
+	if len(removed) != 2 || !container.IsEmpty() {
+		t.Errorf("Expected two stacks to be cleared, got %d", len(removed))
+	}
+}
+
+func TestContainerFill(t *testing.T) {
+	container := NewContainer(ChestSlotCount)
+	loot := []ItemStack{
+		{Type: Rope, Count: 8},
+		{Type: IronPickaxe, Count: 1},
+		{Type: Air, Count: 3},
+	}
+
+	leftovers := container.Fill(loot, rand.New(rand.NewSource(1)))
+	if len(leftovers) != 0 {
+		t.Errorf("Expected all loot to fit, got %d leftovers", len(leftovers))
+	}
+	if container.CountItem(Rope) != 8 || container.CountItem(IronPickaxe) != 1 {
+		t.Error("Expected loot to be placed in the container")
+	}
+	for _, stack := range container.Slots {
+		if stack.Type == IronPickaxe && stack.Durability != NewToolStack(IronPickaxe).Durability {
+			t.Error("Expected loot tools to have full durability")
+		}
+	}
+
+	// 相同的种子生成相同的布局
+	other := NewContainer(ChestSlotCount)
+	other.Fill(loot, rand.New(rand.NewSource(1)))
+	for i := range container.Slots {
+		if container.Slots[i] != other.Slots[i] {
+			t.Fatal("Expected the same seed to fill the same slots")
+		}
+	}
+
+	// 槽位已满时返回放不下的物品
+	full := NewContainer(1)
+	leftovers = full.Fill([]ItemStack{{Type: Stone, Count: 10}, {Type: Dirt, Count: 10}}, rand.New(rand.NewSource(1)))
+	if len(leftovers) != 1 || leftovers[0].Type != Dirt {
+		t.Error("Expected dirt to be returned as leftover")
+	}
+}
@@ -0,0 +1,205 @@
+package entity
+
+const (
+	InventoryCraftingSize = 2 // 物品栏中合成网格的边长
+	TableCraftingSize     = 3 // 工作台合成网格的边长
+)
+
+// CraftingGrid 合成网格
+type CraftingGrid struct {
+	Size  int
+	Slots []ItemStack // 按行存储，索引为 y*Size+x
+}
+
+// NewCraftingGrid 创建指定边长的空合成网格
+func NewCraftingGrid(size int) *CraftingGrid {
+	slots := make([]ItemStack, size*size)
+	for i := range slots {
+		slots[i] = ItemStack{Type: Air, Count: 0}
+	}
+	return &CraftingGrid{Size: size, Slots: slots}
+}
+
+// Get 获取网格中(x, y)位置的物品
+func (g *CraftingGrid) Get(x, y int) ItemStack {
+	if x < 0 || y < 0 || x >= g.Size || y >= g.Size {
+		return ItemStack{Type: Air, Count: 0}
+	}
+	return g.Slots[y*g.Size+x]
+}
+
+// Set 设置网格中(x, y)位置的物品
+func (g *CraftingGrid) Set(x, y int, stack ItemStack) {
+	if x < 0 || y < 0 || x >= g.Size || y >= g.Size {
+		return
+	}
+	g.Slots[y*g.Size+x] = stack
+}
+
+// IsEmpty 检查网格是否为空
+func (g *CraftingGrid) IsEmpty() bool {
+	for _, stack := range g.Slots {
+		if stack.Type != Air && stack.Count > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Clear 清空网格，返回其中的物品
+func (g *CraftingGrid) Clear() []ItemStack {
+	removed := make([]ItemStack, 0)
+	for i := range g.Slots {
+		if g.Slots[i].Type != Air && g.Slots[i].Count > 0 {
+			removed = append(removed, g.Slots[i])
+		}
+		g.Slots[i] = ItemStack{Type: Air, Count: 0}
+	}
+	return removed
+}
+
+// Preview 获取当前摆放能合成的产物，不能合成时返回空气
+func (g *CraftingGrid) Preview(registry *RecipeRegistry) ItemStack {
+	if recipe := registry.Match(g); recipe != nil {
+		return recipe.Result
+	}
+	return ItemStack{Type: Air, Count: 0}
+}
+
+// Craft 合成一次：每个非空格子消耗一个材料，返回产物
+func (g *CraftingGrid) Craft(registry *RecipeRegistry) (ItemStack, bool) {
+	recipe := registry.Match(g)
+	if recipe == nil {
+		return ItemStack{Type: Air, Count: 0}, false
+	}
+
+	for i := range g.Slots {
+		if g.Slots[i].Type == Air {
+			continue
+		}
+		g.Slots[i].Count--
+		if g.Slots[i].Count <= 0 {
+			g.Slots[i] = ItemStack{Type: Air, Count: 0}
+		}
+	}
+	return recipe.Result, true
+}
+
+// matches 检查网格的摆放是否符合配方
+func (g *CraftingGrid) matches(recipe *Recipe) bool {
+	if !recipe.Shaped {
+		counts := make(map[ItemType]int)
+		for _, stack := range g.Slots {
+			if stack.Type != Air {
+				counts[stack.Type]++
+			}
+		}
+		required := recipe.IngredientCounts()
+		if len(counts) != len(required) {
+			return false
+		}
+		for itemType, count := range required {
+			if counts[itemType] != count {
+				return false
+			}
+		}
+		return true
+	}
+
+	// 有序配方：比较非空格子的包围盒与配方形状
+	minX, minY, maxX, maxY := g.Size, g.Size, -1, -1
+	for y := 0; y < g.Size; y++ {
+		for x := 0; x < g.Size; x++ {
+			if g.Get(x, y).Type == Air {
+				continue
+			}
+			if x < minX {
+				minX = x
+			}
+			if y < minY {
+				minY = y
+			}
+			if x > maxX {
+				maxX = x
+			}
+			if y > maxY {
+				maxY = y
+			}
+		}
+	}
+	if maxX < 0 || maxX-minX+1 != recipe.Width() || maxY-minY+1 != recipe.Height() {
+		return false
+	}
+
+	return g.matchesAt(recipe, minX, minY, false) || g.matchesAt(recipe, minX, minY, true)
+}
+
+// matchesAt 从(offsetX, offsetY)开始逐格比较配方形状，mirrored为true时水平镜像比较
+func (g *CraftingGrid) matchesAt(recipe *Recipe, offsetX, offsetY int, mirrored bool) bool {
+	width := recipe.Width()
+	for y, row := range recipe.Pattern {
+		for x := 0; x < width; x++ {
+			patternX := x
+			if mirrored {
+				patternX = width - 1 - x
+			}
+			expected := Air
+			if patternX < len(row) {
+				expected = row[patternX]
+			}
+			if g.Get(offsetX+x, offsetY+y).Type != expected {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// CraftToCursor 点击合成产物格：合成一次并把产物放到鼠标上
+// 鼠标上已有其他物品或放不下时不合成，返回是否合成成功
+func (inv *Inventory) CraftToCursor(grid *CraftingGrid, registry *RecipeRegistry) bool {
+	result := grid.Preview(registry)
+	if result.Type == Air {
+		return false
+	}
+	if inv.HasCursor() {
+		if !canStackWith(inv.Cursor, result) || inv.Cursor.Count+result.Count > GetMaxStackSize(result.Type) {
+			return false
+		}
+	}
+
+	crafted, _ := grid.Craft(registry)
+	if IsTool(crafted.Type) {
+		crafted = NewToolStack(crafted.Type)
+	}
+	if inv.HasCursor() {
+		inv.Cursor.Count += crafted.Count
+	} else {
+		inv.Cursor = crafted
+	}
+	return true
+}
+
+// CanCraftFromInventory 检查物品栏中的材料是否足够合成该配方
+func CanCraftFromInventory(inv *Inventory, recipe *Recipe) bool {
+	for itemType, count := range recipe.IngredientCounts() {
+		if inv.CountItem(itemType) < count {
+			return false
+		}
+	}
+	return true
+}
+
+// CraftFromInventory 直接消耗物品栏中的材料合成，返回产物
+func CraftFromInventory(inv *Inventory, recipe *Recipe) (ItemStack, bool) {
+	if !CanCraftFromInventory(inv, recipe) {
+		return ItemStack{Type: Air, Count: 0}, false
+	}
+	for itemType, count := range recipe.IngredientCounts() {
+		inv.RemoveItem(itemType, count)
+	}
+	if IsTool(recipe.Result.Type) {
+		return NewToolStack(recipe.Result.Type), true
+	}
+	return recipe.Result, true
+}
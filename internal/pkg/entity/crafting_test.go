@@ -0,0 +1,113 @@
+package entity
+
+import "testing"
+
+func testRegistry(t *testing.T) *RecipeRegistry {
+	registry, err := ParseRecipes([]byte(testRecipes))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	return registry
+}
+
+func TestShapedRecipeMatching(t *testing.T) {
+	registry := testRegistry(t)
+	grid := NewCraftingGrid(TableCraftingSize)
+
+	// 木棍配方放在网格任意位置都可以
+	grid.Set(2, 1, ItemStack{Type: Planks, Count: 1})
+	grid.Set(2, 2, ItemStack{Type: Planks, Count: 1})
+	if result := grid.Preview(registry); result.Type != Stick || result.Count != 4 {
+		t.Errorf("Expected 4 sticks, got %v x%d", result.Type, result.Count)
+	}
+
+	// 形状不对时不能合成
+	grid.Set(1, 2, ItemStack{Type: Planks, Count: 1})
+	if grid.Preview(registry).Type != Air {
+		t.Error("Expected no match for a wrong shape")
+	}
+
+	// 水平镜像的斧头也可以合成
+	grid.Clear()
+	grid.Set(1, 0, ItemStack{Type: Planks, Count: 1})
+	grid.Set(2, 0, ItemStack{Type: Planks, Count: 1})
+	grid.Set(2, 1, ItemStack{Type: Planks, Count: 1})
+	grid.Set(1, 1, ItemStack{Type: Stick, Count: 1})
+	grid.Set(1, 2, ItemStack{Type: Stick, Count: 1})
+	if grid.Preview(registry).Type != WoodenAxe {
+		t.Error("Expected mirrored axe pattern to match")
+	}
+
+	// 2x2网格放不下斧头配方
+	small := NewCraftingGrid(InventoryCraftingSize)
+	small.Set(0, 0, ItemStack{Type: Planks, Count: 1})
+	small.Set(1, 0, ItemStack{Type: Planks, Count: 1})
+	if small.Preview(registry).Type != Air {
+		t.Error("Expected no match for an incomplete pattern")
+	}
+}
+
+func TestShapelessRecipeAndCraft(t *testing.T) {
+	registry := testRegistry(t)
+	grid := NewCraftingGrid(InventoryCraftingSize)
+	grid.Set(1, 1, ItemStack{Type: Wood, Count: 2})
+
+	result, ok := grid.Craft(registry)
+	if !ok || result.Type != Planks || result.Count != 4 {
+		t.Fatalf("Expected to craft 4 planks, got %v x%d", result.Type, result.Count)
+	}
+	if grid.Get(1, 1).Count != 1 {
+		t.Errorf("Expected one wood to be consumed, got %d left", grid.Get(1, 1).Count)
+	}
+
+	grid.Craft(registry)
+	if !grid.IsEmpty() {
+		t.Error("Expected grid to be empty after using the last wood")
+	}
+	if _, ok := grid.Craft(registry); ok {
+		t.Error("Expected crafting with an empty grid to fail")
+	}
+}
+
+func TestCraftToCursor(t *testing.T) {
+	registry := testRegistry(t)
+	inventory := NewInventory()
+	grid := NewCraftingGrid(InventoryCraftingSize)
+	grid.Set(0, 0, ItemStack{Type: Wood, Count: 2})
+
+	if !inventory.CraftToCursor(grid, registry) || inventory.GetCursor().Count != 4 {
+		t.Fatal("Expected crafted planks on the cursor")
+	}
+	if !inventory.CraftToCursor(grid, registry) || inventory.GetCursor().Count != 8 {
+		t.Error("Expected crafted planks to stack on the cursor")
+	}
+
+	grid.Set(0, 0, ItemStack{Type: Wood, Count: 1})
+	inventory.Cursor = ItemStack{Type: Stone, Count: 1}
+	if inventory.CraftToCursor(grid, registry) {
+		t.Error("Expected crafting to fail while holding a different item")
+	}
+}
+
+func TestCraftFromInventory(t *testing.T) {
+	registry := testRegistry(t)
+	inventory := NewInventory()
+	inventory.Clear()
+	inventory.AddItem(Planks, 3)
+	inventory.AddItem(Stick, 2)
+
+	axe := registry.Search("wooden_axe")[0]
+	if !CanCraftFromInventory(inventory, axe) {
+		t.Fatal("Expected enough materials for the axe")
+	}
+	result, ok := CraftFromInventory(inventory, axe)
+	if !ok || result.Type != WoodenAxe || result.Durability == 0 {
+		t.Error("Expected a full durability wooden axe")
+	}
+	if inventory.CountItem(Planks) != 0 || inventory.CountItem(Stick) != 0 {
+		t.Error("Expected materials to be consumed")
+	}
+	if CanCraftFromInventory(inventory, axe) {
+		t.Error("Expected no materials left")
+	}
+}
@@ -0,0 +1,59 @@
+package entity
+
+// Hitbox 实体的碰撞箱（世界坐标）
+type Hitbox struct {
+	Left, Top, Right, Bottom float64
+}
+
+// NewHitbox 根据中心位置和大小创建碰撞箱
+func NewHitbox(centerX, centerY, width, height float64) Hitbox {
+	return Hitbox{centerX - width/2, centerY - height/2, centerX + width/2, centerY + height/2}
+}
+
+// Contains 检查点是否在碰撞箱内
+func (h Hitbox) Contains(x, y float64) bool {
+	return x > h.Left && x < h.Right && y > h.Top && y < h.Bottom
+}
+
+// Overlaps 检查两个碰撞箱是否重叠
+func (h Hitbox) Overlaps(other Hitbox) bool {
+	return h.Left < other.Right && h.Right > other.Left && h.Top < other.Bottom && h.Bottom > other.Top
+}
+
+// Size 获取碰撞箱的宽度和高度
+func (h Hitbox) Size() (float64, float64) {
+	return h.Right - h.Left, h.Bottom - h.Top
+}
+
+// Damageable 可以被武器、投射物和冲刺攻击伤害的实体（生物、训练假人等）
+type Damageable interface {
+	Target
+	Hitbox() Hitbox
+	// Hit 受到伤害和击退，返回是否命中（无敌或已死亡时不命中）
+	Hit(damage int, knockbackX, knockbackY float64) bool
+}
+
+// DamageableWorld 可以列出所有可被伤害实体的世界（由world.World实现）
+type DamageableWorld interface {
+	Damageables() []Damageable
+}
+
+// Mortal 有生命值的实体（生物、首领、玩家），训练假人没有生命值
+type Mortal interface {
+	GetHealth() (int, int)
+}
+
+// hitTarget 伤害目标，返回是否命中和目标实际失去的生命值（不超过剩余生命值）；
+// 没有生命值的目标（训练假人）实际伤害为0，不能用来生命偷取
+func hitTarget(target Damageable, damage int, knockbackX, knockbackY float64) (bool, int) {
+	mortal, ok := target.(Mortal)
+	if !ok {
+		return target.Hit(damage, knockbackX, knockbackY), 0
+	}
+	before, _ := mortal.GetHealth()
+	if !target.Hit(damage, knockbackX, knockbackY) {
+		return false, 0
+	}
+	after, _ := mortal.GetHealth()
+	return true, before - after
+}
@@ -0,0 +1,44 @@
+package entity
+
+import "testing"
+
+func TestHitbox(t *testing.T) {
+	box := NewHitbox(10, 20, 8, 4)
+	if box != (Hitbox{6, 18, 14, 22}) {
+		t.Fatalf("Unexpected hitbox %+v", box)
+	}
+	if !box.Contains(10, 20) || box.Contains(14, 20) {
+		t.Error("Expected Contains to include the interior and exclude the edges")
+	}
+	if !box.Overlaps(NewHitbox(15, 20, 4, 4)) || box.Overlaps(NewHitbox(16, 20, 4, 4)) {
+		t.Error("Expected Overlaps to detect touching interiors only")
+	}
+	if width, height := box.Size(); width != 8 || height != 4 {
+		t.Errorf("Expected size 8x4, got %vx%v", width, height)
+	}
+}
+
+func TestTrainingDummy(t *testing.T) {
+	var _ Damageable = (*TrainingDummy)(nil)
+	var _ Damageable = (*Mob)(nil)
+
+	dummy := NewTrainingDummy(100, 100)
+	if !dummy.Hit(50, 6, -3) || dummy.Hit(50, 6, -3) {
+		t.Fatal("Expected a hit followed by invulnerability")
+	}
+	for i := 0; i < DummyHurtDuration; i++ {
+		dummy.Update()
+	}
+	if !dummy.Hit(50, -6, -3) {
+		t.Fatal("Expected the dummy to be hittable again")
+	}
+	if dummy.Hits != 2 || dummy.TotalDamage != 100 || dummy.LastDamage != 50 {
+		t.Errorf("Unexpected dummy stats: %+v", dummy)
+	}
+	if x, y := dummy.GetPosition(); x != 100 || y != 100 {
+		t.Error("Expected the dummy to stay in place")
+	}
+	if dummy.Sway != -6 {
+		t.Errorf("Expected the dummy to sway with the knockback, got %v", dummy.Sway)
+	}
+}
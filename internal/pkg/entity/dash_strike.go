@@ -0,0 +1,75 @@
+package entity
+
+const (
+	DashCooldown        = 60  // 两次冲刺开始之间的最短帧数
+	DashStrikeDamage    = 3   // 冲刺攻击对经过的目标造成的伤害
+	DashStrikeKnockback = 8.0 // 冲刺攻击的击退速度
+	DashHitRefund       = 20  // 冲刺攻击每命中一个目标返还的冷却帧数
+)
+
+// IsDashStriking 返回玩家是否正在进行冲刺攻击（冲刺攻击期间无敌）
+func (p *Player) IsDashStriking() bool {
+	return p.Dashing && p.DashStrike
+}
+
+// strikeAlongDash 冲刺攻击：伤害并击退本帧冲刺经过的区域内的所有目标，
+// 每次冲刺对同一个目标只造成一次伤害，命中时返还部分冷却
+func (p *Player) strikeAlongDash(oldX, oldY float64) {
+	world, ok := p.World.(DamageableWorld)
+	if !ok {
+		return
+	}
+
+	half := float64(PlayerSize) / 2
+	swept := Hitbox{
+		Left:   min(oldX, p.X) - half,
+		Top:    min(oldY, p.Y) - half,
+		Right:  max(oldX, p.X) + half,
+		Bottom: max(oldY, p.Y) + half,
+	}
+	for _, target := range world.Damageables() {
+		if p.hasStruck(target) || !swept.Overlaps(target.Hitbox()) {
+			continue
+		}
+		p.dashStruck = append(p.dashStruck, target)
+
+		knockbackX, knockbackY := p.dashKnockback(target)
+		if hit, dealt := hitTarget(target, DashStrikeDamage, knockbackX, knockbackY); hit {
+			p.DashHit = true
+			p.StealLife(dealt)
+			p.DashCooldownTimer -= DashHitRefund
+			if p.DashCooldownTimer < 0 {
+				p.DashCooldownTimer = 0
+			}
+		}
+	}
+}
+
+// hasStruck 检查本次冲刺是否已经攻击过该目标
+func (p *Player) hasStruck(target Damageable) bool {
+	for _, struck := range p.dashStruck {
+		if struck == target {
+			return true
+		}
+	}
+	return false
+}
+
+// dashKnockback 冲刺攻击的击退方向：水平冲刺沿冲刺方向击飞，竖直冲刺把目标推向两侧
+func (p *Player) dashKnockback(target Damageable) (float64, float64) {
+	switch p.DashDirection {
+	case 0: // 右
+		return DashStrikeKnockback, -DashStrikeKnockback * KnockbackLift
+	case 1: // 左
+		return -DashStrikeKnockback, -DashStrikeKnockback * KnockbackLift
+	}
+	targetX, _ := target.GetPosition()
+	side := 1.0
+	if targetX < p.X {
+		side = -1
+	}
+	if p.DashDirection == 2 { // 上
+		return side * DashStrikeKnockback, -DashStrikeKnockback
+	}
+	return side * DashStrikeKnockback, -DashStrikeKnockback * KnockbackLift
+}
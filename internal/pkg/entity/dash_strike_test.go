@@ -0,0 +1,94 @@
+package entity
+
+import "testing"
+
+// newDashStrikeTest 创建站在玩家右侧冲刺路径上的训练假人和生物
+func newDashStrikeTest() (*Player, *TrainingDummy, *Mob) {
+	world := &behaviorWorld{MockWorld: NewMockWorld()}
+	player := NewPlayer(5*BlockSize, 5*BlockSize)
+	player.SetWorld(world)
+	player.DashStrike = true
+	dummy := NewTrainingDummy(player.X+3*BlockSize, player.Y)
+	mob := NewMob(MobCaveCrawler, player.X+6*BlockSize, player.Y)
+	world.targets = []Damageable{dummy, mob}
+	return player, dummy, mob
+}
+
+func TestDashStrikeDamagesTargetsInPath(t *testing.T) {
+	player, dummy, mob := newDashStrikeTest()
+	player.Dash(player.X+100, player.Y)
+	for player.Dashing {
+		player.updateDash()
+	}
+
+	if dummy.Hits != 1 || dummy.TotalDamage != DashStrikeDamage {
+		t.Errorf("Expected the dummy to be hit exactly once, got %d hits", dummy.Hits)
+	}
+	if mob.Health != mob.MaxHealth-DashStrikeDamage || mob.VX <= 0 {
+		t.Errorf("Expected the mob to be damaged and knocked forward, health %d vx %v", mob.Health, mob.VX)
+	}
+	if !player.DashHit {
+		t.Error("Expected the dash to register a hit")
+	}
+	if want := DashCooldown - 2*DashHitRefund; player.DashCooldownTimer != want {
+		t.Errorf("Expected each hit to refund cooldown, got %d want %d", player.DashCooldownTimer, want)
+	}
+
+	strikeTrails := 0
+	for _, trail := range player.GetDashTrails() {
+		if trail.Strike {
+			strikeTrails++
+		}
+	}
+	if strikeTrails == 0 || player.GetDashTrails()[0].Strike {
+		t.Error("Expected only trails after the first hit to be tinted")
+	}
+}
+
+func TestDashStrikeInvulnerability(t *testing.T) {
+	player, _, _ := newDashStrikeTest()
+	player.Dash(player.X+100, player.Y)
+	if player.TakeDamage(5, DamageMob) {
+		t.Error("Expected the player to be invulnerable while dash striking")
+	}
+
+	for player.Dashing {
+		player.updateDash()
+	}
+	if !player.TakeDamage(5, DamageMob) {
+		t.Error("Expected invulnerability to end with the dash")
+	}
+}
+
+func TestPlainDashDoesNotStrike(t *testing.T) {
+	player, dummy, _ := newDashStrikeTest()
+	player.DashStrike = false
+	player.Dash(player.X+100, player.Y)
+	if !player.TakeDamage(1, DamageMob) {
+		t.Error("Expected a plain dash not to grant invulnerability")
+	}
+	for player.Dashing {
+		player.updateDash()
+	}
+	if dummy.Hits != 0 || player.DashHit {
+		t.Error("Expected a plain dash not to damage anything")
+	}
+}
+
+func TestDashCooldown(t *testing.T) {
+	player := NewPlayer(0, 0)
+	player.Dash(100, 0)
+	player.Dashing = false
+
+	player.Dash(100, 0)
+	if player.Dashing {
+		t.Fatal("Expected dashing to be on cooldown")
+	}
+	for i := 0; i < DashCooldown; i++ {
+		player.Update()
+	}
+	player.Dash(100, 0)
+	if !player.Dashing {
+		t.Error("Expected to dash again after the cooldown")
+	}
+}
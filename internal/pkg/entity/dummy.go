@@ -0,0 +1,74 @@
+package entity
+
+const (
+	DummyWidth        = 24
+	DummyHeight       = 48
+	DummyHurtDuration = 10   // 受击后闪烁的帧数，期间不会再被命中
+	DummySwayDecay    = 0.85 // 受击后摇晃幅度每帧的衰减
+)
+
+// TrainingDummy 训练假人：固定在原地，不会死亡，记录受到的伤害，用于测试武器和冲刺攻击
+type TrainingDummy struct {
+	X, Y        float64 // 中心位置
+	HurtTimer   int
+	Sway        float64 // 被击退时的摇晃偏移（只影响绘制）
+	LastDamage  int     // 最近一次受到的伤害
+	TotalDamage int     // 累计受到的伤害
+	Hits        int     // 被命中的次数
+}
+
+// NewTrainingDummy 在指定位置（中心）创建训练假人
+func NewTrainingDummy(x, y float64) *TrainingDummy {
+	return &TrainingDummy{X: x, Y: y}
+}
+
+// GetPosition 获取假人的中心位置
+func (d *TrainingDummy) GetPosition() (float64, float64) {
+	return d.X, d.Y
+}
+
+// Hitbox 获取假人的碰撞箱
+func (d *TrainingDummy) Hitbox() Hitbox {
+	return NewHitbox(d.X, d.Y, DummyWidth, DummyHeight)
+}
+
+// Tick 更新一帧（实现Entity）
+func (d *TrainingDummy) Tick(world EntityWorld) {
+	d.Update()
+}
+
+// Bounds 获取假人占据的范围（实现Entity）
+func (d *TrainingDummy) Bounds() Hitbox {
+	return d.Hitbox()
+}
+
+// IsRemoved 假人不会被移除（实现Entity）
+func (d *TrainingDummy) IsRemoved() bool {
+	return false
+}
+
+// DrawLayer 获取假人的绘制层（实现Entity）
+func (d *TrainingDummy) DrawLayer() DrawLayer {
+	return LayerProps
+}
+
+// Hit 记录伤害，击退只会让假人摇晃
+func (d *TrainingDummy) Hit(damage int, knockbackX, knockbackY float64) bool {
+	if d.HurtTimer > 0 || damage <= 0 {
+		return false
+	}
+	d.HurtTimer = DummyHurtDuration
+	d.Sway = knockbackX
+	d.LastDamage = damage
+	d.TotalDamage += damage
+	d.Hits++
+	return true
+}
+
+// Update 更新受击闪烁和摇晃
+func (d *TrainingDummy) Update() {
+	if d.HurtTimer > 0 {
+		d.HurtTimer--
+	}
+	d.Sway *= DummySwayDecay
+}
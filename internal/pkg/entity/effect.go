@@ -0,0 +1,206 @@
+package entity
+
+const (
+	MaxEffectLevel       = 3   // 状态效果的最大叠加等级
+	PoisonInterval       = 40  // 中毒伤害间隔帧数（每级缩短）
+	RegenerationInterval = 50  // 再生回血间隔帧数（每级缩短）
+	PotionDuration       = 900 // 药水效果持续时间（15秒）
+)
+
+// EffectType 状态效果类型
+type EffectType int
+
+const (
+	EffectNone         EffectType = iota
+	EffectSwiftness               // 迅捷：提升移动速度
+	EffectFeatherfall             // 轻羽：减弱重力并免疫摔落伤害
+	EffectLeaping                 // 跳跃：增加空中跳跃次数
+	EffectLongDash                // 疾冲：增加冲刺距离和持续时间
+	EffectPoison                  // 中毒：持续受到伤害
+	EffectRegeneration            // 再生：持续回复生命值
+)
+
+// String 返回状态效果的名称
+func (e EffectType) String() string {
+	switch e {
+	case EffectSwiftness:
+		return "Swiftness"
+	case EffectFeatherfall:
+		return "Featherfall"
+	case EffectLeaping:
+		return "Leaping"
+	case EffectLongDash:
+		return "Long Dash"
+	case EffectPoison:
+		return "Poison"
+	case EffectRegeneration:
+		return "Regeneration"
+	}
+	return "None"
+}
+
+// StatusEffect 一个正在生效的限时状态效果
+type StatusEffect struct {
+	Type     EffectType
+	Level    int // 效果等级，从1开始
+	Duration int // 剩余持续帧数
+}
+
+// PlayerStats 玩家的实际能力数值（基础常量叠加状态效果和天赋之后）
+type PlayerStats struct {
+	Speed        float64 // 移动速度
+	JumpPower    float64 // 跳跃力度
+	Gravity      float64 // 重力加速度
+	AirJumps     int     // 最大跳跃次数（含地面起跳）
+	DashDistance float64 // 每帧冲刺距离
+	DashDuration int     // 冲刺持续帧数
+	FallDamage   bool    // 是否受到摔落伤害
+	MiningSpeed  float64 // 挖掘速度倍率
+	MagnetBonus  float64 // 掉落物吸附半径增加的像素
+	LifeSteal    float64 // 造成伤害时回复生命值的比例
+}
+
+// itemEffects 可以使用的物品及其提供的状态效果
+var itemEffects = map[ItemType]StatusEffect{
+	SwiftnessPotion:    {Type: EffectSwiftness, Level: 1, Duration: PotionDuration},
+	FeatherfallPotion:  {Type: EffectFeatherfall, Level: 1, Duration: PotionDuration},
+	LeapingPotion:      {Type: EffectLeaping, Level: 1, Duration: PotionDuration},
+	DashPotion:         {Type: EffectLongDash, Level: 1, Duration: PotionDuration},
+	RegenerationPotion: {Type: EffectRegeneration, Level: 1, Duration: PotionDuration / 3},
+}
+
+// GetItemEffect 获取物品使用后提供的状态效果
+func GetItemEffect(itemType ItemType) (StatusEffect, bool) {
+	effect, exists := itemEffects[itemType]
+	return effect, exists
+}
+
+// ApplyEffect 给玩家施加状态效果
+// 已有同类效果时等级叠加（不超过MaxEffectLevel），持续时间取较长者
+func (p *Player) ApplyEffect(effect StatusEffect) {
+	if effect.Type == EffectNone || effect.Level <= 0 || effect.Duration <= 0 {
+		return
+	}
+
+	for i := range p.Effects {
+		active := &p.Effects[i]
+		if active.Type != effect.Type {
+			continue
+		}
+		active.Level += effect.Level
+		if active.Level > MaxEffectLevel {
+			active.Level = MaxEffectLevel
+		}
+		if effect.Duration > active.Duration {
+			active.Duration = effect.Duration
+		}
+		return
+	}
+
+	if effect.Level > MaxEffectLevel {
+		effect.Level = MaxEffectLevel
+	}
+	p.Effects = append(p.Effects, effect)
+}
+
+// UseItem 使用物品：食物回复生命值，药水等提供状态效果，返回是否成功使用
+func (p *Player) UseItem(itemType ItemType) bool {
+	// 食物在未满血时回复生命值
+	if food := GetFoodValue(itemType); food > 0 {
+		if p.Health >= p.MaxHealth {
+			return false
+		}
+		p.Heal(food)
+		return true
+	}
+
+	effect, exists := GetItemEffect(itemType)
+	if !exists {
+		return false
+	}
+	p.ApplyEffect(effect)
+	return true
+}
+
+// GetEffectLevel 获取指定状态效果的当前等级，未生效时返回0
+func (p *Player) GetEffectLevel(effectType EffectType) int {
+	for _, effect := range p.Effects {
+		if effect.Type == effectType {
+			return effect.Level
+		}
+	}
+	return 0
+}
+
+// HasEffect 检查玩家是否拥有指定状态效果
+func (p *Player) HasEffect(effectType EffectType) bool {
+	return p.GetEffectLevel(effectType) > 0
+}
+
+// GetEffects 获取当前生效的状态效果用于渲染
+func (p *Player) GetEffects() []StatusEffect {
+	return p.Effects
+}
+
+// ClearEffects 清除所有状态效果
+func (p *Player) ClearEffects() {
+	p.Effects = p.Effects[:0]
+}
+
+// Stats 计算玩家当前的实际能力数值
+func (p *Player) Stats() PlayerStats {
+	stats := PlayerStats{
+		Speed:        PlayerSpeed,
+		JumpPower:    JumpPower,
+		Gravity:      Gravity,
+		AirJumps:     DoubleJumpMax,
+		DashDistance: DashDistance,
+		DashDuration: DashDuration,
+		FallDamage:   true,
+		MiningSpeed:  1,
+	}
+
+	for _, effect := range p.Effects {
+		level := float64(effect.Level)
+		switch effect.Type {
+		case EffectSwiftness:
+			stats.Speed *= 1 + 0.3*level
+		case EffectFeatherfall:
+			stats.Gravity /= 1 + level
+			stats.FallDamage = false
+		case EffectLeaping:
+			stats.AirJumps += effect.Level
+		case EffectLongDash:
+			stats.DashDistance += 3 * level
+			stats.DashDuration += 5 * effect.Level
+		}
+	}
+	p.applyPerks(&stats)
+	return stats
+}
+
+// updateEffects 更新状态效果：结算持续伤害和回复，移除到期的效果
+func (p *Player) updateEffects() {
+	for i := len(p.Effects) - 1; i >= 0; i-- {
+		effect := &p.Effects[i]
+		effect.Duration--
+
+		switch effect.Type {
+		case EffectPoison:
+			// 中毒不会致死，最多将生命值降到1
+			interval := PoisonInterval / effect.Level
+			if effect.Duration%interval == 0 && p.Health > 1 && p.InvulnerableTimer == 0 {
+				p.TakeDamage(1, DamagePoison)
+			}
+		case EffectRegeneration:
+			interval := RegenerationInterval / effect.Level
+			if effect.Duration%interval == 0 {
+				p.Heal(1)
+			}
+		}
+
+		if effect.Duration <= 0 {
+			p.Effects = append(p.Effects[:i], p.Effects[i+1:]...)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package entity
+
+import (
+	"testing"
+)
+
+func TestApplyEffectStacking(t *testing.T) {
+	player := NewPlayer(0, 0)
+
+	player.ApplyEffect(StatusEffect{Type: EffectSwiftness, Level: 1, Duration: 100})
+	if player.GetEffectLevel(EffectSwiftness) != 1 {
+		t.Errorf("Expected swiftness level 1, got %d", player.GetEffectLevel(EffectSwiftness))
+	}
+
+	// 同类效果叠加等级，持续时间取较长者
+	player.ApplyEffect(StatusEffect{Type: EffectSwiftness, Level: 1, Duration: 50})
+	if len(player.GetEffects()) != 1 {
+		t.Fatalf("Expected stacked effect to reuse the same entry, got %d effects", len(player.GetEffects()))
+	}
+	effect := player.GetEffects()[0]
+	if effect.Level != 2 || effect.Duration != 100 {
+		t.Errorf("Expected level 2 with duration 100, got level %d duration %d", effect.Level, effect.Duration)
+	}
+
+	// 等级不超过上限
+	for i := 0; i < 5; i++ {
+		player.ApplyEffect(StatusEffect{Type: EffectSwiftness, Level: 1, Duration: 100})
+	}
+	if player.GetEffectLevel(EffectSwiftness) != MaxEffectLevel {
+		t.Errorf("Expected level capped at %d, got %d", MaxEffectLevel, player.GetEffectLevel(EffectSwiftness))
+	}
+}
+
+func TestEffectExpiration(t *testing.T) {
+	player := NewPlayer(0, 0)
+	player.ApplyEffect(StatusEffect{Type: EffectLeaping, Level: 1, Duration: 3})
+
+	for i := 0; i < 3; i++ {
+		player.Update()
+	}
+
+	if player.HasEffect(EffectLeaping) {
+		t.Error("Expected effect to expire after its duration")
+	}
+}
+
+func TestEffectsModifyStats(t *testing.T) {
+	player := NewPlayer(0, 0)
+
+	// 没有效果时与基础常量一致
+	stats := player.Stats()
+	if stats.Speed != PlayerSpeed || stats.JumpPower != JumpPower || stats.AirJumps != DoubleJumpMax ||
+		stats.DashDistance != DashDistance || stats.DashDuration != DashDuration || !stats.FallDamage {
+		t.Errorf("Expected base stats without effects, got %+v", stats)
+	}
+
+	player.ApplyEffect(StatusEffect{Type: EffectSwiftness, Level: 1, Duration: 100})
+	player.ApplyEffect(StatusEffect{Type: EffectLeaping, Level: 2, Duration: 100})
+	player.ApplyEffect(StatusEffect{Type: EffectLongDash, Level: 1, Duration: 100})
+	player.ApplyEffect(StatusEffect{Type: EffectFeatherfall, Level: 1, Duration: 100})
+	stats = player.Stats()
+
+	if stats.Speed <= PlayerSpeed {
+		t.Errorf("Expected swiftness to increase speed, got %f", stats.Speed)
+	}
+	if stats.AirJumps != DoubleJumpMax+2 {
+		t.Errorf("Expected %d air jumps, got %d", DoubleJumpMax+2, stats.AirJumps)
+	}
+	if stats.DashDistance <= DashDistance || stats.DashDuration <= DashDuration {
+		t.Errorf("Expected longer dash, got distance %f duration %d", stats.DashDistance, stats.DashDuration)
+	}
+	if stats.Gravity >= Gravity || stats.FallDamage {
+		t.Errorf("Expected featherfall to reduce gravity and prevent fall damage, got %+v", stats)
+	}
+
+	// 实际行为使用效果后的数值
+	player.MoveHorizontal(1)
+	if player.VX != stats.Speed {
+		t.Errorf("Expected VX=%f with swiftness, got %f", stats.Speed, player.VX)
+	}
+
+	player.Jump()
+	if player.DoubleJump != stats.AirJumps-1 {
+		t.Errorf("Expected %d remaining jumps, got %d", stats.AirJumps-1, player.DoubleJump)
+	}
+}
+
+func TestPoisonAndRegeneration(t *testing.T) {
+	player := NewPlayer(0, 0)
+	player.SetWorld(NewMockWorld())
+
+	// 中毒持续掉血，但不会致死
+	player.Health = 3
+	player.ApplyEffect(StatusEffect{Type: EffectPoison, Level: 1, Duration: PoisonInterval * 10})
+	for i := 0; i < PoisonInterval*10; i++ {
+		player.Update()
+		player.SetPosition(0, 0)
+	}
+	if player.Health != 1 {
+		t.Errorf("Expected poison to leave the player at 1 health, got %d", player.Health)
+	}
+	if player.IsDead() {
+		t.Error("Expected poison not to kill the player")
+	}
+
+	// 再生持续回血
+	player.ApplyEffect(StatusEffect{Type: EffectRegeneration, Level: 1, Duration: RegenerationInterval * 4})
+	for i := 0; i < RegenerationInterval*4; i++ {
+		player.Update()
+		player.SetPosition(0, 0)
+	}
+	if player.Health <= 1 {
+		t.Errorf("Expected regeneration to restore health, got %d", player.Health)
+	}
+}
+
+func TestUseItemAppliesEffect(t *testing.T) {
+	player := NewPlayer(0, 0)
+
+	if !player.UseItem(SwiftnessPotion) {
+		t.Error("Expected swiftness potion to be usable")
+	}
+	if !player.HasEffect(EffectSwiftness) {
+		t.Error("Expected swiftness effect after drinking the potion")
+	}
+
+	if player.UseItem(Stone) {
+		t.Error("Expected stone not to be usable")
+	}
+}
+
+func TestHazardAppliesEffect(t *testing.T) {
+	mockWorld := NewMockWorld()
+	mockWorld.AddBlockWithType(2, 2, SpikeBlock)
+
+	player := NewPlayer(float64(2*BlockSize+PlayerSize/2), float64(2*BlockSize+PlayerSize/2))
+	player.SetWorld(mockWorld)
+	player.Update()
+
+	if !player.HasEffect(EffectPoison) {
+		t.Error("Expected spikes to poison the player")
+	}
+}
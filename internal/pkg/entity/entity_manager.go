@@ -0,0 +1,333 @@
+package entity
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// EntityCellSize 空间哈希一个格子的边长（像素）
+const EntityCellSize = 64
+
+// DrawLayer 实体的绘制层，层号小的先绘制
+type DrawLayer int
+
+const (
+	LayerItems       DrawLayer = iota // 掉落物
+	LayerProps                        // 训练假人等固定在原地的实体
+	LayerMobs                         // 生物
+	LayerProjectiles                  // 投射物
+)
+
+// Entity 由实体管理器统一更新、查询和绘制的实体（掉落物、生物、投射物、训练假人等）
+// 实体必须是指针类型，管理器用它查找实体的ID
+type Entity interface {
+	Target
+	// Tick 更新一帧
+	Tick(world EntityWorld)
+	// Bounds 获取实体占据的范围，用于空间查询
+	Bounds() Hitbox
+	// IsRemoved 返回实体是否已经可以从世界中移除（死亡、过期等）
+	IsRemoved() bool
+	// DrawLayer 获取实体的绘制层
+	DrawLayer() DrawLayer
+}
+
+// EntityWorld 实体更新时可以访问的世界（由world.World实现）
+type EntityWorld interface {
+	World
+	GetPlayer() *Player
+	// DamageablesIn 获取与范围重叠的可以被伤害的实体
+	DamageablesIn(area Hitbox) []Damageable
+	// Rng 获取世界的随机数（由世界种子决定），实体的随机行为使用它
+	Rng() *rand.Rand
+}
+
+// EntityID 实体在管理器中的ID，从1开始递增，不会被重用
+type EntityID uint64
+
+// cellRange 实体在空间哈希中占据的格子范围
+type cellRange struct {
+	minX, minY, maxX, maxY int
+}
+
+// entityEntry 管理器中的一个实体
+type entityEntry struct {
+	id      EntityID
+	entity  Entity
+	cells   cellRange
+	removed bool   // 已经通过Remove移除，等待Flush
+	stamp   uint64 // 最近一次被查询收集的编号，用于去重
+}
+
+// live 检查实体是否仍然在世界中
+func (e *entityEntry) live() bool {
+	return !e.removed && !e.entity.IsRemoved()
+}
+
+// EntityManager 实体管理器：为实体分配稳定的ID，按加入顺序更新，延迟移除，
+// 并维护空间哈希用于按半径和矩形查询附近的实体
+type EntityManager struct {
+	nextID  EntityID
+	order   []*entityEntry // 按加入顺序排列
+	byID    map[EntityID]*entityEntry
+	byValue map[Entity]*entityEntry
+	cells   map[GridPos][]*entityEntry
+	stamp   uint64
+}
+
+// NewEntityManager 创建空的实体管理器
+func NewEntityManager() *EntityManager {
+	return &EntityManager{
+		byID:    make(map[EntityID]*entityEntry),
+		byValue: make(map[Entity]*entityEntry),
+		cells:   make(map[GridPos][]*entityEntry),
+	}
+}
+
+// Add 添加实体并返回它的ID，已经在管理器中的实体返回原来的ID
+func (m *EntityManager) Add(e Entity) EntityID {
+	if entry, exists := m.byValue[e]; exists {
+		return entry.id
+	}
+	m.nextID++
+	entry := &entityEntry{id: m.nextID, entity: e, cells: boundsCells(e.Bounds())}
+	m.order = append(m.order, entry)
+	m.byID[entry.id] = entry
+	m.byValue[e] = entry
+	m.insert(entry)
+	return entry.id
+}
+
+// Get 根据ID获取仍然在世界中的实体
+func (m *EntityManager) Get(id EntityID) (Entity, bool) {
+	entry, exists := m.byID[id]
+	if !exists || !entry.live() {
+		return nil, false
+	}
+	return entry.entity, true
+}
+
+// ID 获取实体的ID
+func (m *EntityManager) ID(e Entity) (EntityID, bool) {
+	entry, exists := m.byValue[e]
+	if !exists {
+		return 0, false
+	}
+	return entry.id, true
+}
+
+// Has 检查实体是否仍然在世界中（没有被移除，也没有死亡或过期）
+func (m *EntityManager) Has(e Entity) bool {
+	entry, exists := m.byValue[e]
+	return exists && entry.live()
+}
+
+// Remove 移除实体：实体立即不再出现在查询和更新中，到Flush时才真正释放
+func (m *EntityManager) Remove(e Entity) {
+	if entry, exists := m.byValue[e]; exists {
+		entry.removed = true
+	}
+}
+
+// RemoveID 根据ID移除实体
+func (m *EntityManager) RemoveID(id EntityID) {
+	if entry, exists := m.byID[id]; exists {
+		entry.removed = true
+	}
+}
+
+// Update 按加入顺序更新所有仍然在世界中的实体，并根据新的位置更新空间哈希
+// 更新过程中加入的实体从下一帧开始更新
+func (m *EntityManager) Update(world EntityWorld) {
+	count := len(m.order)
+	for i := 0; i < count; i++ {
+		entry := m.order[i]
+		if !entry.live() {
+			continue
+		}
+		entry.entity.Tick(world)
+		m.reindex(entry)
+	}
+}
+
+// Flush 释放被移除、死亡或过期的实体，返回被释放的实体
+func (m *EntityManager) Flush() []Entity {
+	var released []Entity
+	kept := m.order[:0]
+	for _, entry := range m.order {
+		if entry.live() {
+			kept = append(kept, entry)
+			continue
+		}
+		released = append(released, entry.entity)
+		m.unindex(entry)
+		delete(m.byID, entry.id)
+		delete(m.byValue, entry.entity)
+	}
+	for i := len(kept); i < len(m.order); i++ {
+		m.order[i] = nil
+	}
+	m.order = kept
+	return released
+}
+
+// Clear 立即移除所有实体，ID继续递增
+func (m *EntityManager) Clear() {
+	m.order = nil
+	m.byID = make(map[EntityID]*entityEntry)
+	m.byValue = make(map[Entity]*entityEntry)
+	m.cells = make(map[GridPos][]*entityEntry)
+}
+
+// Reindex 在实体的位置被直接修改后（不是通过Update）更新它在空间哈希中的位置
+func (m *EntityManager) Reindex(e Entity) {
+	if entry, exists := m.byValue[e]; exists {
+		m.reindex(entry)
+	}
+}
+
+// Len 获取仍然在世界中的实体数量
+func (m *EntityManager) Len() int {
+	count := 0
+	for _, entry := range m.order {
+		if entry.live() {
+			count++
+		}
+	}
+	return count
+}
+
+// All 按加入顺序获取所有仍然在世界中的实体
+func (m *EntityManager) All() []Entity {
+	entities := make([]Entity, 0, len(m.order))
+	for _, entry := range m.order {
+		if entry.live() {
+			entities = append(entities, entry.entity)
+		}
+	}
+	return entities
+}
+
+// ByLayer 按绘制层获取所有仍然在世界中的实体，同一层的实体保持加入顺序
+func (m *EntityManager) ByLayer() []Entity {
+	entities := m.All()
+	SortByLayer(entities)
+	return entities
+}
+
+// SortByLayer 按绘制层排序实体，同一层的实体保持原来的顺序
+func SortByLayer(entities []Entity) {
+	sort.SliceStable(entities, func(i, j int) bool {
+		return entities[i].DrawLayer() < entities[j].DrawLayer()
+	})
+}
+
+// QueryRect 获取范围与矩形重叠的实体，按ID排序
+func (m *EntityManager) QueryRect(area Hitbox) []Entity {
+	var found []*entityEntry
+	m.collect(area, func(entry *entityEntry) {
+		if entry.entity.Bounds().Overlaps(area) {
+			found = append(found, entry)
+		}
+	})
+	return sortedEntities(found)
+}
+
+// QueryRadius 获取范围与圆（中心和半径）相交的实体，按ID排序
+func (m *EntityManager) QueryRadius(x, y, radius float64) []Entity {
+	area := Hitbox{x - radius, y - radius, x + radius, y + radius}
+	var found []*entityEntry
+	m.collect(area, func(entry *entityEntry) {
+		bounds := entry.entity.Bounds()
+		dx := x - math.Max(bounds.Left, math.Min(x, bounds.Right))
+		dy := y - math.Max(bounds.Top, math.Min(y, bounds.Bottom))
+		if dx*dx+dy*dy <= radius*radius {
+			found = append(found, entry)
+		}
+	})
+	return sortedEntities(found)
+}
+
+// collect 对空间哈希中与矩形所在格子重叠的每个仍然在世界中的实体调用一次fn
+func (m *EntityManager) collect(area Hitbox, fn func(entry *entityEntry)) {
+	m.stamp++
+	r := boundsCells(area)
+	for cx := r.minX; cx <= r.maxX; cx++ {
+		for cy := r.minY; cy <= r.maxY; cy++ {
+			for _, entry := range m.cells[GridPos{cx, cy}] {
+				if entry.stamp == m.stamp || !entry.live() {
+					continue
+				}
+				entry.stamp = m.stamp
+				fn(entry)
+			}
+		}
+	}
+}
+
+// sortedEntities 按ID排序查询结果，保证查询顺序稳定
+func sortedEntities(entries []*entityEntry) []Entity {
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].id < entries[j].id
+	})
+	entities := make([]Entity, len(entries))
+	for i, entry := range entries {
+		entities[i] = entry.entity
+	}
+	return entities
+}
+
+// boundsCells 计算范围占据的空间哈希格子
+func boundsCells(bounds Hitbox) cellRange {
+	return cellRange{
+		minX: int(math.Floor(bounds.Left / EntityCellSize)),
+		minY: int(math.Floor(bounds.Top / EntityCellSize)),
+		maxX: int(math.Floor(bounds.Right / EntityCellSize)),
+		maxY: int(math.Floor(bounds.Bottom / EntityCellSize)),
+	}
+}
+
+// insert 把实体加入它占据的格子
+func (m *EntityManager) insert(entry *entityEntry) {
+	for cx := entry.cells.minX; cx <= entry.cells.maxX; cx++ {
+		for cy := entry.cells.minY; cy <= entry.cells.maxY; cy++ {
+			cell := GridPos{cx, cy}
+			m.cells[cell] = append(m.cells[cell], entry)
+		}
+	}
+}
+
+// unindex 把实体从它占据的格子中移除
+func (m *EntityManager) unindex(entry *entityEntry) {
+	for cx := entry.cells.minX; cx <= entry.cells.maxX; cx++ {
+		for cy := entry.cells.minY; cy <= entry.cells.maxY; cy++ {
+			cell := GridPos{cx, cy}
+			entries := m.cells[cell]
+			for i, other := range entries {
+				if other == entry {
+					last := len(entries) - 1
+					entries[i], entries[last] = entries[last], nil
+					entries = entries[:last]
+					break
+				}
+			}
+			if len(entries) == 0 {
+				delete(m.cells, cell)
+			} else {
+				m.cells[cell] = entries
+			}
+		}
+	}
+}
+
+// reindex 实体占据的格子变化时更新空间哈希
+func (m *EntityManager) reindex(entry *entityEntry) {
+	cells := boundsCells(entry.entity.Bounds())
+	if cells == entry.cells {
+		return
+	}
+	m.unindex(entry)
+	entry.cells = cells
+	m.insert(entry)
+}
@@ -0,0 +1,171 @@
+package entity
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// testEntity 测试用的实体：每帧按速度移动，记录被更新的次数
+type testEntity struct {
+	x, y, vx  float64
+	size      float64
+	layer     DrawLayer
+	removed   bool
+	ticks     int
+	spawnNext *EntityManager // 不为nil时在更新中加入一个新的实体
+}
+
+func (e *testEntity) GetPosition() (float64, float64) { return e.x, e.y }
+func (e *testEntity) Bounds() Hitbox                  { return NewHitbox(e.x, e.y, e.size, e.size) }
+func (e *testEntity) IsRemoved() bool                 { return e.removed }
+func (e *testEntity) DrawLayer() DrawLayer            { return e.layer }
+
+func (e *testEntity) Tick(world EntityWorld) {
+	e.ticks++
+	e.x += e.vx
+	if e.spawnNext != nil {
+		e.spawnNext.Add(&testEntity{size: 8})
+		e.spawnNext = nil
+	}
+}
+
+// entityTestWorld 测试用的实体世界
+type entityTestWorld struct {
+	*MockWorld
+	player *Player
+	rng    *rand.Rand
+}
+
+func (w *entityTestWorld) GetPlayer() *Player                { return w.player }
+func (w *entityTestWorld) DamageablesIn(Hitbox) []Damageable { return nil }
+func (w *entityTestWorld) Rng() *rand.Rand                   { return w.rng }
+
+func newEntityTestWorld() *entityTestWorld {
+	return &entityTestWorld{MockWorld: NewMockWorld(), player: NewPlayer(0, 0), rng: rand.New(rand.NewSource(1))}
+}
+
+func TestEntityManagerAssignsStableIDs(t *testing.T) {
+	m := NewEntityManager()
+	a, b := &testEntity{size: 8}, &testEntity{size: 8}
+	idA, idB := m.Add(a), m.Add(b)
+	if idA == idB || m.Add(a) != idA {
+		t.Fatalf("Expected distinct, stable IDs, got %d and %d", idA, idB)
+	}
+
+	m.Remove(a)
+	m.Flush()
+	if _, exists := m.Get(idA); exists {
+		t.Error("Expected removed entity to be gone")
+	}
+	if got, exists := m.Get(idB); !exists || got != b {
+		t.Error("Expected other entity to keep its ID")
+	}
+	if idC := m.Add(&testEntity{size: 8}); idC <= idB {
+		t.Errorf("Expected IDs not to be reused, got %d after %d", idC, idB)
+	}
+}
+
+func TestEntityManagerDefersRemoval(t *testing.T) {
+	m := NewEntityManager()
+	a := &testEntity{size: 8}
+	m.Add(a)
+
+	m.Remove(a)
+	if m.Has(a) || m.Len() != 0 || len(m.QueryRadius(0, 0, 10)) != 0 {
+		t.Error("Expected removed entity to leave queries immediately")
+	}
+	if _, exists := m.ID(a); !exists {
+		t.Error("Expected removed entity to keep its ID until flushed")
+	}
+	m.Update(newEntityTestWorld())
+	if a.ticks != 0 {
+		t.Error("Expected removed entity not to be updated")
+	}
+
+	m.Flush()
+	if _, exists := m.ID(a); exists {
+		t.Error("Expected flushed entity to be released")
+	}
+}
+
+func TestEntityManagerUpdateAndRemovedFlag(t *testing.T) {
+	m := NewEntityManager()
+	spawner := &testEntity{size: 8}
+	spawner.spawnNext = m
+	dying := &testEntity{size: 8}
+	m.Add(spawner)
+	m.Add(dying)
+
+	m.Update(newEntityTestWorld())
+	if spawner.ticks != 1 || dying.ticks != 1 {
+		t.Fatal("Expected every entity to be updated once")
+	}
+	all := m.All()
+	if len(all) != 3 || all[2].(*testEntity).ticks != 0 {
+		t.Fatal("Expected entity added during the update to wait for the next frame")
+	}
+
+	dying.removed = true
+	if m.Has(dying) {
+		t.Error("Expected entity with removed flag to leave the world")
+	}
+	m.Flush()
+	if m.Len() != 2 {
+		t.Errorf("Expected 2 entities after flush, got %d", m.Len())
+	}
+}
+
+func TestEntityManagerSpatialQueries(t *testing.T) {
+	m := NewEntityManager()
+	near := &testEntity{x: 10, y: 10, size: 8}
+	far := &testEntity{x: 500, y: 10, size: 8}
+	mover := &testEntity{x: 300, y: 10, size: 8, vx: -100}
+	m.Add(near)
+	m.Add(far)
+	m.Add(mover)
+
+	found := m.QueryRadius(0, 0, 50)
+	if len(found) != 1 || found[0] != near {
+		t.Fatalf("Expected only the near entity, got %d", len(found))
+	}
+	if found := m.QueryRect(Hitbox{Left: 400, Top: 0, Right: 600, Bottom: 20}); len(found) != 1 || found[0] != far {
+		t.Fatal("Expected rectangle query to find the far entity")
+	}
+
+	// 更新后按新的位置查询
+	m.Update(newEntityTestWorld())
+	m.Update(newEntityTestWorld())
+	found = m.QueryRadius(0, 0, 120)
+	if len(found) != 2 || found[0] != near || found[1] != mover {
+		t.Fatalf("Expected moved entity to be found in ID order, got %d", len(found))
+	}
+
+	// 直接修改位置后需要重新索引
+	far.x = 20
+	m.Reindex(far)
+	if found := m.QueryRect(Hitbox{Left: 0, Top: 0, Right: 40, Bottom: 20}); len(found) != 2 {
+		t.Errorf("Expected reindexed entity to be found, got %d", len(found))
+	}
+	if found := m.QueryRect(Hitbox{Left: 400, Top: 0, Right: 600, Bottom: 20}); len(found) != 0 {
+		t.Error("Expected reindexed entity to leave its old cell")
+	}
+}
+
+func TestEntityManagerByLayer(t *testing.T) {
+	m := NewEntityManager()
+	projectile := &testEntity{layer: LayerProjectiles}
+	item := &testEntity{layer: LayerItems}
+	mob := &testEntity{layer: LayerMobs}
+	item2 := &testEntity{layer: LayerItems}
+	for _, e := range []*testEntity{projectile, item, mob, item2} {
+		m.Add(e)
+	}
+
+	layered := m.ByLayer()
+	expected := []Entity{item, item2, mob, projectile}
+	for i := range expected {
+		if layered[i] != expected[i] {
+			t.Fatalf("Unexpected draw order at %d", i)
+		}
+	}
+}
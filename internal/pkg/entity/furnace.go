@@ -0,0 +1,174 @@
+package entity
+
+const (
+	// 熔炉的槽位
+	FurnaceInput     = 0 // 待烧制的物品
+	FurnaceFuel      = 1 // 燃料
+	FurnaceOutput    = 2 // 产物
+	FurnaceSlotCount = 3
+
+	// SmeltTime 烧制一个物品所需的帧数
+	SmeltTime = 200
+)
+
+// BlockEntity 随时间工作的方块实体（例如熔炉）
+// 方块实体属于世界中的某个位置，所在区域加载时由世界每帧调用Tick
+type BlockEntity interface {
+	Tick()
+	Clear() []ItemStack // 清空并返回其中的物品（方块被破坏时撒出）
+}
+
+// NewBlockEntity 为需要方块实体的方块类型创建对应的方块实体
+func NewBlockEntity(blockType BlockType) (BlockEntity, bool) {
+	switch blockType {
+	case FurnaceBlock:
+		return NewFurnaceEntity(), true
+	}
+	return nil, false
+}
+
+// smeltingRecipes 熔炉的烧制配方：输入物品 -> 产物
+var smeltingRecipes = map[ItemType]ItemStack{
+	IronOre: {Type: IronIngot, Count: 1},
+	Stone:   {Type: SmoothStone, Count: 1},
+}
+
+// fuelValues 燃料可以燃烧的帧数
+var fuelValues = map[ItemType]int{
+	Coal:   SmeltTime * 8,
+	Wood:   SmeltTime * 3 / 2,
+	Planks: SmeltTime * 3 / 2,
+	Stick:  SmeltTime / 2,
+}
+
+// GetSmeltingResult 获取物品在熔炉中烧制后的产物
+func GetSmeltingResult(itemType ItemType) (ItemStack, bool) {
+	result, exists := smeltingRecipes[itemType]
+	return result, exists
+}
+
+// GetFuelValue 获取物品作为燃料可以燃烧的帧数，不是燃料时为0
+func GetFuelValue(itemType ItemType) int {
+	return fuelValues[itemType]
+}
+
+// FurnaceEntity 熔炉的方块实体：消耗燃料把输入槽中的物品烧制成产物
+type FurnaceEntity struct {
+	Slots        [FurnaceSlotCount]ItemStack
+	BurnTime     int // 当前燃料剩余的燃烧帧数
+	BurnDuration int // 当前燃料的总燃烧帧数
+	CookTime     int // 当前物品的烧制进度（帧）
+}
+
+// NewFurnaceEntity 创建空的熔炉
+func NewFurnaceEntity() *FurnaceEntity {
+	return &FurnaceEntity{}
+}
+
+// Tick 更新一帧：燃烧燃料并推进烧制进度，界面关闭时也会继续工作
+func (f *FurnaceEntity) Tick() {
+	if f.BurnTime > 0 {
+		f.BurnTime--
+	}
+
+	canSmelt := f.canSmelt()
+
+	// 燃料烧完且有东西可以烧制时点燃下一个燃料
+	if f.BurnTime == 0 && canSmelt {
+		fuel := f.Slots[FurnaceFuel]
+		if value := GetFuelValue(fuel.Type); value > 0 && fuel.Count > 0 {
+			f.BurnTime, f.BurnDuration = value, value
+			f.Slots[FurnaceFuel].Count--
+			if f.Slots[FurnaceFuel].Count == 0 {
+				f.Slots[FurnaceFuel] = ItemStack{Type: Air, Count: 0}
+			}
+		}
+	}
+
+	switch {
+	case !canSmelt:
+		f.CookTime = 0
+	case f.BurnTime > 0:
+		f.CookTime++
+		if f.CookTime >= SmeltTime {
+			f.CookTime = 0
+			f.smelt()
+		}
+	case f.CookTime > 0:
+		// 没有燃料时进度慢慢退回
+		f.CookTime--
+	}
+}
+
+// canSmelt 检查输入槽中的物品能否烧制，并且产物槽放得下
+func (f *FurnaceEntity) canSmelt() bool {
+	input := f.Slots[FurnaceInput]
+	result, exists := GetSmeltingResult(input.Type)
+	if !exists || input.Count <= 0 {
+		return false
+	}
+
+	output := f.Slots[FurnaceOutput]
+	if output.Type == Air || output.Count == 0 {
+		return true
+	}
+	return output.Type == result.Type && output.Count+result.Count <= GetMaxStackSize(result.Type)
+}
+
+// smelt 消耗一个输入物品，把产物放入产物槽
+func (f *FurnaceEntity) smelt() {
+	result, _ := GetSmeltingResult(f.Slots[FurnaceInput].Type)
+	f.Slots[FurnaceInput].Count--
+	if f.Slots[FurnaceInput].Count == 0 {
+		f.Slots[FurnaceInput] = ItemStack{Type: Air, Count: 0}
+	}
+
+	if f.Slots[FurnaceOutput].Type == result.Type {
+		f.Slots[FurnaceOutput].Count += result.Count
+	} else {
+		f.Slots[FurnaceOutput] = result
+	}
+}
+
+// IsBurning 返回熔炉是否正在燃烧燃料
+func (f *FurnaceEntity) IsBurning() bool {
+	return f.BurnTime > 0
+}
+
+// CookProgress 获取当前物品的烧制进度，0到1
+func (f *FurnaceEntity) CookProgress() float64 {
+	return float64(f.CookTime) / SmeltTime
+}
+
+// BurnProgress 获取当前燃料的剩余比例，0到1
+func (f *FurnaceEntity) BurnProgress() float64 {
+	if f.BurnDuration <= 0 {
+		return 0
+	}
+	return float64(f.BurnTime) / float64(f.BurnDuration)
+}
+
+// InsertStack 把物品放入熔炉：可烧制的物品放入输入槽，燃料放入燃料槽
+// 返回放不下的剩余数量
+func (f *FurnaceEntity) InsertStack(stack ItemStack) int {
+	if _, smeltable := GetSmeltingResult(stack.Type); smeltable {
+		return addStackToSlots(f.Slots[FurnaceInput:FurnaceInput+1], stack)
+	}
+	if GetFuelValue(stack.Type) > 0 {
+		return addStackToSlots(f.Slots[FurnaceFuel:FurnaceFuel+1], stack)
+	}
+	return stack.Count
+}
+
+// Clear 清空熔炉的所有槽位并重置进度，返回其中的物品
+func (f *FurnaceEntity) Clear() []ItemStack {
+	removed := make([]ItemStack, 0)
+	for i := range f.Slots {
+		if f.Slots[i].Type != Air && f.Slots[i].Count > 0 {
+			removed = append(removed, f.Slots[i])
+		}
+		f.Slots[i] = ItemStack{Type: Air, Count: 0}
+	}
+	f.BurnTime, f.BurnDuration, f.CookTime = 0, 0, 0
+	return removed
+}
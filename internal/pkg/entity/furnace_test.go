@@ -0,0 +1,111 @@
+package entity
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestFurnaceSmeltsWithFuel(t *testing.T) {
+	furnace := NewFurnaceEntity()
+	furnace.Slots[FurnaceInput] = ItemStack{Type: IronOre, Count: 2}
+
+	// 没有燃料时不会烧制
+	for i := 0; i < SmeltTime; i++ {
+		furnace.Tick()
+	}
+	if furnace.IsBurning() || furnace.Slots[FurnaceOutput].Count != 0 {
+		t.Fatal("Expected furnace without fuel to do nothing")
+	}
+
+	furnace.Slots[FurnaceFuel] = ItemStack{Type: Coal, Count: 1}
+	for i := 0; i < SmeltTime; i++ {
+		furnace.Tick()
+	}
+	if furnace.Slots[FurnaceOutput].Type != IronIngot || furnace.Slots[FurnaceOutput].Count != 1 {
+		t.Fatalf("Expected one iron ingot, got %v", furnace.Slots[FurnaceOutput])
+	}
+	if furnace.Slots[FurnaceInput].Count != 1 || furnace.Slots[FurnaceFuel].Type != Air {
+		t.Error("Expected one ore and the coal to be consumed")
+	}
+	if !furnace.IsBurning() {
+		t.Error("Expected coal to keep burning")
+	}
+
+	for i := 0; i < SmeltTime; i++ {
+		furnace.Tick()
+	}
+	if furnace.Slots[FurnaceOutput].Count != 2 || furnace.Slots[FurnaceInput].Type != Air {
+		t.Error("Expected the second ore to be smelted with the same coal")
+	}
+
+	// 没有东西可以烧制时进度清零，燃料继续燃烧完
+	furnace.Tick()
+	if furnace.CookProgress() != 0 {
+		t.Error("Expected cook progress to reset with an empty input")
+	}
+}
+
+func TestFurnaceProgressAndOutputLimits(t *testing.T) {
+	furnace := NewFurnaceEntity()
+	furnace.Slots[FurnaceInput] = ItemStack{Type: Stone, Count: 5}
+	furnace.Slots[FurnaceFuel] = ItemStack{Type: Stick, Count: 1}
+
+	// 木棍只能烧半个物品的时间，燃料耗尽后进度慢慢退回
+	for i := 0; i < SmeltTime/2; i++ {
+		furnace.Tick()
+	}
+	progress := furnace.CookProgress()
+	if progress <= 0 || progress >= 1 {
+		t.Fatalf("Expected partial progress, got %f", progress)
+	}
+	furnace.Tick()
+	furnace.Tick()
+	if furnace.IsBurning() || furnace.CookProgress() >= progress {
+		t.Error("Expected progress to fall back without fuel")
+	}
+
+	// 产物槽放着其他物品时不能烧制
+	furnace.Slots[FurnaceOutput] = ItemStack{Type: IronIngot, Count: 1}
+	furnace.Slots[FurnaceFuel] = ItemStack{Type: Coal, Count: 1}
+	furnace.Tick()
+	if furnace.IsBurning() || furnace.Slots[FurnaceFuel].Count != 1 {
+		t.Error("Expected fuel not to be used while the output is blocked")
+	}
+}
+
+func TestFurnaceInsertStackAndClear(t *testing.T) {
+	furnace := NewFurnaceEntity()
+	if leftover := furnace.InsertStack(ItemStack{Type: IronOre, Count: 3}); leftover != 0 {
+		t.Errorf("Expected ore to go into the input slot, got %d left", leftover)
+	}
+	if leftover := furnace.InsertStack(ItemStack{Type: Planks, Count: 4}); leftover != 0 {
+		t.Errorf("Expected planks to go into the fuel slot, got %d left", leftover)
+	}
+	if leftover := furnace.InsertStack(ItemStack{Type: Dirt, Count: 2}); leftover != 2 {
+		t.Error("Expected dirt to be rejected")
+	}
+	if furnace.Slots[FurnaceInput].Type != IronOre || furnace.Slots[FurnaceFuel].Type != Planks {
+		t.Error("Expected items in the input and fuel slots")
+	}
+
+	furnace.Tick()
+	removed := furnace.Clear()
+	if len(removed) != 2 || furnace.IsBurning() || furnace.CookProgress() != 0 {
+		t.Error("Expected clear to return items and reset progress")
+	}
+}
+
+func TestNewBlockEntity(t *testing.T) {
+	if blockEntity, ok := NewBlockEntity(FurnaceBlock); !ok {
+		t.Error("Expected furnace block to have a block entity")
+	} else if _, isFurnace := blockEntity.(*FurnaceEntity); !isFurnace {
+		t.Error("Expected furnace block entity to be a FurnaceEntity")
+	}
+	if _, ok := NewBlockEntity(StoneBlock); ok {
+		t.Error("Expected stone block to have no block entity")
+	}
+	stacks, _ := LootTables.Roll("blocks/coal_ore", LootContext{Rng: rand.New(rand.NewSource(1))})
+	if len(stacks) != 1 || stacks[0].Type != Coal {
+		t.Errorf("Expected coal ore to drop coal, got %v", stacks)
+	}
+}
@@ -0,0 +1,207 @@
+package entity
+
+import (
+	"math"
+)
+
+const (
+	PlayerMaxHealth      = 20              // 玩家最大生命值
+	InvulnerableDuration = 20              // 受伤后的无敌帧数
+	RegenDelay           = 300             // 最后一次受伤后开始回血的等待帧数（5秒）
+	RegenInterval        = 60              // 回血间隔帧数，每次回复1点
+	FallDamageThreshold  = 13.0            // 落地速度超过该值时受到摔落伤害（约5格高度）
+	FallDamageFactor     = 1.5             // 每超出1点落地速度造成的伤害
+	SuffocationDamage    = 1               // 窒息伤害
+	SuffocationInterval  = 30              // 窒息伤害间隔帧数
+	VoidDepth            = 100 * BlockSize // 低于该高度视为掉入虚空
+	VoidDamage           = 4               // 虚空伤害
+	VoidInterval         = 10              // 虚空伤害间隔帧数
+)
+
+// DamageSource 伤害来源
+type DamageSource int
+
+const (
+	DamageFall        DamageSource = iota // 摔落
+	DamageHazard                          // 危险方块（如尖刺）
+	DamageSuffocation                     // 卡在方块内窒息
+	DamageVoid                            // 掉入虚空
+	DamagePoison                          // 中毒
+	DamageExplosion                       // 爆炸
+	DamageMob                             // 生物攻击
+	DamageAttack                          // 首领的攻击和敌对投射物
+)
+
+// String 返回伤害来源的名称
+func (s DamageSource) String() string {
+	switch s {
+	case DamageFall:
+		return "Fall"
+	case DamageHazard:
+		return "Hazard"
+	case DamageSuffocation:
+		return "Suffocation"
+	case DamageVoid:
+		return "Void"
+	case DamagePoison:
+		return "Poison"
+	case DamageExplosion:
+		return "Explosion"
+	case DamageMob:
+		return "Mob"
+	case DamageAttack:
+		return "Attack"
+	}
+	return "Unknown"
+}
+
+// TakeDamage 对玩家造成伤害，无敌帧内、冲刺攻击期间或已死亡时无效
+// 返回伤害是否生效
+func (p *Player) TakeDamage(amount int, source DamageSource) bool {
+	if amount <= 0 || p.Dead || p.InvulnerableTimer > 0 || p.IsDashStriking() {
+		return false
+	}
+
+	p.Health -= amount
+	p.LastDamageSource = source
+	p.InvulnerableTimer = InvulnerableDuration
+	p.RegenTimer = RegenDelay
+
+	if p.Health <= 0 {
+		p.Health = 0
+		p.Dead = true
+	}
+	return true
+}
+
+// Hitbox 获取玩家的碰撞箱
+func (p *Player) Hitbox() Hitbox {
+	return NewHitbox(p.X, p.Y, PlayerSize, PlayerSize)
+}
+
+// Hit 被首领的攻击或敌对投射物命中：受到伤害并被击退；返回是否命中
+func (p *Player) Hit(damage int, knockbackX, knockbackY float64) bool {
+	if !p.TakeDamage(damage, DamageAttack) {
+		return false
+	}
+	p.VX, p.VY = knockbackX, knockbackY
+	p.OnGround = false
+	return true
+}
+
+// Heal 回复生命值，不超过最大生命值
+func (p *Player) Heal(amount int) {
+	if amount <= 0 || p.Dead {
+		return
+	}
+	p.Health += amount
+	if p.Health > p.MaxHealth {
+		p.Health = p.MaxHealth
+	}
+}
+
+// GetHealth 获取当前生命值和最大生命值
+func (p *Player) GetHealth() (int, int) {
+	return p.Health, p.MaxHealth
+}
+
+// IsDead 检查玩家是否死亡
+func (p *Player) IsDead() bool {
+	return p.Dead
+}
+
+// SetSpawnPoint 设置重生点
+func (p *Player) SetSpawnPoint(x, y float64) {
+	p.SpawnX = x
+	p.SpawnY = y
+}
+
+// Respawn 在重生点复活玩家，恢复满血并清除运动状态
+func (p *Player) Respawn() {
+	p.X, p.Y = p.SpawnX, p.SpawnY
+	p.VX, p.VY = 0, 0
+	p.Health = p.MaxHealth
+	p.Dead = false
+	p.InvulnerableTimer = InvulnerableDuration
+	p.RegenTimer = 0
+	p.Dashing = false
+	p.Climbing = false
+	p.OnGround = false
+	p.ClearEffects()
+	p.DoubleJump = p.Stats().AirJumps
+	p.DashTrails = p.DashTrails[:0]
+}
+
+// applyFallDamage 根据落地时的速度计算摔落伤害
+func (p *Player) applyFallDamage(impactVY float64) {
+	if impactVY <= FallDamageThreshold || !p.Stats().FallDamage {
+		return
+	}
+	damage := int(math.Ceil((impactVY - FallDamageThreshold) * FallDamageFactor))
+	p.TakeDamage(damage, DamageFall)
+}
+
+// updateHealth 更新生命值相关状态：无敌帧、环境伤害和自然回血
+func (p *Player) updateHealth() {
+	if p.Dead {
+		return
+	}
+
+	if p.InvulnerableTimer > 0 {
+		p.InvulnerableTimer--
+	}
+	p.DamageTicks++
+
+	// 掉入虚空
+	if p.Y > VoidDepth {
+		if p.DamageTicks%VoidInterval == 0 {
+			p.InvulnerableTimer = 0
+			p.TakeDamage(VoidDamage, DamageVoid)
+		}
+		return
+	}
+
+	if p.World != nil {
+		// 接触危险方块，部分危险方块还会附带状态效果
+		if damage, effect := p.checkHazardDamage(); damage > 0 {
+			if p.TakeDamage(damage, DamageHazard) {
+				p.ApplyEffect(effect)
+			}
+		}
+
+		// 身体任何部分卡在实心方块内（例如竞技场屏障或长大的树苗出现在玩家身上）都无法移动，承受窒息伤害直到死亡重生
+		if p.DamageTicks%SuffocationInterval == 0 && p.checkOverlap() {
+			p.TakeDamage(SuffocationDamage, DamageSuffocation)
+		}
+	}
+
+	// 一段时间未受伤后自然回血
+	if p.RegenTimer > 0 {
+		p.RegenTimer--
+	} else if p.Health < p.MaxHealth && p.DamageTicks%RegenInterval == 0 {
+		p.Heal(1)
+	}
+}
+
+// checkHazardDamage 返回玩家接触到的危险方块中最高的接触伤害及其附带的状态效果
+func (p *Player) checkHazardDamage() (int, StatusEffect) {
+	left := int(math.Floor((p.X - PlayerSize/2) / BlockSize))
+	right := int(math.Floor((p.X + PlayerSize/2 - 1) / BlockSize))
+	top := int(math.Floor((p.Y - PlayerSize/2) / BlockSize))
+	bottom := int(math.Floor((p.Y + PlayerSize/2 - 1) / BlockSize))
+
+	damage := 0
+	effect := StatusEffect{}
+	for x := left; x <= right; x++ {
+		for y := top; y <= bottom; y++ {
+			if block, exists := p.World.GetBlock(x, y); exists {
+				props := GetBlockProperties(block.Type)
+				if props.ContactDamage > damage {
+					damage = props.ContactDamage
+					effect = props.ContactEffect
+				}
+			}
+		}
+	}
+	return damage, effect
+}
@@ -0,0 +1,201 @@
+package entity
+
+import (
+	"testing"
+)
+
+func TestPlayerTakeDamage(t *testing.T) {
+	player := NewPlayer(0, 0)
+
+	if player.Health != PlayerMaxHealth || player.MaxHealth != PlayerMaxHealth {
+		t.Errorf("Expected full health %d, got %d/%d", PlayerMaxHealth, player.Health, player.MaxHealth)
+	}
+
+	// 受到伤害
+	if !player.TakeDamage(5, DamageHazard) {
+		t.Error("Expected damage to be applied")
+	}
+	if player.Health != PlayerMaxHealth-5 {
+		t.Errorf("Expected health %d, got %d", PlayerMaxHealth-5, player.Health)
+	}
+	if player.LastDamageSource != DamageHazard {
+		t.Errorf("Expected last damage source Hazard, got %v", player.LastDamageSource)
+	}
+
+	// 无敌帧内不再受伤
+	if player.TakeDamage(5, DamageHazard) {
+		t.Error("Expected damage to be ignored during invulnerability")
+	}
+
+	// 生命值归零时死亡
+	player.InvulnerableTimer = 0
+	player.TakeDamage(100, DamageVoid)
+	if player.Health != 0 || !player.IsDead() {
+		t.Errorf("Expected player to be dead with 0 health, got %d dead=%v", player.Health, player.IsDead())
+	}
+}
+
+func TestPlayerFallDamage(t *testing.T) {
+	mockWorld := NewMockWorld()
+	for x := 0; x < 4; x++ {
+		mockWorld.AddBlock(x, 10)
+	}
+
+	// 高速落地受到伤害
+	player := NewPlayer(float64(BlockSize+PlayerSize/2), float64(10*BlockSize-PlayerSize/2-10))
+	player.SetWorld(mockWorld)
+	player.SetOnGround(false)
+	player.VY = 20
+	player.Update()
+
+	if !player.IsOnGround() {
+		t.Fatal("Expected player to land on the ground")
+	}
+	if player.Health >= PlayerMaxHealth {
+		t.Error("Expected player to take fall damage from a fast landing")
+	}
+	if player.LastDamageSource != DamageFall {
+		t.Errorf("Expected last damage source Fall, got %v", player.LastDamageSource)
+	}
+
+	// 低速落地不受伤
+	player = NewPlayer(float64(BlockSize+PlayerSize/2), float64(10*BlockSize-PlayerSize/2-4))
+	player.SetWorld(mockWorld)
+	player.SetOnGround(false)
+	player.VY = 5
+	player.Update()
+
+	if player.Health != PlayerMaxHealth {
+		t.Errorf("Expected no fall damage from a slow landing, got health %d", player.Health)
+	}
+}
+
+func TestPlayerHazardDamage(t *testing.T) {
+	mockWorld := NewMockWorld()
+	mockWorld.AddBlockWithType(2, 2, SpikeBlock)
+
+	player := NewPlayer(float64(2*BlockSize+PlayerSize/2), float64(2*BlockSize+PlayerSize/2))
+	player.SetWorld(mockWorld)
+	player.Update()
+
+	expected := PlayerMaxHealth - GetBlockProperties(SpikeBlock).ContactDamage
+	if player.Health != expected {
+		t.Errorf("Expected health %d after touching spikes, got %d", expected, player.Health)
+	}
+}
+
+func TestPlayerSuffocation(t *testing.T) {
+	mockWorld := NewMockWorld()
+	mockWorld.AddBlock(2, 2)
+
+	player := NewPlayer(float64(2*BlockSize+PlayerSize/2), float64(2*BlockSize+PlayerSize/2))
+	player.SetWorld(mockWorld)
+	player.SetOnGround(true)
+
+	for i := 0; i < SuffocationInterval; i++ {
+		player.Update()
+	}
+
+	if player.Health != PlayerMaxHealth-SuffocationDamage {
+		t.Errorf("Expected suffocation damage, got health %d", player.Health)
+	}
+	if player.LastDamageSource != DamageSuffocation {
+		t.Errorf("Expected last damage source Suffocation, got %v", player.LastDamageSource)
+	}
+}
+
+func TestPlayerSuffocationWhenBodyIsEmbedded(t *testing.T) {
+	mockWorld := NewMockWorld()
+	mockWorld.AddBlock(2, 2)
+
+	// 只有脚下的一半卡在方块里，头部是空的
+	player := NewPlayer(float64(2*BlockSize+PlayerSize/2), float64(2*BlockSize))
+	player.SetWorld(mockWorld)
+	player.SetOnGround(true)
+
+	for i := 0; i < SuffocationInterval; i++ {
+		player.Update()
+	}
+
+	if player.Health != PlayerMaxHealth-SuffocationDamage || player.LastDamageSource != DamageSuffocation {
+		t.Errorf("Expected suffocation damage for a body-only overlap, got health %d", player.Health)
+	}
+}
+
+func TestPlayerVoidDamage(t *testing.T) {
+	player := NewPlayer(0, VoidDepth+BlockSize)
+	player.SetOnGround(true)
+
+	for i := 0; i < VoidInterval*PlayerMaxHealth; i++ {
+		player.Update()
+	}
+
+	if !player.IsDead() {
+		t.Error("Expected player to die in the void")
+	}
+}
+
+func TestPlayerRegeneration(t *testing.T) {
+	mockWorld := NewMockWorld()
+	for x := 0; x < 4; x++ {
+		mockWorld.AddBlock(x, 10)
+	}
+
+	player := NewPlayer(float64(BlockSize+PlayerSize/2), float64(10*BlockSize-PlayerSize/2))
+	player.SetWorld(mockWorld)
+	player.TakeDamage(4, DamageHazard)
+
+	// 受伤后短时间内不会回血
+	for i := 0; i < RegenDelay-1; i++ {
+		player.Update()
+	}
+	if player.Health != PlayerMaxHealth-4 {
+		t.Errorf("Expected no regeneration during delay, got health %d", player.Health)
+	}
+
+	// 等待足够长的时间后开始回血
+	for i := 0; i < RegenInterval*2; i++ {
+		player.Update()
+	}
+	if player.Health <= PlayerMaxHealth-4 {
+		t.Errorf("Expected health to regenerate, got %d", player.Health)
+	}
+
+	// 回血不超过最大生命值
+	player.Heal(100)
+	if player.Health != player.MaxHealth {
+		t.Errorf("Expected health to cap at %d, got %d", player.MaxHealth, player.Health)
+	}
+}
+
+func TestPlayerRespawn(t *testing.T) {
+	player := NewPlayer(0, 0)
+	player.SetSpawnPoint(64, -32)
+	player.SetPosition(500, 500)
+	player.TakeDamage(100, DamageFall)
+
+	player.Respawn()
+
+	if player.IsDead() {
+		t.Error("Expected player to be alive after respawn")
+	}
+	if player.Health != player.MaxHealth {
+		t.Errorf("Expected full health after respawn, got %d", player.Health)
+	}
+	if player.X != 64 || player.Y != -32 {
+		t.Errorf("Expected player at spawn point (64, -32), got (%f, %f)", player.X, player.Y)
+	}
+}
+
+func TestPlayerRespawnKeepsPerkAirJumps(t *testing.T) {
+	player := NewPlayer(0, 0)
+	player.AddPerk("air_jump")
+	player.DoubleJump = 0
+	player.TakeDamage(100, DamageFall)
+
+	player.Respawn()
+
+	if player.DoubleJump != DoubleJumpMax+1 {
+		t.Errorf("Expected perk air jumps after respawn, got %d", player.DoubleJump)
+	}
+}
@@ -1,5 +1,10 @@
 package entity
 
+import (
+	"encoding/json"
+	"fmt"
+)
+
 const (
 	// HotbarSlotCount 底部快捷栏槽数量
 	HotbarSlotCount = 9
@@ -20,6 +25,35 @@ const (
 	// Grass = Dirt  // 注释掉这个定义，避免switch语句中的重复
 	Wood
 	Leaves
+	Ladder
+	Vine
+	Rope
+	Spike
+	SwiftnessPotion    // 迅捷药水
+	FeatherfallPotion  // 轻羽药水
+	LeapingPotion      // 跳跃药水
+	DashPotion         // 疾冲药水
+	RegenerationPotion // 再生药水
+	WoodenPickaxe      // 木镐
+	StonePickaxe       // 石镐
+	IronPickaxe        // 铁镐
+	WoodenAxe          // 木斧
+	StoneAxe           // 石斧
+	IronAxe            // 铁斧
+	WoodenShovel       // 木铲
+	StoneShovel        // 石铲
+	IronShovel         // 铁铲
+	Planks             // 木板
+	Stick              // 木棍
+	CraftingTable      // 工作台
+	Chest              // 箱子
+	IronOre            // 铁矿石
+	Coal               // 煤炭
+	IronIngot          // 铁锭
+	SmoothStone        // 平滑石头
+	Furnace            // 熔炉
+	Torch              // 火把
+	Bomb               // 炸弹
 )
 
 // 为了保持向后兼容性，定义一个常量指向Dirt
@@ -27,10 +61,86 @@ const (
 	Grass ItemType = Dirt
 )
 
+// builtinItemNames 内置物品在数据文件（如物品属性、合成配方）中使用的名称
+var builtinItemNames = map[ItemType]string{
+	Air:                "air",
+	Stone:              "stone",
+	Dirt:               "dirt",
+	Wood:               "wood",
+	Leaves:             "leaves",
+	Ladder:             "ladder",
+	Vine:               "vine",
+	Rope:               "rope",
+	Spike:              "spike",
+	SwiftnessPotion:    "swiftness_potion",
+	FeatherfallPotion:  "featherfall_potion",
+	LeapingPotion:      "leaping_potion",
+	DashPotion:         "dash_potion",
+	RegenerationPotion: "regeneration_potion",
+	WoodenPickaxe:      "wooden_pickaxe",
+	StonePickaxe:       "stone_pickaxe",
+	IronPickaxe:        "iron_pickaxe",
+	WoodenAxe:          "wooden_axe",
+	StoneAxe:           "stone_axe",
+	IronAxe:            "iron_axe",
+	WoodenShovel:       "wooden_shovel",
+	StoneShovel:        "stone_shovel",
+	IronShovel:         "iron_shovel",
+	Planks:             "planks",
+	Stick:              "stick",
+	CraftingTable:      "crafting_table",
+	Chest:              "chest",
+	IronOre:            "iron_ore",
+	Coal:               "coal",
+	IronIngot:          "iron_ingot",
+	SmoothStone:        "smooth_stone",
+	Furnace:            "furnace",
+	Torch:              "torch",
+	Bomb:               "bomb",
+}
+
+// GetItemName 获取物品的名称
+func GetItemName(itemType ItemType) string {
+	return Items.Get(itemType).Name
+}
+
+// ParseItemName 根据名称查找物品类型
+func ParseItemName(name string) (ItemType, bool) {
+	return Items.Lookup(name)
+}
+
+// MarshalJSON 物品类型按名称保存（例如存档），新增物品不会改变已有存档的含义
+func (t ItemType) MarshalJSON() ([]byte, error) {
+	return json.Marshal(GetItemName(t))
+}
+
+// UnmarshalJSON 根据名称读取物品类型，旧存档中按编号保存的物品类型也可以读取
+func (t *ItemType) UnmarshalJSON(data []byte) error {
+	var id int
+	if err := json.Unmarshal(data, &id); err == nil {
+		if itemType, ok := ParseItemName(GetItemName(ItemType(id))); !ok || itemType != ItemType(id) {
+			return fmt.Errorf("unknown item %d", id)
+		}
+		*t = ItemType(id)
+		return nil
+	}
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		return err
+	}
+	itemType, ok := ParseItemName(name)
+	if !ok {
+		return fmt.Errorf("unknown item %q", name)
+	}
+	*t = itemType
+	return nil
+}
+
 // ItemStack 物品堆叠
 type ItemStack struct {
-	Type  ItemType
-	Count int
+	Type       ItemType
+	Count      int
+	Durability int // 工具的剩余耐久度，非工具物品为0
 }
 
 // Inventory 物品栏
@@ -38,6 +148,7 @@ type Inventory struct {
 	Slots       []ItemStack
 	SelectedSlot int // 当前选中的快捷栏槽位 (0-8)
 	Open        bool // 物品栏是否展开
+	Cursor      ItemStack // 物品栏展开时鼠标上拿着的物品
 }
 
 // NewInventory 创建新的物品栏
@@ -51,7 +162,12 @@ func NewInventory() *Inventory {
 	slots[0] = ItemStack{Type: Stone, Count: 64}
 	slots[1] = ItemStack{Type: Dirt, Count: 64}  // 使用合并后的泥土物品
 	slots[2] = ItemStack{Type: Wood, Count: 64}
-	slots[3] = ItemStack{Type: Leaves, Count: 64}
+	slots[3] = NewToolStack(WoodenPickaxe)
+	slots[4] = ItemStack{Type: Ladder, Count: 64}
+	slots[5] = ItemStack{Type: Rope, Count: 64}
+	slots[6] = ItemStack{Type: SwiftnessPotion, Count: 8}
+	slots[7] = ItemStack{Type: LeapingPotion, Count: 8}
+	slots[8] = ItemStack{Type: RegenerationPotion, Count: 8}
 	
 	return &Inventory{
 		Slots:       slots,
@@ -113,6 +229,38 @@ func (inv *Inventory) ConsumeSelectedItem() {
 	}
 }
 
+// RemoveFromSlot 从指定槽位取出最多count个物品，返回取出的物品堆叠
+func (inv *Inventory) RemoveFromSlot(slot, count int) ItemStack {
+	if slot < 0 || slot >= TotalSlotCount || count <= 0 || inv.Slots[slot].Type == Air {
+		return ItemStack{Type: Air, Count: 0}
+	}
+	
+	removed := inv.Slots[slot]
+	if count < removed.Count {
+		removed.Count = count
+		inv.Slots[slot].Count -= count
+	} else {
+		inv.Slots[slot] = ItemStack{Type: Air, Count: 0}
+	}
+	return removed
+}
+
+// DamageSelectedItem 消耗选中工具的耐久度，耐久耗尽时工具损坏
+// 返回工具是否因此损坏；选中的不是工具时不做任何事
+func (inv *Inventory) DamageSelectedItem(amount int) bool {
+	slot := inv.SelectedSlot
+	if !IsTool(inv.Slots[slot].Type) || amount <= 0 {
+		return false
+	}
+	
+	inv.Slots[slot].Durability -= amount
+	if inv.Slots[slot].Durability <= 0 {
+		inv.Slots[slot] = ItemStack{Type: Air, Count: 0}
+		return true
+	}
+	return false
+}
+
 // IsOpen 返回物品栏是否展开
 func (inv *Inventory) IsOpen() bool {
 	return inv.Open
@@ -151,56 +299,128 @@ func (inv *Inventory) GetInventory() []ItemStack {
 	return inventory
 }
 
-// AddItem 添加物品到物品栏
-func (inv *Inventory) AddItem(itemType ItemType, count int) {
+// AddItem 添加物品到物品栏，返回放不下的剩余数量
+func (inv *Inventory) AddItem(itemType ItemType, count int) int {
+	return inv.AddStack(ItemStack{Type: itemType, Count: count})
+}
+
+// AddStack 添加物品堆叠到物品栏，工具会保留其耐久度（未设置时为满耐久）
+// 返回放不下的剩余数量
+func (inv *Inventory) AddStack(stack ItemStack) int {
+	return addStackToSlots(inv.Slots, stack)
+}
+
+// addStackToSlots 把物品堆叠放入一组槽位：先叠加到相同物品上，再放入空槽位
+// 返回放不下的剩余数量
+func addStackToSlots(slots []ItemStack, stack ItemStack) int {
+	itemType, count := stack.Type, stack.Count
+	maxStack := GetMaxStackSize(itemType)
+	if IsTool(itemType) && stack.Durability <= 0 {
+		stack.Durability = NewToolStack(itemType).Durability
+	}
+	
 	// 首先查找是否已有相同类型的物品可以堆叠
-	for i := 0; i < TotalSlotCount; i++ {
-		if inv.Slots[i].Type == itemType && inv.Slots[i].Count > 0 && inv.Slots[i].Count < 64 {
+	for i := range slots {
+		if slots[i].Type == itemType && slots[i].Count > 0 && slots[i].Count < maxStack {
 			// 计算可以添加的数量
-			availableSpace := 64 - inv.Slots[i].Count
+			availableSpace := maxStack - slots[i].Count
 			addCount := count
 			if count > availableSpace {
 				addCount = availableSpace
 			}
 			
-			inv.Slots[i].Count += addCount
+			slots[i].Count += addCount
 			count -= addCount
 			
 			if count <= 0 {
-				return // 所有物品都已添加
+				return 0 // 所有物品都已添加
 			}
 		}
 	}
 	
 	// 如果还有剩余的物品，寻找空槽位
-	for i := 0; i < TotalSlotCount; i++ {
-		if inv.Slots[i].Type == Air {
+	for i := range slots {
+		if slots[i].Type == Air {
 			addCount := count
-			if count > 64 {
-				addCount = 64
+			if count > maxStack {
+				addCount = maxStack
 			}
 			
-			inv.Slots[i] = ItemStack{Type: itemType, Count: addCount}
+			slots[i] = ItemStack{Type: itemType, Count: addCount, Durability: stack.Durability}
 			count -= addCount
 			
 			if count <= 0 {
-				return // 所有物品都已添加
+				return 0 // 所有物品都已添加
 			}
 		}
 	}
+	
+	return count
+}
+
+// CountItem 统计物品栏中某种物品的总数
+func (inv *Inventory) CountItem(itemType ItemType) int {
+	total := 0
+	for _, stack := range inv.Slots {
+		if stack.Type == itemType {
+			total += stack.Count
+		}
+	}
+	return total
 }
 
-// getBlockToItem 将方块类型转换为物品类型
-func getBlockToItem(blockType BlockType) ItemType {
-	// 由于GrassBlock已合并到DirtBlock中，需要特殊处理
-	if blockType == StoneBlock {
-		return Stone
-	} else if blockType == DirtBlock || blockType == GrassBlock {
-		return Dirt  // 泥土方块和草方块都掉落泥土物品
-	} else if blockType == WoodBlock {
-		return Wood
-	} else if blockType == LeavesBlock {
-		return Leaves
+// RemoveItem 从物品栏中移除最多count个指定物品，返回实际移除的数量
+func (inv *Inventory) RemoveItem(itemType ItemType, count int) int {
+	removed := 0
+	for i := range inv.Slots {
+		if removed >= count {
+			break
+		}
+		if inv.Slots[i].Type == itemType {
+			removed += inv.RemoveFromSlot(i, count-removed).Count
+		}
 	}
-	return Stone // 默认为石头
+	return removed
+}
+
+// CanAccept 检查物品栏是否还能放下至少一个该物品
+func (inv *Inventory) CanAccept(itemType ItemType) bool {
+	maxStack := GetMaxStackSize(itemType)
+	for i := 0; i < TotalSlotCount; i++ {
+		if inv.Slots[i].Type == Air {
+			return true
+		}
+		if inv.Slots[i].Type == itemType && inv.Slots[i].Count < maxStack {
+			return true
+		}
+	}
+	return false
+}
+
+// Clear 清空物品栏（包括鼠标上拿着的物品），返回被清空的物品堆叠（不包括空槽位）
+func (inv *Inventory) Clear() []ItemStack {
+	removed := make([]ItemStack, 0)
+	if inv.HasCursor() {
+		removed = append(removed, inv.TakeCursor())
+	}
+	for i := range inv.Slots {
+		if inv.Slots[i].Type != Air && inv.Slots[i].Count > 0 {
+			removed = append(removed, inv.Slots[i])
+		}
+		inv.Slots[i] = ItemStack{Type: Air, Count: 0}
+	}
+	return removed
+}
+
+// getItemToBlock 将物品类型转换为对应的方块类型（用于显示掉落物）
+func getItemToBlock(itemType ItemType) BlockType {
+	if props := Items.Get(itemType); props.Placeable {
+		return props.Block
+	}
+	return StoneBlock // 默认为石头
+}
+
+// GetBlockItem 获取放置后成为指定方块的物品（由物品注册表决定），没有对应物品的方块（如煤矿石）返回false
+func GetBlockItem(blockType BlockType) (ItemType, bool) {
+	return Items.ForBlock(blockType)
 }
\ No newline at end of file
@@ -0,0 +1,195 @@
+package entity
+
+// 物品栏的鼠标操作：鼠标上可以拿着一个物品堆叠（Cursor），
+// 通过点击槽位拿起、放下、拆分、交换或合并物品
+
+// GetCursor 获取鼠标上拿着的物品
+func (inv *Inventory) GetCursor() ItemStack {
+	return inv.Cursor
+}
+
+// HasCursor 检查鼠标上是否拿着物品
+func (inv *Inventory) HasCursor() bool {
+	return inv.Cursor.Type != Air && inv.Cursor.Count > 0
+}
+
+// TakeCursor 取走鼠标上的全部物品（例如丢到世界中）
+func (inv *Inventory) TakeCursor() ItemStack {
+	stack := inv.Cursor
+	inv.Cursor = ItemStack{Type: Air, Count: 0}
+	return stack
+}
+
+// TakeCursorOne 从鼠标上取走一个物品
+func (inv *Inventory) TakeCursorOne() ItemStack {
+	if !inv.HasCursor() {
+		return ItemStack{Type: Air, Count: 0}
+	}
+
+	one := inv.Cursor
+	one.Count = 1
+	inv.Cursor.Count--
+	if inv.Cursor.Count == 0 {
+		inv.Cursor = ItemStack{Type: Air, Count: 0}
+	}
+	return one
+}
+
+// ReturnCursor 把鼠标上的物品放回物品栏，返回放不下的部分
+func (inv *Inventory) ReturnCursor() ItemStack {
+	stack := inv.TakeCursor()
+	if stack.Type == Air {
+		return stack
+	}
+	stack.Count = inv.AddStack(stack)
+	if stack.Count == 0 {
+		return ItemStack{Type: Air, Count: 0}
+	}
+	return stack
+}
+
+// ClickSlot 左键点击槽位
+// 鼠标为空时拿起整组；槽位为空时放下整组；相同物品时尽量合并；不同物品时交换
+func (inv *Inventory) ClickSlot(slot int) {
+	if slot < 0 || slot >= TotalSlotCount {
+		return
+	}
+	inv.ClickStack(&inv.Slots[slot])
+}
+
+// ClickStack 对任意物品堆叠（例如合成网格中的格子）执行左键点击
+func (inv *Inventory) ClickStack(target *ItemStack) {
+	switch {
+	case !inv.HasCursor():
+		if target.Type == Air {
+			return
+		}
+		inv.Cursor = *target
+		*target = ItemStack{Type: Air, Count: 0}
+	case target.Type == Air:
+		*target = inv.TakeCursor()
+	case canStackWith(*target, inv.Cursor):
+		moved := GetMaxStackSize(target.Type) - target.Count
+		if moved > inv.Cursor.Count {
+			moved = inv.Cursor.Count
+		}
+		target.Count += moved
+		inv.Cursor.Count -= moved
+		if inv.Cursor.Count == 0 {
+			inv.Cursor = ItemStack{Type: Air, Count: 0}
+		}
+	default:
+		*target, inv.Cursor = inv.Cursor, *target
+	}
+}
+
+// TakeStack 把物品堆叠（例如熔炉的产物槽）中的物品拿到鼠标上，只取不放
+// 鼠标上是相同物品时尽量合并，放不下的部分留在原处；返回是否拿到了物品
+func (inv *Inventory) TakeStack(source *ItemStack) bool {
+	if source.Type == Air || source.Count <= 0 {
+		return false
+	}
+	if !inv.HasCursor() {
+		inv.Cursor = *source
+		*source = ItemStack{Type: Air, Count: 0}
+		return true
+	}
+	if !canStackWith(inv.Cursor, *source) {
+		return false
+	}
+
+	moved := GetMaxStackSize(source.Type) - inv.Cursor.Count
+	if moved > source.Count {
+		moved = source.Count
+	}
+	if moved <= 0 {
+		return false
+	}
+	inv.Cursor.Count += moved
+	source.Count -= moved
+	if source.Count == 0 {
+		*source = ItemStack{Type: Air, Count: 0}
+	}
+	return true
+}
+
+// RightClickSlot 右键点击槽位
+// 鼠标为空时拿起一半（向上取整）；否则向槽位放入一个物品，物品不同时交换
+func (inv *Inventory) RightClickSlot(slot int) {
+	if slot < 0 || slot >= TotalSlotCount {
+		return
+	}
+	inv.RightClickStack(&inv.Slots[slot])
+}
+
+// RightClickStack 对任意物品堆叠（例如合成网格中的格子）执行右键点击
+func (inv *Inventory) RightClickStack(target *ItemStack) {
+	switch {
+	case !inv.HasCursor():
+		if target.Type == Air {
+			return
+		}
+		half := (target.Count + 1) / 2
+		inv.Cursor = *target
+		inv.Cursor.Count = half
+		target.Count -= half
+		if target.Count == 0 {
+			*target = ItemStack{Type: Air, Count: 0}
+		}
+	case target.Type == Air:
+		*target = inv.TakeCursorOne()
+	case canStackWith(*target, inv.Cursor):
+		if target.Count < GetMaxStackSize(target.Type) {
+			target.Count++
+			inv.TakeCursorOne()
+		}
+	default:
+		*target, inv.Cursor = inv.Cursor, *target
+	}
+}
+
+// QuickMove 将槽位中的物品快速移动到另一区域（快捷栏与主物品栏之间）
+// 先合并到已有的相同物品堆叠，再放入空槽位，放不下的部分留在原槽位
+func (inv *Inventory) QuickMove(slot int) {
+	if slot < 0 || slot >= TotalSlotCount || inv.Slots[slot].Type == Air {
+		return
+	}
+
+	start, end := HotbarSlotCount, TotalSlotCount
+	if slot >= HotbarSlotCount {
+		start, end = 0, HotbarSlotCount
+	}
+
+	source := &inv.Slots[slot]
+	maxStack := GetMaxStackSize(source.Type)
+
+	// 合并到已有堆叠
+	for i := start; i < end && source.Count > 0; i++ {
+		target := &inv.Slots[i]
+		if target.Count > 0 && target.Count < maxStack && canStackWith(*target, *source) {
+			moved := maxStack - target.Count
+			if moved > source.Count {
+				moved = source.Count
+			}
+			target.Count += moved
+			source.Count -= moved
+		}
+	}
+
+	// 放入空槽位
+	for i := start; i < end && source.Count > 0; i++ {
+		if inv.Slots[i].Type == Air {
+			inv.Slots[i] = *source
+			source.Count = 0
+		}
+	}
+
+	if source.Count == 0 {
+		*source = ItemStack{Type: Air, Count: 0}
+	}
+}
+
+// canStackWith 检查两个物品堆叠能否合并
+func canStackWith(a, b ItemStack) bool {
+	return a.Type == b.Type && a.Type != Air && GetMaxStackSize(a.Type) > 1
+}
@@ -0,0 +1,120 @@
+package entity
+
+import "testing"
+
+func TestClickSlotPickUpAndPlace(t *testing.T) {
+	inventory := NewInventory()
+
+	inventory.ClickSlot(0)
+	if cursor := inventory.GetCursor(); cursor.Type != Stone || cursor.Count != 64 {
+		t.Fatalf("Expected to pick up 64 stone, got %v x%d", cursor.Type, cursor.Count)
+	}
+	if inventory.GetSlot(0).Type != Air {
+		t.Error("Expected slot to be empty after picking up")
+	}
+
+	inventory.ClickSlot(HotbarSlotCount)
+	if inventory.HasCursor() || inventory.GetSlot(HotbarSlotCount).Count != 64 {
+		t.Error("Expected stack to be placed in the empty slot")
+	}
+}
+
+func TestClickSlotSwapAndMerge(t *testing.T) {
+	inventory := NewInventory()
+	inventory.SetSlot(HotbarSlotCount, ItemStack{Type: Stone, Count: 10})
+
+	// 不同物品交换
+	inventory.ClickSlot(1) // 拿起泥土
+	inventory.ClickSlot(0) // 与石头交换
+	if inventory.GetSlot(0).Type != Dirt || inventory.GetCursor().Type != Stone {
+		t.Fatal("Expected dirt and stone to be swapped")
+	}
+
+	// 相同物品合并，放不下的留在鼠标上
+	inventory.ClickSlot(HotbarSlotCount)
+	if inventory.GetSlot(HotbarSlotCount).Count != 64 {
+		t.Errorf("Expected merged stack of 64, got %d", inventory.GetSlot(HotbarSlotCount).Count)
+	}
+	if inventory.GetCursor().Count != 10 {
+		t.Errorf("Expected 10 stone left on the cursor, got %d", inventory.GetCursor().Count)
+	}
+}
+
+func TestRightClickSlotSplitAndPlaceOne(t *testing.T) {
+	inventory := NewInventory()
+	inventory.SetSlot(0, ItemStack{Type: Stone, Count: 5})
+
+	inventory.RightClickSlot(0)
+	if inventory.GetCursor().Count != 3 || inventory.GetSlot(0).Count != 2 {
+		t.Fatalf("Expected split into 3 on cursor and 2 in slot, got %d and %d",
+			inventory.GetCursor().Count, inventory.GetSlot(0).Count)
+	}
+
+	inventory.RightClickSlot(HotbarSlotCount)
+	inventory.RightClickSlot(0)
+	if inventory.GetSlot(HotbarSlotCount).Count != 1 || inventory.GetSlot(0).Count != 3 {
+		t.Error("Expected right click to place one item at a time")
+	}
+	if inventory.GetCursor().Count != 1 {
+		t.Errorf("Expected 1 stone left on the cursor, got %d", inventory.GetCursor().Count)
+	}
+}
+
+func TestQuickMove(t *testing.T) {
+	inventory := NewInventory()
+	inventory.SetSlot(HotbarSlotCount+3, ItemStack{Type: Stone, Count: 60})
+
+	// 快捷栏到主物品栏：先合并再放入空槽位
+	inventory.QuickMove(0)
+	if inventory.GetSlot(HotbarSlotCount+3).Count != 64 {
+		t.Errorf("Expected stack to be topped up, got %d", inventory.GetSlot(HotbarSlotCount+3).Count)
+	}
+	if slot := inventory.GetSlot(HotbarSlotCount); slot.Type != Stone || slot.Count != 60 {
+		t.Errorf("Expected rest in the first empty slot, got %v x%d", slot.Type, slot.Count)
+	}
+	if inventory.GetSlot(0).Type != Air {
+		t.Error("Expected source slot to be emptied")
+	}
+
+	// 主物品栏到快捷栏
+	inventory.QuickMove(HotbarSlotCount)
+	if inventory.GetSlot(0).Type != Stone || inventory.GetSlot(HotbarSlotCount).Type != Air {
+		t.Error("Expected stack to move back to the hotbar")
+	}
+}
+
+func TestReturnCursor(t *testing.T) {
+	inventory := NewInventory()
+	inventory.ClickSlot(0)
+
+	if leftover := inventory.ReturnCursor(); leftover.Count != 0 {
+		t.Errorf("Expected cursor to fit back, got leftover %d", leftover.Count)
+	}
+	if inventory.HasCursor() {
+		t.Error("Expected cursor to be empty")
+	}
+
+	inventory.Cursor = ItemStack{Type: Wood, Count: 3}
+	if stacks := inventory.Clear(); len(stacks) == 0 || stacks[0].Type != Wood {
+		t.Error("Expected Clear to include the held stack")
+	}
+}
+
+func TestTakeStack(t *testing.T) {
+	inv := NewInventory()
+	output := ItemStack{Type: IronIngot, Count: 3}
+
+	if !inv.TakeStack(&output) || inv.GetCursor().Count != 3 || output.Type != Air {
+		t.Fatal("Expected output to be taken onto the cursor")
+	}
+
+	output = ItemStack{Type: IronIngot, Count: 2}
+	if !inv.TakeStack(&output) || inv.GetCursor().Count != 5 {
+		t.Error("Expected same items to merge onto the cursor")
+	}
+
+	output = ItemStack{Type: SmoothStone, Count: 1}
+	if inv.TakeStack(&output) || output.Count != 1 {
+		t.Error("Expected different items not to be taken")
+	}
+}
@@ -207,4 +207,50 @@ func TestConsumeSelectedItem(t *testing.T) {
 	if item.Type != Air || item.Count != 0 {
 		t.Errorf("Expected Air with count 0 after consuming last item, got %v with count %d", item.Type, item.Count)
 	}
-}
\ No newline at end of file
+}
+func TestAddItemReturnsLeftover(t *testing.T) {
+	inventory := NewInventory()
+	inventory.Clear()
+
+	if leftover := inventory.AddItem(Stone, 100); leftover != 0 {
+		t.Errorf("Expected all items to fit, got leftover %d", leftover)
+	}
+
+	// 填满所有槽位
+	for i := 0; i < TotalSlotCount; i++ {
+		inventory.SetSlot(i, ItemStack{Type: Dirt, Count: 64})
+	}
+	inventory.SetSlot(3, ItemStack{Type: Stone, Count: 60})
+
+	if !inventory.CanAccept(Stone) {
+		t.Error("Expected room for stone in the partial stack")
+	}
+	if inventory.CanAccept(Wood) {
+		t.Error("Expected no room for wood in a full inventory")
+	}
+
+	if leftover := inventory.AddItem(Stone, 10); leftover != 6 {
+		t.Errorf("Expected leftover 6, got %d", leftover)
+	}
+	if leftover := inventory.AddItem(Wood, 5); leftover != 5 {
+		t.Errorf("Expected leftover 5, got %d", leftover)
+	}
+}
+
+func TestRemoveFromSlot(t *testing.T) {
+	inventory := NewInventory()
+
+	removed := inventory.RemoveFromSlot(0, 1)
+	if removed.Type != Stone || removed.Count != 1 || inventory.GetSlot(0).Count != 63 {
+		t.Errorf("Expected to remove one stone, got %v x%d", removed.Type, removed.Count)
+	}
+
+	removed = inventory.RemoveFromSlot(0, 100)
+	if removed.Count != 63 || inventory.GetSlot(0).Type != Air {
+		t.Errorf("Expected to remove the rest of the stack, got %d", removed.Count)
+	}
+
+	if removed = inventory.RemoveFromSlot(0, 1); removed.Type != Air {
+		t.Error("Expected nothing to be removed from an empty slot")
+	}
+}
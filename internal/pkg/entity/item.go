@@ -17,28 +17,34 @@ const (
 	ItemMaxFallSpeed = 8.0
 	ItemFriction     = 0.85 // 很大的摩擦力
 	ItemBounce       = 0.2  // 弹跳系数
+	ItemLifetime     = 600  // 默认存活时间（10秒）
+	ItemPickupDelay  = 30   // 生成后不能被拾取的帧数
+	ItemMergeRange   = 24.0 // 相同掉落物合并的距离
+	ItemMagnetRange  = 96.0 // 默认的吸附半径
+	ItemMagnetSpeed  = 5.0  // 被吸附时飞向玩家的速度
+	ItemThrowSpeed   = 6.0  // 丢出物品的初速度
+	ItemThrowDelay   = 60   // 丢出的物品不能被拾取的帧数
 )
 
 // ItemEntity 表示世界中的掉落物
 type ItemEntity struct {
-	X, Y       float64     // 位置
-	VX, VY     float64     // 速度
-	ItemType   ItemType    // 物品类型
-	BlockType  BlockType   // 方块类型（用于显示方块的缩影）
-	Count      int         // 数量
-	Lifetime   int         // 存活时间
-	World      interface { // 世界引用（使用接口避免循环依赖）
-		IsBlockAt(x, y int) bool
-	}
+	X, Y        float64     // 位置
+	VX, VY      float64     // 速度
+	ItemType    ItemType    // 物品类型
+	BlockType   BlockType   // 方块类型（用于显示方块的缩影）
+	Count       int         // 数量
+	Durability  int         // 工具的剩余耐久度
+	Lifetime    int         // 存活时间
+	PickupDelay int         // 剩余的拾取延迟帧数
+	Magnetized  bool        // 本帧是否正被玩家吸附
+	World       World       // 世界引用（使用接口避免循环依赖）
 }
 
 // NewItemEntity 创建新的掉落物
+// 不在世界中的掉落物使用全局随机数决定初始速度，世界生成的掉落物通过Scatter改用世界的随机数
 func NewItemEntity(x, y float64, itemType ItemType, count int) *ItemEntity {
 	// 给掉落物一个随机的初始速度
-	angle := rand.Float64() * 2 * math.Pi
-	speed := 2.0 + rand.Float64()*2.0
-	vx := math.Cos(angle) * speed
-	vy := math.Sin(angle)*speed - 2 // 向上弹起
+	vx, vy := scatterVelocity(rand.Float64)
 
 	return &ItemEntity{
 		X:        x,
@@ -46,22 +52,20 @@ func NewItemEntity(x, y float64, itemType ItemType, count int) *ItemEntity {
 		VX:       vx,
 		VY:       vy,
 		ItemType: itemType,
-		BlockType: StoneBlock, // 默认为石头方块
+		BlockType: getItemToBlock(itemType), // 显示对应方块的缩影
 		Count:    count,
-		Lifetime: 600, // 10秒 (60fps * 10)
+		Lifetime: ItemLifetime,
+		PickupDelay: ItemPickupDelay,
 	}
 }
 
 // NewItemEntityFromBlock 创建来自方块的掉落物
 func NewItemEntityFromBlock(x, y float64, blockType BlockType, count int) *ItemEntity {
 	// 给掉落物一个随机的初始速度
-	angle := rand.Float64() * 2 * math.Pi
-	speed := 2.0 + rand.Float64()*2.0
-	vx := math.Cos(angle) * speed
-	vy := math.Sin(angle)*speed - 2 // 向上弹起
+	vx, vy := scatterVelocity(rand.Float64)
 
 	// 将方块类型转换为物品类型
-	itemType := getBlockToItem(blockType)
+	itemType, _ := GetBlockItem(blockType)
 
 	return &ItemEntity{
 		X:        x,
@@ -71,14 +75,60 @@ func NewItemEntityFromBlock(x, y float64, blockType BlockType, count int) *ItemE
 		ItemType: itemType,
 		BlockType: blockType,
 		Count:    count,
-		Lifetime: 600, // 10秒 (60fps * 10)
+		Lifetime: ItemLifetime,
+		PickupDelay: ItemPickupDelay,
+	}
+}
+
+// scatterVelocity 掉落物弹出的随机速度：向随机方向弹出并向上弹起
+func scatterVelocity(random func() float64) (float64, float64) {
+	angle := random() * 2 * math.Pi
+	speed := 2.0 + random()*2.0
+	return math.Cos(angle) * speed, math.Sin(angle)*speed - 2
+}
+
+// Scatter 用指定的随机数（例如世界由种子决定的随机数）重新决定掉落物弹出的速度
+func (item *ItemEntity) Scatter(rng *rand.Rand) {
+	item.VX, item.VY = scatterVelocity(rng.Float64)
+}
+
+// NewThrownItemEntity 创建玩家丢出的掉落物，朝(dirX, dirY)方向抛出
+// 丢出的物品有更长的拾取延迟，避免被立即捡回
+func NewThrownItemEntity(x, y float64, stack ItemStack, dirX, dirY float64) *ItemEntity {
+	length := math.Sqrt(dirX*dirX + dirY*dirY)
+	if length == 0 {
+		dirX, dirY, length = 1, 0, 1
+	}
+
+	return &ItemEntity{
+		X:           x,
+		Y:           y,
+		VX:          dirX / length * ItemThrowSpeed,
+		VY:          dirY/length*ItemThrowSpeed - 2, // 略微向上抛起
+		ItemType:    stack.Type,
+		BlockType:   getItemToBlock(stack.Type),
+		Count:       stack.Count,
+		Durability:  stack.Durability,
+		Lifetime:    ItemLifetime,
+		PickupDelay: ItemThrowDelay,
 	}
 }
 
 // Update 更新掉落物状态
 func (item *ItemEntity) Update() {
-	// 减少存活时间
+	// 减少存活时间和拾取延迟
 	item.Lifetime--
+	if item.PickupDelay > 0 {
+		item.PickupDelay--
+	}
+	
+	// 被吸附时直接飞向玩家，不受重力和碰撞影响
+	if item.Magnetized {
+		item.X += item.VX
+		item.Y += item.VY
+		item.Magnetized = false
+		return
+	}
 
 	// 应用重力
 	item.VY += ItemGravity
@@ -130,10 +180,10 @@ func (item *ItemEntity) checkHorizontalCollision(x, y float64) bool {
 
 	// 检查左边和右边是否有方块
 	for gridY := top; gridY <= bottom; gridY++ {
-		if item.VX < 0 && item.World.IsBlockAt(left, gridY) {
+		if item.VX < 0 && IsSolidBlockAt(item.World, left, gridY) {
 			return true
 		}
-		if item.VX > 0 && item.World.IsBlockAt(right, gridY) {
+		if item.VX > 0 && IsSolidBlockAt(item.World, right, gridY) {
 			return true
 		}
 	}
@@ -151,10 +201,10 @@ func (item *ItemEntity) checkVerticalCollision(x, y float64) bool {
 
 	// 检查顶部和底部是否有方块
 	for gridX := left; gridX <= right; gridX++ {
-		if item.VY < 0 && item.World.IsBlockAt(gridX, top) {
+		if item.VY < 0 && IsSolidBlockAt(item.World, gridX, top) {
 			return true
 		}
-		if item.VY > 0 && item.World.IsBlockAt(gridX, bottom) {
+		if item.VY > 0 && IsSolidBlockAt(item.World, gridX, bottom) {
 			return true
 		}
 	}
@@ -164,8 +214,8 @@ func (item *ItemEntity) checkVerticalCollision(x, y float64) bool {
 
 // Draw 绘制掉落物
 func (item *ItemEntity) Draw(screen *ebiten.Image, spriteSheet *ebiten.Image) {
-	// 如果是方块类型的掉落物，绘制方块的缩影
-	spriteIndex := GetBlockSpriteIndex(item.BlockType)
+	// 绘制物品精灵（方块类物品的精灵就是方块的缩影）
+	spriteIndex := GetItemSpriteIndex(item.ItemType)
 	
 	// 计算精灵在精灵表中的位置
 	spriteX := (spriteIndex % 20) * 32 // 每行20个精灵
@@ -198,8 +248,8 @@ func (item *ItemEntity) DrawWithCamera(screen *ebiten.Image, spriteSheet *ebiten
 	// 获取屏幕坐标
 	screenX, screenY := camera.WorldToScreen(item.X, item.Y)
 	
-	// 如果是方块类型的掉落物，绘制方块的缩影
-	spriteIndex := GetBlockSpriteIndex(item.BlockType)
+	// 绘制物品精灵（方块类物品的精灵就是方块的缩影）
+	spriteIndex := GetItemSpriteIndex(item.ItemType)
 	
 	// 计算精灵在精灵表中的位置
 	spriteX := (spriteIndex % 20) * 32 // 每行20个精灵
@@ -258,9 +308,73 @@ func (item *ItemEntity) TryPickup(playerX, playerY float64) bool {
 	return distance <= ItemPickupRange
 }
 
+// CanPickup 返回拾取延迟是否已经结束
+func (item *ItemEntity) CanPickup() bool {
+	return item.PickupDelay <= 0
+}
+
+// Attract 让掉落物在本帧飞向指定位置（玩家）
+func (item *ItemEntity) Attract(x, y float64) {
+	dx := x - item.X
+	dy := y - item.Y
+	distance := math.Sqrt(dx*dx + dy*dy)
+	if distance == 0 {
+		return
+	}
+	
+	speed := math.Min(ItemMagnetSpeed, distance)
+	item.VX = dx / distance * speed
+	item.VY = dy / distance * speed
+	item.Magnetized = true
+}
+
+// TryMerge 尝试将另一个相同的掉落物合并到当前掉落物中
+// 两者必须足够接近，且合并后不超过最大堆叠数量；返回是否合并成功
+func (item *ItemEntity) TryMerge(other *ItemEntity) bool {
+	if other == item || other.ItemType != item.ItemType || other.Durability != item.Durability {
+		return false
+	}
+	if item.Count+other.Count > GetMaxStackSize(item.ItemType) {
+		return false
+	}
+	
+	dx := item.X - other.X
+	dy := item.Y - other.Y
+	if math.Sqrt(dx*dx+dy*dy) > ItemMergeRange {
+		return false
+	}
+	
+	item.Count += other.Count
+	if other.Lifetime > item.Lifetime {
+		item.Lifetime = other.Lifetime
+	}
+	if other.PickupDelay > item.PickupDelay {
+		item.PickupDelay = other.PickupDelay
+	}
+	return true
+}
+
+// Tick 更新一帧（实现Entity）
+func (item *ItemEntity) Tick(world EntityWorld) {
+	item.Update()
+}
+
+// Bounds 获取掉落物占据的范围（实现Entity）
+func (item *ItemEntity) Bounds() Hitbox {
+	return NewHitbox(item.X, item.Y, ItemSize, ItemSize)
+}
+
+// IsRemoved 过期的掉落物从世界中移除（实现Entity）
+func (item *ItemEntity) IsRemoved() bool {
+	return item.IsExpired()
+}
+
+// DrawLayer 获取掉落物的绘制层（实现Entity）
+func (item *ItemEntity) DrawLayer() DrawLayer {
+	return LayerItems
+}
+
 // SetWorld 设置世界引用
-func (item *ItemEntity) SetWorld(world interface {
-	IsBlockAt(x, y int) bool
-}) {
+func (item *ItemEntity) SetWorld(world World) {
 	item.World = world
 }
@@ -0,0 +1,227 @@
+package entity
+
+const (
+	BombRadius = 2 // 炸弹的爆炸半径（格）
+	BombDamage = 6 // 炸弹对爆炸范围内的玩家造成的伤害
+)
+
+// ItemWorld 物品使用行为需要的世界操作（由world.World实现，使用接口避免循环依赖）
+type ItemWorld interface {
+	World
+	CanReach(gridX, gridY int) bool
+	IsOccupied(gridX, gridY int) bool
+	AddBlockWithType(x, y int, blockType BlockType)
+	RemoveBlock(x, y int)
+	PlaceRope(x, y int) (int, int, bool)
+	Damageables() []Damageable
+	AddProjectile(projectile *Projectile)
+	SummonBoss(name string, x, y int) bool
+}
+
+// Target 物品可以作用或攻击的实体（例如生物）
+type Target interface {
+	GetPosition() (float64, float64)
+}
+
+// ItemUseContext 使用物品时的上下文
+type ItemUseContext struct {
+	Player       *Player
+	World        ItemWorld
+	Stack        *ItemStack // 正在使用的物品堆叠，消耗物品时直接修改
+	GridX, GridY int        // 目标网格位置
+}
+
+// Consume 消耗一个正在使用的物品
+func (ctx *ItemUseContext) Consume() {
+	ctx.Stack.Count--
+	if ctx.Stack.Count <= 0 {
+		*ctx.Stack = ItemStack{Type: Air, Count: 0}
+	}
+}
+
+// ItemBehavior 物品的使用行为
+// 右键时目标处有实体调用UseOnEntity，有方块调用UseOnBlock，否则调用UseInAir；左键点击实体时调用Attack。
+// 返回物品是否被使用，返回false时游戏继续执行默认操作（例如挖掘）
+type ItemBehavior interface {
+	UseInAir(ctx *ItemUseContext) bool
+	UseOnBlock(ctx *ItemUseContext, block *Block) bool
+	UseOnEntity(ctx *ItemUseContext, target Target) bool
+	Attack(ctx *ItemUseContext, target Target) bool
+}
+
+// NoBehavior 没有使用效果的物品（木棍、煤炭等），其他行为嵌入它后只需实现用到的方法
+type NoBehavior struct{}
+
+func (NoBehavior) UseInAir(*ItemUseContext) bool            { return false }
+func (NoBehavior) UseOnBlock(*ItemUseContext, *Block) bool  { return false }
+func (NoBehavior) UseOnEntity(*ItemUseContext, Target) bool { return false }
+func (NoBehavior) Attack(*ItemUseContext, Target) bool      { return false }
+
+// PlaceBlockBehavior 在目标空位放置物品对应的方块
+type PlaceBlockBehavior struct{ NoBehavior }
+
+// UseInAir 目标必须在触及距离和视线范围内，实心方块不能放在玩家或掉落物所在的位置
+func (PlaceBlockBehavior) UseInAir(ctx *ItemUseContext) bool {
+	props := GetItemProperties(ctx.Stack.Type)
+	if !props.Placeable || !ctx.World.CanReach(ctx.GridX, ctx.GridY) {
+		return false
+	}
+	if props.Block.IsSolid() && ctx.World.IsOccupied(ctx.GridX, ctx.GridY) {
+		return false
+	}
+	ctx.World.AddBlockWithType(ctx.GridX, ctx.GridY, props.Block)
+	ctx.Consume()
+	return true
+}
+
+// RopeBehavior 放置绳索：绳索只能向下延伸，点击已有绳索时接在末端
+type RopeBehavior struct{ NoBehavior }
+
+func (RopeBehavior) UseInAir(ctx *ItemUseContext) bool {
+	return placeRope(ctx)
+}
+
+func (RopeBehavior) UseOnBlock(ctx *ItemUseContext, block *Block) bool {
+	if block.GetType() != RopeBlock {
+		return false
+	}
+	return placeRope(ctx)
+}
+
+// placeRope 从目标位置开始放置绳索
+func placeRope(ctx *ItemUseContext) bool {
+	if !ctx.World.CanReach(ctx.GridX, ctx.GridY) {
+		return false
+	}
+	if _, _, placed := ctx.World.PlaceRope(ctx.GridX, ctx.GridY); !placed {
+		return false
+	}
+	ctx.Consume()
+	return true
+}
+
+// TorchBehavior 放置火把：火把需要附着在下方或左右两侧的实心方块上
+type TorchBehavior struct{ PlaceBlockBehavior }
+
+func (b TorchBehavior) UseInAir(ctx *ItemUseContext) bool {
+	x, y := ctx.GridX, ctx.GridY
+	if !IsSolidBlockAt(ctx.World, x, y+1) && !IsSolidBlockAt(ctx.World, x-1, y) && !IsSolidBlockAt(ctx.World, x+1, y) {
+		return false
+	}
+	return b.PlaceBlockBehavior.UseInAir(ctx)
+}
+
+// ConsumeBehavior 食用或饮用：食物回复生命值，药水提供状态效果
+type ConsumeBehavior struct{ NoBehavior }
+
+func (ConsumeBehavior) UseInAir(ctx *ItemUseContext) bool {
+	if !ctx.Player.UseItem(ctx.Stack.Type) {
+		return false
+	}
+	ctx.Consume()
+	return true
+}
+
+// UseOnBlock 对着方块使用时和在空中使用相同
+func (b ConsumeBehavior) UseOnBlock(ctx *ItemUseContext, _ *Block) bool {
+	return b.UseInAir(ctx)
+}
+
+// BombBehavior 炸弹：在目标位置爆炸，破坏半径内的方块并伤害范围内的玩家
+type BombBehavior struct{ NoBehavior }
+
+func (BombBehavior) UseInAir(ctx *ItemUseContext) bool {
+	if !ctx.World.CanReach(ctx.GridX, ctx.GridY) {
+		return false
+	}
+	Explode(ctx.World, ctx.Player, ctx.GridX, ctx.GridY, BombRadius)
+	ctx.Consume()
+	return true
+}
+
+func (b BombBehavior) UseOnBlock(ctx *ItemUseContext, _ *Block) bool {
+	return b.UseInAir(ctx)
+}
+
+// Explode 以网格位置为中心爆炸：破坏圆形半径内的所有方块（方块照常掉落），
+// 玩家中心在爆炸范围内时受到伤害
+func Explode(world ItemWorld, player *Player, gridX, gridY, radius int) {
+	for dy := -radius; dy <= radius; dy++ {
+		for dx := -radius; dx <= radius; dx++ {
+			if dx*dx+dy*dy > radius*radius {
+				continue
+			}
+			if world.IsBlockAt(gridX+dx, gridY+dy) {
+				world.RemoveBlock(gridX+dx, gridY+dy)
+			}
+		}
+	}
+
+	if player == nil {
+		return
+	}
+	centerX := (float64(gridX) + 0.5) * BlockSize
+	centerY := (float64(gridY) + 0.5) * BlockSize
+	px, py := player.GetPosition()
+	reach := float64(radius+1) * BlockSize
+	if (px-centerX)*(px-centerX)+(py-centerY)*(py-centerY) <= reach*reach {
+		player.TakeDamage(BombDamage, DamageExplosion)
+	}
+}
+
+// SummonBehavior 召唤物品：在玩家周围封闭出竞技场并召唤以该物品为召唤物的首领，
+// 已经有首领战在进行时不能使用
+type SummonBehavior struct{ NoBehavior }
+
+func (SummonBehavior) UseInAir(ctx *ItemUseContext) bool {
+	boss, ok := Bosses.BySummonItem(ctx.Stack.Type)
+	if !ok {
+		return false
+	}
+	feet := playerGridPos(ctx.Player)
+	if !ctx.World.SummonBoss(boss.Name, feet.X, feet.Y) {
+		return false
+	}
+	ctx.Consume()
+	return true
+}
+
+func (b SummonBehavior) UseOnBlock(ctx *ItemUseContext, _ *Block) bool {
+	return b.UseInAir(ctx)
+}
+
+// itemBehaviors 可以在物品数据文件中通过名称指定的使用行为
+var itemBehaviors = map[string]ItemBehavior{
+	"none":    NoBehavior{},
+	"place":   PlaceBlockBehavior{},
+	"rope":    RopeBehavior{},
+	"torch":   TorchBehavior{},
+	"consume": ConsumeBehavior{},
+	"bomb":    BombBehavior{},
+	"weapon":  WeaponBehavior{},
+	"summon":  SummonBehavior{},
+}
+
+// RegisterItemBehavior 注册新的使用行为，之后加载的物品数据文件可以通过名称引用它
+func RegisterItemBehavior(name string, behavior ItemBehavior) {
+	itemBehaviors[name] = behavior
+}
+
+// GetItemBehavior 获取物品的使用行为：优先使用数据文件指定的行为，
+// 否则可放置的物品放置方块，武器用于攻击，食物和药水被消耗，其余物品没有使用效果
+func GetItemBehavior(itemType ItemType) ItemBehavior {
+	props := GetItemProperties(itemType)
+	if behavior, exists := itemBehaviors[props.Behavior]; exists {
+		return behavior
+	}
+	if props.Placeable {
+		return PlaceBlockBehavior{}
+	}
+	if props.Weapon != nil {
+		return WeaponBehavior{}
+	}
+	if _, usable := GetItemEffect(itemType); usable || props.Food > 0 {
+		return ConsumeBehavior{}
+	}
+	return NoBehavior{}
+}
@@ -0,0 +1,166 @@
+package entity
+
+import (
+	"reflect"
+	"testing"
+)
+
+// behaviorWorld 在MockWorld基础上实现物品使用行为需要的世界操作，所有位置都视为可触及
+type behaviorWorld struct {
+	*MockWorld
+	occupied    bool
+	targets     []Damageable
+	projectiles []*Projectile
+	summoned    []string
+}
+
+func (w *behaviorWorld) CanReach(gridX, gridY int) bool   { return true }
+func (w *behaviorWorld) IsOccupied(gridX, gridY int) bool { return w.occupied }
+
+func (w *behaviorWorld) RemoveBlock(x, y int) {
+	delete(w.blocks, blockKey(x, y))
+	delete(w.types, blockKey(x, y))
+}
+
+func (w *behaviorWorld) PlaceRope(x, y int) (int, int, bool) {
+	for w.IsBlockAt(x, y) {
+		y++
+	}
+	if !w.IsBlockAt(x, y-1) {
+		return x, y, false
+	}
+	w.AddBlockWithType(x, y, RopeBlock)
+	return x, y, true
+}
+
+func (w *behaviorWorld) Damageables() []Damageable { return w.targets }
+
+func (w *behaviorWorld) AddProjectile(projectile *Projectile) {
+	projectile.SetWorld(w)
+	w.projectiles = append(w.projectiles, projectile)
+}
+
+func (w *behaviorWorld) SummonBoss(name string, x, y int) bool {
+	if len(w.summoned) > 0 {
+		return false
+	}
+	w.summoned = append(w.summoned, name)
+	return true
+}
+
+func newBehaviorContext(stack ItemStack, x, y int) (*ItemUseContext, *behaviorWorld) {
+	world := &behaviorWorld{MockWorld: NewMockWorld()}
+	return &ItemUseContext{Player: NewPlayer(0, 0), World: world, Stack: &stack, GridX: x, GridY: y}, world
+}
+
+func TestGetItemBehavior(t *testing.T) {
+	apple, _ := ParseItemName("apple")
+	cases := map[ItemType]ItemBehavior{
+		Stone:           PlaceBlockBehavior{},
+		Furnace:         PlaceBlockBehavior{},
+		Rope:            RopeBehavior{},
+		Torch:           TorchBehavior{},
+		Bomb:            BombBehavior{},
+		SwiftnessPotion: ConsumeBehavior{},
+		apple:           ConsumeBehavior{},
+		Stick:           NoBehavior{},
+		IronPickaxe:     NoBehavior{},
+	}
+	for itemType, want := range cases {
+		if got := GetItemBehavior(itemType); reflect.TypeOf(got) != reflect.TypeOf(want) {
+			t.Errorf("Expected %s to use %T, got %T", GetItemName(itemType), want, got)
+		}
+	}
+}
+
+func TestPlaceBlockBehavior(t *testing.T) {
+	ctx, world := newBehaviorContext(ItemStack{Type: Planks, Count: 1}, 5, 5)
+	behavior := GetItemBehavior(Planks)
+
+	world.occupied = true
+	if behavior.UseInAir(ctx) {
+		t.Error("Expected solid block not to be placed on an occupied cell")
+	}
+	world.occupied = false
+	if !behavior.UseInAir(ctx) {
+		t.Fatal("Expected planks to be placed")
+	}
+	if block, ok := world.GetBlock(5, 5); !ok || block.Type != PlanksBlock {
+		t.Error("Expected a planks block at the target")
+	}
+	if ctx.Stack.Type != Air || ctx.Stack.Count != 0 {
+		t.Errorf("Expected the last item to be consumed, got %v", *ctx.Stack)
+	}
+}
+
+func TestRopeBehaviorExtendsRope(t *testing.T) {
+	ctx, world := newBehaviorContext(ItemStack{Type: Rope, Count: 2}, 5, 5)
+	world.AddBlockWithType(5, 4, StoneBlock)
+	behavior := GetItemBehavior(Rope)
+
+	if !behavior.UseInAir(ctx) {
+		t.Fatal("Expected rope to hang from the block above")
+	}
+	rope, _ := world.GetBlock(5, 5)
+	if !behavior.UseOnBlock(ctx, rope) || !world.IsBlockAt(5, 6) {
+		t.Error("Expected clicking the rope to extend it downwards")
+	}
+	stone, _ := world.GetBlock(5, 4)
+	ctx.Stack.Count = 1
+	if behavior.UseOnBlock(ctx, stone) {
+		t.Error("Expected rope not to be used on other blocks")
+	}
+}
+
+func TestTorchNeedsSupport(t *testing.T) {
+	ctx, world := newBehaviorContext(ItemStack{Type: Torch, Count: 2}, 5, 5)
+	behavior := GetItemBehavior(Torch)
+
+	if behavior.UseInAir(ctx) {
+		t.Error("Expected torch not to float in the air")
+	}
+	world.AddBlockWithType(6, 5, StoneBlock)
+	if !behavior.UseInAir(ctx) {
+		t.Fatal("Expected torch to attach to the wall")
+	}
+	if block, _ := world.GetBlock(5, 5); block.Type != TorchBlock || block.Type.IsSolid() {
+		t.Error("Expected a non-solid torch block")
+	}
+}
+
+func TestConsumeBehavior(t *testing.T) {
+	ctx, _ := newBehaviorContext(ItemStack{Type: SwiftnessPotion, Count: 2}, 5, 5)
+	if !GetItemBehavior(SwiftnessPotion).UseInAir(ctx) {
+		t.Fatal("Expected potion to be drunk")
+	}
+	if ctx.Stack.Count != 1 || !ctx.Player.HasEffect(EffectSwiftness) {
+		t.Error("Expected potion to be consumed and apply its effect")
+	}
+}
+
+func TestBombExplodes(t *testing.T) {
+	ctx, world := newBehaviorContext(ItemStack{Type: Bomb, Count: 1}, 5, 5)
+	for x := 2; x <= 8; x++ {
+		world.AddBlockWithType(x, 5, StoneBlock)
+	}
+	ctx.Player.SetPosition(5*BlockSize+16, 4*BlockSize+16)
+
+	block, _ := world.GetBlock(5, 5)
+	if !GetItemBehavior(Bomb).UseOnBlock(ctx, block) {
+		t.Fatal("Expected bomb to explode")
+	}
+	for x := 3; x <= 7; x++ {
+		if world.IsBlockAt(x, 5) {
+			t.Errorf("Expected block at %d to be destroyed", x)
+		}
+	}
+	if !world.IsBlockAt(2, 5) || !world.IsBlockAt(8, 5) {
+		t.Error("Expected blocks outside the radius to remain")
+	}
+	if ctx.Player.Health != PlayerMaxHealth-BombDamage || ctx.Player.LastDamageSource != DamageExplosion {
+		t.Error("Expected the nearby player to take explosion damage")
+	}
+	if ctx.Stack.Type != Air {
+		t.Error("Expected bomb to be consumed")
+	}
+}
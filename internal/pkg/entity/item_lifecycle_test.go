@@ -75,7 +75,7 @@ func TestItemEntityFromBlockCreation(t *testing.T) {
 		}
 		
 		// 检查物品类型是否正确转换
-		expectedItemType := getBlockToItem(blockType)
+		expectedItemType, _ := GetBlockItem(blockType)
 		if item.ItemType != expectedItemType {
 			t.Errorf("物品类型未正确转换，方块: %v, 期望物品: %v, 实际物品: %v", 
 				blockType, expectedItemType, item.ItemType)
@@ -100,4 +100,70 @@ func TestItemEntityCreation(t *testing.T) {
 			t.Errorf("物品数量未正确设置，期望: %d, 实际: %d", 1, item.Count)
 		}
 	}
-}
\ No newline at end of file
+}
+func TestItemEntityPickupDelay(t *testing.T) {
+	item := NewItemEntityFromBlock(100, 50, StoneBlock, 1)
+	if item.CanPickup() {
+		t.Error("新生成的掉落物不应立即可以拾取")
+	}
+
+	for i := 0; i < ItemPickupDelay; i++ {
+		item.Update()
+	}
+	if !item.CanPickup() {
+		t.Error("拾取延迟结束后应可以拾取")
+	}
+}
+
+func TestItemEntityAttract(t *testing.T) {
+	item := &ItemEntity{X: 100, Y: 100, ItemType: Stone, Count: 1, Lifetime: ItemLifetime}
+	item.Attract(200, 100)
+	item.Update()
+
+	if item.X != 100+ItemMagnetSpeed || item.Y != 100 {
+		t.Errorf("被吸附的掉落物应直线飞向目标，实际位置: (%v, %v)", item.X, item.Y)
+	}
+	if item.Magnetized {
+		t.Error("吸附状态应只持续一帧")
+	}
+}
+
+func TestItemEntityMerge(t *testing.T) {
+	a := &ItemEntity{X: 100, Y: 100, ItemType: Stone, Count: 3, Lifetime: 100}
+	b := &ItemEntity{X: 110, Y: 100, ItemType: Stone, Count: 2, Lifetime: 500, PickupDelay: 10}
+
+	if !a.TryMerge(b) {
+		t.Fatal("相邻的相同掉落物应该合并")
+	}
+	if a.Count != 5 || a.Lifetime != 500 || a.PickupDelay != 10 {
+		t.Errorf("合并结果不正确: count=%d lifetime=%d delay=%d", a.Count, a.Lifetime, a.PickupDelay)
+	}
+
+	if a.TryMerge(&ItemEntity{X: 100, Y: 100, ItemType: Dirt, Count: 1}) {
+		t.Error("不同类型的掉落物不应合并")
+	}
+	if a.TryMerge(&ItemEntity{X: 200, Y: 100, ItemType: Stone, Count: 1}) {
+		t.Error("距离过远的掉落物不应合并")
+	}
+	if a.TryMerge(&ItemEntity{X: 100, Y: 100, ItemType: Stone, Count: MaxStackSize}) {
+		t.Error("超过最大堆叠数量时不应合并")
+	}
+	tool := &ItemEntity{X: 0, Y: 0, ItemType: IronAxe, Count: 1, Durability: 10}
+	if tool.TryMerge(&ItemEntity{X: 0, Y: 0, ItemType: IronAxe, Count: 1, Durability: 10}) {
+		t.Error("工具不应合并")
+	}
+}
+
+func TestNewThrownItemEntity(t *testing.T) {
+	item := NewThrownItemEntity(0, 0, ItemStack{Type: IronAxe, Count: 1, Durability: 9}, -10, 0)
+
+	if item.VX >= 0 {
+		t.Error("丢出的物品应朝指定方向飞出")
+	}
+	if item.Durability != 9 || item.Count != 1 {
+		t.Error("丢出的物品应保留数量和耐久度")
+	}
+	if item.PickupDelay != ItemThrowDelay || item.CanPickup() {
+		t.Error("丢出的物品应有较长的拾取延迟")
+	}
+}
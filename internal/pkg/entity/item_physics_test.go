@@ -8,6 +8,7 @@ import (
 // MockWorld 是一个模拟的世界实现，用于测试
 type MockWorld struct {
 	blocks map[string]bool
+	types  map[string]BlockType
 }
 
 func (w *MockWorld) IsBlockAt(x, y int) bool {
@@ -15,6 +16,13 @@ func (w *MockWorld) IsBlockAt(x, y int) bool {
 	return w.blocks[key]
 }
 
+func (w *MockWorld) GetBlock(x, y int) (*Block, bool) {
+	if !w.IsBlockAt(x, y) {
+		return nil, false
+	}
+	return NewBlockWithType(x, y, w.types[blockKey(x, y)]), true
+}
+
 func blockKey(x, y int) string {
 	return string(rune(x)) + "," + string(rune(y))
 }
@@ -22,6 +30,7 @@ func blockKey(x, y int) string {
 func NewMockWorld() *MockWorld {
 	return &MockWorld{
 		blocks: make(map[string]bool),
+		types:  make(map[string]BlockType),
 	}
 }
 
@@ -30,6 +39,12 @@ func (w *MockWorld) AddBlock(x, y int) {
 	w.blocks[key] = true
 }
 
+func (w *MockWorld) AddBlockWithType(x, y int, blockType BlockType) {
+	key := blockKey(x, y)
+	w.blocks[key] = true
+	w.types[key] = blockType
+}
+
 func TestItemPhysicsUpdate(t *testing.T) {
 	// 创建一个模拟世界
 	mockWorld := NewMockWorld()
@@ -156,4 +171,4 @@ func TestItemPhysicsGravityEffect(t *testing.T) {
 	if item.VY > ItemMaxFallSpeed {
 		t.Errorf("物品下落速度不应超过最大值，当前: %f, 最大: %f", item.VY, ItemMaxFallSpeed)
 	}
-}
\ No newline at end of file
+}
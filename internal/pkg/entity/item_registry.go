@@ -0,0 +1,334 @@
+package entity
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+)
+
+// Rarity 物品稀有度
+type Rarity int
+
+const (
+	RarityCommon Rarity = iota
+	RarityUncommon
+	RarityRare
+	RarityEpic
+)
+
+// rarityNames 稀有度在数据文件中使用的名称
+var rarityNames = map[string]Rarity{
+	"common":   RarityCommon,
+	"uncommon": RarityUncommon,
+	"rare":     RarityRare,
+	"epic":     RarityEpic,
+}
+
+// toolKindNames 工具种类在数据文件中使用的名称
+var toolKindNames = map[string]ToolKind{
+	"pickaxe": ToolPickaxe,
+	"axe":     ToolAxe,
+	"shovel":  ToolShovel,
+}
+
+// toolTierNames 工具等级在数据文件中使用的名称
+var toolTierNames = map[string]ToolTier{
+	"wood":  TierWood,
+	"stone": TierStone,
+	"iron":  TierIron,
+}
+
+// weaponKindNames 武器种类在数据文件中使用的名称
+var weaponKindNames = map[string]WeaponKind{
+	"melee":  WeaponMelee,
+	"ranged": WeaponRanged,
+	"thrown": WeaponThrown,
+}
+
+// projectileKindNames 投射物种类在数据文件中使用的名称
+var projectileKindNames = map[string]ProjectileKind{
+	"arrow": ProjectileArrow,
+	"knife": ProjectileKnife,
+}
+
+// ItemProperties 物品属性
+type ItemProperties struct {
+	Type        ItemType
+	Name        string            // 数据文件中使用的名称，例如"stone"
+	DisplayName string            // 显示给玩家的名称
+	Sprite      int               // 精灵表索引
+	MaxStack    int               // 最大堆叠数量
+	Block       BlockType         // 放置后成为的方块
+	Placeable   bool              // 是否可以作为方块放置
+	Tool        *ToolProperties   // 工具属性，不是工具时为nil
+	Weapon      *WeaponProperties // 武器属性，不是武器时为nil
+	Food        int               // 食用后回复的生命值，0表示不能食用
+	Rarity      Rarity            // 稀有度
+	Behavior    string            // 使用行为的名称（见itemBehaviors），为空时根据其他属性选择
+}
+
+// ItemRegistry 物品注册表
+// 内置物品（ItemType常量）的编号固定，数据文件中新增的物品按注册顺序分配新的编号
+type ItemRegistry struct {
+	items  map[ItemType]*ItemProperties
+	byName map[string]ItemType
+	next   ItemType // 下一个可分配给新物品的编号
+}
+
+// Items 全局物品注册表，启动时通过LoadItems从数据文件加载物品属性
+var Items = NewItemRegistry()
+
+// NewItemRegistry 创建只包含内置物品名称和默认属性的注册表
+func NewItemRegistry() *ItemRegistry {
+	registry := &ItemRegistry{
+		items:  make(map[ItemType]*ItemProperties),
+		byName: make(map[string]ItemType),
+	}
+	for itemType, name := range builtinItemNames {
+		props := defaultItemProperties(itemType, name)
+		registry.items[itemType] = &props
+		registry.byName[name] = itemType
+		if itemType >= registry.next {
+			registry.next = itemType + 1
+		}
+	}
+	return registry
+}
+
+// defaultItemProperties 数据文件没有提供属性时物品使用的默认属性
+func defaultItemProperties(itemType ItemType, name string) ItemProperties {
+	return ItemProperties{
+		Type:        itemType,
+		Name:        name,
+		DisplayName: name,
+		Sprite:      StoneItemSprite,
+		MaxStack:    MaxStackSize,
+	}
+}
+
+// Register 注册物品属性，返回物品的编号
+// 名称与已有物品相同时覆盖其属性，否则作为新物品分配编号
+func (r *ItemRegistry) Register(props ItemProperties) ItemType {
+	itemType, exists := r.byName[props.Name]
+	if !exists {
+		itemType = r.next
+		r.next++
+		r.byName[props.Name] = itemType
+	}
+	props.Type = itemType
+	r.items[itemType] = &props
+	return itemType
+}
+
+// Get 获取物品属性，未知物品返回默认属性
+func (r *ItemRegistry) Get(itemType ItemType) ItemProperties {
+	if props, exists := r.items[itemType]; exists {
+		return *props
+	}
+	return defaultItemProperties(itemType, "unknown")
+}
+
+// Lookup 根据名称查找物品
+func (r *ItemRegistry) Lookup(name string) (ItemType, bool) {
+	itemType, exists := r.byName[name]
+	return itemType, exists
+}
+
+// ForBlock 查找放置后成为指定方块的物品，有多个时返回编号最小的
+func (r *ItemRegistry) ForBlock(blockType BlockType) (ItemType, bool) {
+	for _, props := range r.All() {
+		if props.Placeable && props.Block == blockType {
+			return props.Type, true
+		}
+	}
+	return Air, false
+}
+
+// All 获取所有已注册的物品属性，按编号排序
+func (r *ItemRegistry) All() []ItemProperties {
+	all := make([]ItemProperties, 0, len(r.items))
+	for _, props := range r.items {
+		all = append(all, *props)
+	}
+	sort.Slice(all, func(i, j int) bool {
+		return all[i].Type < all[j].Type
+	})
+	return all
+}
+
+// itemFile 物品数据文件的格式
+type itemFile struct {
+	Items []itemData `json:"items"`
+}
+
+// itemData 数据文件中的一个物品
+type itemData struct {
+	Name        string `json:"name"`
+	DisplayName string `json:"display_name"`
+	Sprite      string `json:"sprite"`
+	MaxStack    int    `json:"max_stack"`
+	Block       string `json:"block"`
+	Tool        *struct {
+		Kind       string  `json:"kind"`
+		Tier       string  `json:"tier"`
+		Speed      float64 `json:"speed"`
+		Durability int     `json:"durability"`
+	} `json:"tool"`
+	Weapon *struct {
+		Kind       string  `json:"kind"`
+		Damage     int     `json:"damage"`
+		Knockback  float64 `json:"knockback"`
+		Range      float64 `json:"range"`
+		Arc        float64 `json:"arc"`
+		Cooldown   int     `json:"cooldown"`
+		Projectile string  `json:"projectile"`
+		Speed      float64 `json:"speed"`
+	} `json:"weapon"`
+	Food     int    `json:"food"`
+	Rarity   string `json:"rarity"`
+	Behavior string `json:"behavior"`
+}
+
+// LoadItems 从JSON文件加载物品属性，替换全局物品注册表
+func LoadItems(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	registry, err := ParseItems(data)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	Items = registry
+	return nil
+}
+
+// ParseItems 解析JSON格式的物品数据
+func ParseItems(data []byte) (*ItemRegistry, error) {
+	var file itemFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, err
+	}
+
+	registry := NewItemRegistry()
+	for _, entry := range file.Items {
+		props, err := entry.toProperties()
+		if err != nil {
+			return nil, fmt.Errorf("item %q: %w", entry.Name, err)
+		}
+		registry.Register(props)
+	}
+	return registry, nil
+}
+
+// toProperties 把数据文件中的物品转换为物品属性
+func (d itemData) toProperties() (ItemProperties, error) {
+	if d.Name == "" {
+		return ItemProperties{}, fmt.Errorf("missing name")
+	}
+
+	props := ItemProperties{
+		Name:        d.Name,
+		DisplayName: d.DisplayName,
+		MaxStack:    d.MaxStack,
+		Food:        d.Food,
+	}
+	if props.DisplayName == "" {
+		props.DisplayName = d.Name
+	}
+
+	sprite, exists := SpriteMap[d.Sprite]
+	if !exists {
+		return props, fmt.Errorf("unknown sprite %q", d.Sprite)
+	}
+	props.Sprite = sprite.Index
+
+	if d.Block != "" {
+		blockType, ok := ParseBlockName(d.Block)
+		if !ok {
+			return props, fmt.Errorf("unknown block %q", d.Block)
+		}
+		props.Block, props.Placeable = blockType, true
+	}
+
+	if d.Tool != nil {
+		kind, ok := toolKindNames[d.Tool.Kind]
+		if !ok {
+			return props, fmt.Errorf("unknown tool kind %q", d.Tool.Kind)
+		}
+		tier, ok := toolTierNames[d.Tool.Tier]
+		if !ok {
+			return props, fmt.Errorf("unknown tool tier %q", d.Tool.Tier)
+		}
+		props.Tool = &ToolProperties{Kind: kind, Tier: tier, Speed: d.Tool.Speed, MaxDurability: d.Tool.Durability}
+		// 工具不能堆叠
+		props.MaxStack = 1
+	}
+	if d.Weapon != nil {
+		weapon, err := d.weaponProperties()
+		if err != nil {
+			return props, err
+		}
+		props.Weapon = &weapon
+		// 投掷武器本身就是弹药，可以堆叠，其他武器不能堆叠
+		if weapon.Kind != WeaponThrown {
+			props.MaxStack = 1
+		}
+	}
+	if props.MaxStack <= 0 {
+		props.MaxStack = MaxStackSize
+	}
+
+	if d.Rarity != "" {
+		rarity, ok := rarityNames[d.Rarity]
+		if !ok {
+			return props, fmt.Errorf("unknown rarity %q", d.Rarity)
+		}
+		props.Rarity = rarity
+	}
+
+	if d.Behavior != "" {
+		if _, ok := itemBehaviors[d.Behavior]; !ok {
+			return props, fmt.Errorf("unknown behavior %q", d.Behavior)
+		}
+		props.Behavior = d.Behavior
+	}
+	return props, nil
+}
+
+// weaponProperties 把数据文件中的武器属性转换为武器属性，远程和投掷武器必须指定投射物
+func (d itemData) weaponProperties() (WeaponProperties, error) {
+	kind, ok := weaponKindNames[d.Weapon.Kind]
+	if !ok {
+		return WeaponProperties{}, fmt.Errorf("unknown weapon kind %q", d.Weapon.Kind)
+	}
+	weapon := WeaponProperties{
+		Kind:      kind,
+		Damage:    d.Weapon.Damage,
+		Knockback: d.Weapon.Knockback,
+		Range:     d.Weapon.Range,
+		Arc:       d.Weapon.Arc,
+		Cooldown:  d.Weapon.Cooldown,
+		Speed:     d.Weapon.Speed,
+	}
+	if kind == WeaponMelee {
+		return weapon, nil
+	}
+	projectile, ok := projectileKindNames[d.Weapon.Projectile]
+	if !ok {
+		return weapon, fmt.Errorf("unknown projectile %q", d.Weapon.Projectile)
+	}
+	weapon.Projectile = projectile
+	return weapon, nil
+}
+
+// GetItemProperties 从全局物品注册表获取物品属性
+func GetItemProperties(itemType ItemType) ItemProperties {
+	return Items.Get(itemType)
+}
+
+// GetFoodValue 获取物品食用后回复的生命值，不能食用时为0
+func GetFoodValue(itemType ItemType) int {
+	return Items.Get(itemType).Food
+}
@@ -0,0 +1,143 @@
+package entity
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+)
+
+// TestMain 测试前加载物品、首领、战利品表和天赋数据文件，与游戏启动时一致
+func TestMain(m *testing.M) {
+	if err := LoadItems("../../../data/items.json"); err != nil {
+		panic(err)
+	}
+	if err := LoadBosses("../../../data/bosses.json"); err != nil {
+		panic(err)
+	}
+	if err := LoadLootTables("../../../data/loot_tables.json"); err != nil {
+		panic(err)
+	}
+	if err := LoadPerks("../../../data/perks.json"); err != nil {
+		panic(err)
+	}
+	os.Exit(m.Run())
+}
+
+func TestItemDataFile(t *testing.T) {
+	if GetMaxStackSize(Stone) != MaxStackSize || GetMaxStackSize(SwiftnessPotion) != 16 {
+		t.Error("Expected stack sizes from the data file")
+	}
+
+	props := GetItemProperties(Furnace)
+	if !props.Placeable || props.Block != FurnaceBlock || props.DisplayName != "Furnace" {
+		t.Errorf("Unexpected furnace properties %+v", props)
+	}
+	if GetItemProperties(Stick).Placeable || GetItemProperties(Coal).Placeable {
+		t.Error("Expected sticks and coal not to be placeable")
+	}
+
+	tool, ok := GetToolProperties(IronPickaxe)
+	if !ok || tool.Kind != ToolPickaxe || tool.Tier != TierIron || tool.MaxDurability != 250 {
+		t.Errorf("Unexpected iron pickaxe properties %+v", tool)
+	}
+	if GetItemProperties(IronPickaxe).Rarity != RarityUncommon {
+		t.Error("Expected iron tools to be uncommon")
+	}
+
+	// 苹果只在数据文件中定义
+	apple, ok := ParseItemName("apple")
+	if !ok || apple <= Bomb {
+		t.Fatal("Expected apple to be registered as a new item")
+	}
+	if GetFoodValue(apple) <= 0 || GetItemSpriteIndex(apple) != AppleSprite {
+		t.Error("Expected apple food value and sprite from the data file")
+	}
+
+	// 每个内置物品都应该在数据文件中定义
+	for itemType := Stone; itemType <= Bomb; itemType++ {
+		if GetItemProperties(itemType).DisplayName == GetItemName(itemType) {
+			t.Errorf("Expected item %s to have properties in the data file", GetItemName(itemType))
+		}
+	}
+}
+
+func TestParseItems(t *testing.T) {
+	registry, err := ParseItems([]byte(`{"items": [
+		{"name": "stone", "sprite": "stone_item", "block": "stone", "max_stack": 32},
+		{"name": "golden_pickaxe", "display_name": "Golden Pickaxe", "sprite": "iron_pickaxe", "max_stack": 8,
+		 "tool": {"kind": "pickaxe", "tier": "iron", "speed": 10, "durability": 30}, "rarity": "epic"}
+	]}`))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if registry.Get(Stone).MaxStack != 32 {
+		t.Error("Expected data file to override built-in item properties")
+	}
+	golden, ok := registry.Lookup("golden_pickaxe")
+	if !ok {
+		t.Fatal("Expected new item to be registered")
+	}
+	props := registry.Get(golden)
+	if props.MaxStack != 1 || props.Tool == nil || props.Tool.Speed != 10 || props.Rarity != RarityEpic {
+		t.Errorf("Unexpected golden pickaxe properties %+v", props)
+	}
+	if len(registry.All()) != len(builtinItemNames)+1 {
+		t.Errorf("Expected %d items, got %d", len(builtinItemNames)+1, len(registry.All()))
+	}
+}
+
+func TestParseItemsErrors(t *testing.T) {
+	bad := []string{
+		`{"items": [{"sprite": "stone_item"}]}`,
+		`{"items": [{"name": "x", "sprite": "no_such_sprite"}]}`,
+		`{"items": [{"name": "x", "sprite": "stone_item", "block": "no_such_block"}]}`,
+		`{"items": [{"name": "x", "sprite": "stone_item", "tool": {"kind": "hammer", "tier": "iron"}}]}`,
+		`{"items": [{"name": "x", "sprite": "stone_item", "tool": {"kind": "axe", "tier": "gold"}}]}`,
+		`{"items": [{"name": "x", "sprite": "stone_item", "rarity": "legendary"}]}`,
+		`{"items": [{"name": "x", "sprite": "stone_item", "behavior": "teleport"}]}`,
+		`{"items": [{"name": "x", "sprite": "stone_item", "weapon": {"kind": "magic"}}]}`,
+		`{"items": [{"name": "x", "sprite": "stone_item", "weapon": {"kind": "ranged", "projectile": "rock"}}]}`,
+		`not json`,
+	}
+	for _, data := range bad {
+		if _, err := ParseItems([]byte(data)); err == nil {
+			t.Errorf("Expected error for %s", data)
+		}
+	}
+}
+
+func TestItemTypeJSON(t *testing.T) {
+	data, err := json.Marshal(ItemStack{Type: IronIngot, Count: 3})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var stack ItemStack
+	if err := json.Unmarshal(data, &stack); err != nil || stack.Type != IronIngot || stack.Count != 3 {
+		t.Errorf("Expected item stack to round-trip by name, got %v (%v)", stack, err)
+	}
+	if err := json.Unmarshal([]byte(`{"Type": "no_such_item"}`), &stack); err == nil {
+		t.Error("Expected error for unknown item name")
+	}
+	// 旧存档按编号保存物品类型
+	if err := json.Unmarshal([]byte(`{"Type": 3, "Count": 2}`), &stack); err != nil || stack.Type != Wood || stack.Count != 2 {
+		t.Errorf("Expected numeric item type to be read, got %v (%v)", stack, err)
+	}
+	if err := json.Unmarshal([]byte(`{"Type": 999}`), &stack); err == nil {
+		t.Error("Expected error for unknown item number")
+	}
+}
+
+func TestEatingFood(t *testing.T) {
+	apple, _ := ParseItemName("apple")
+	player := NewPlayer(0, 0)
+	if player.UseItem(apple) {
+		t.Error("Expected food not to be eaten at full health")
+	}
+
+	player.Health = player.MaxHealth - 10
+	if !player.UseItem(apple) || player.Health != player.MaxHealth-10+GetFoodValue(apple) {
+		t.Error("Expected food to restore health")
+	}
+}
@@ -0,0 +1,371 @@
+package entity
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"os"
+	"sort"
+	"strings"
+)
+
+// LootContext 抽取战利品时的环境，条件根据它判断，随机数由调用方提供以保证结果可以复现
+type LootContext struct {
+	Tool  ToolKind   // 破坏方块时使用的工具种类
+	Depth int        // 网格深度（y）
+	Biome string     // 生物群落的名称，不区分大小写
+	Rng   *rand.Rand // 抽取使用的随机数
+}
+
+// LootCondition 战利品条目或奖池生效的条件，未设置的条件总是满足
+type LootCondition struct {
+	Tools    []ToolKind // 使用其中一种工具时生效
+	MinDepth *int       // 深度不小于该值时生效
+	MaxDepth *int       // 深度不大于该值时生效
+	Biomes   []string   // 位于其中一个生物群落时生效
+}
+
+// Matches 检查环境是否满足条件
+func (c LootCondition) Matches(ctx LootContext) bool {
+	if len(c.Tools) > 0 {
+		matched := false
+		for _, tool := range c.Tools {
+			if tool == ctx.Tool {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	if c.MinDepth != nil && ctx.Depth < *c.MinDepth {
+		return false
+	}
+	if c.MaxDepth != nil && ctx.Depth > *c.MaxDepth {
+		return false
+	}
+	if len(c.Biomes) > 0 {
+		matched := false
+		for _, biome := range c.Biomes {
+			if strings.EqualFold(biome, ctx.Biome) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+// LootEntry 奖池中的一项：掉落物品、抽取另一张战利品表，或者什么都不掉落（两者都为空）
+type LootEntry struct {
+	Item      ItemType
+	Table     string // 嵌套的战利品表名称
+	Weight    int
+	Min, Max  int // 物品的数量范围
+	Condition LootCondition
+}
+
+// LootPool 战利品表中的一个奖池：每次抽取若干次，每次按权重从满足条件的条目中选出一项
+type LootPool struct {
+	RollsMin, RollsMax int
+	Distinct           bool // 同一次抽取中每个条目最多被选中一次
+	Condition          LootCondition
+	Entries            []LootEntry
+}
+
+// LootTable 战利品表，依次抽取每个奖池
+type LootTable struct {
+	Name  string
+	Pools []LootPool
+}
+
+// maxLootDepth 嵌套战利品表的最大层数，加载时已经排除了循环引用，这里只是保险
+const maxLootDepth = 16
+
+// LootTableRegistry 战利品表注册表，按名称查找战利品表
+type LootTableRegistry struct {
+	tables map[string]*LootTable
+}
+
+// LootTables 全局战利品表注册表，启动时通过LoadLootTables从数据文件加载
+var LootTables = NewLootTableRegistry()
+
+// NewLootTableRegistry 创建空的战利品表注册表
+func NewLootTableRegistry() *LootTableRegistry {
+	return &LootTableRegistry{tables: make(map[string]*LootTable)}
+}
+
+// Register 注册战利品表，同名的战利品表会被替换
+func (r *LootTableRegistry) Register(table LootTable) {
+	r.tables[table.Name] = &table
+}
+
+// Has 检查战利品表是否存在
+func (r *LootTableRegistry) Has(name string) bool {
+	_, exists := r.tables[name]
+	return exists
+}
+
+// Names 获取所有战利品表的名称，按名称排序
+func (r *LootTableRegistry) Names() []string {
+	names := make([]string, 0, len(r.tables))
+	for name := range r.tables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Roll 抽取战利品表，相同种类的物品合并为一个堆叠，按第一次抽到的顺序排列
+// 战利品表不存在时返回false
+func (r *LootTableRegistry) Roll(name string, ctx LootContext) ([]ItemStack, bool) {
+	if !r.Has(name) {
+		return nil, false
+	}
+	var stacks []ItemStack
+	r.roll(name, ctx, 0, func(item ItemType, count int) {
+		for i := range stacks {
+			if stacks[i].Type == item {
+				stacks[i].Count += count
+				return
+			}
+		}
+		stacks = append(stacks, ItemStack{Type: item, Count: count})
+	})
+	return stacks, true
+}
+
+// roll 抽取战利品表，每抽到一个物品调用一次add
+func (r *LootTableRegistry) roll(name string, ctx LootContext, depth int, add func(item ItemType, count int)) {
+	table, exists := r.tables[name]
+	if !exists || depth > maxLootDepth {
+		return
+	}
+	for _, pool := range table.Pools {
+		if !pool.Condition.Matches(ctx) {
+			continue
+		}
+		entries := make([]LootEntry, 0, len(pool.Entries))
+		for _, entry := range pool.Entries {
+			if entry.Condition.Matches(ctx) {
+				entries = append(entries, entry)
+			}
+		}
+		rolls := pool.RollsMin + ctx.Rng.Intn(pool.RollsMax-pool.RollsMin+1)
+		for i := 0; i < rolls && len(entries) > 0; i++ {
+			index := pickLootEntry(entries, ctx.Rng)
+			entry := entries[index]
+			if pool.Distinct {
+				entries = append(entries[:index:index], entries[index+1:]...)
+			}
+			switch {
+			case entry.Table != "":
+				r.roll(entry.Table, ctx, depth+1, add)
+			case entry.Item != Air:
+				add(entry.Item, entry.Min+ctx.Rng.Intn(entry.Max-entry.Min+1))
+			}
+		}
+	}
+}
+
+// pickLootEntry 按权重随机选出一个条目的下标
+func pickLootEntry(entries []LootEntry, rng *rand.Rand) int {
+	total := 0
+	for _, entry := range entries {
+		total += entry.Weight
+	}
+	pick := rng.Intn(total)
+	for i, entry := range entries {
+		pick -= entry.Weight
+		if pick < 0 {
+			return i
+		}
+	}
+	return len(entries) - 1
+}
+
+// lootTableFile 战利品表数据文件的格式
+type lootTableFile struct {
+	Tables []lootTableData `json:"tables"`
+}
+
+// lootTableData 数据文件中的一张战利品表
+type lootTableData struct {
+	Name  string `json:"name"`
+	Pools []struct {
+		Rolls *struct {
+			Min int `json:"min"`
+			Max int `json:"max"`
+		} `json:"rolls"`
+		Distinct   bool              `json:"distinct"`
+		Conditions lootConditionData `json:"conditions"`
+		Entries    []struct {
+			Item       string            `json:"item"`
+			Table      string            `json:"table"`
+			Weight     *int              `json:"weight"`
+			Min        int               `json:"min"`
+			Max        int               `json:"max"`
+			Conditions lootConditionData `json:"conditions"`
+		} `json:"entries"`
+	} `json:"pools"`
+}
+
+// lootConditionData 数据文件中的条件
+type lootConditionData struct {
+	Tools    []string `json:"tools"`
+	MinDepth *int     `json:"min_depth"`
+	MaxDepth *int     `json:"max_depth"`
+	Biomes   []string `json:"biomes"`
+}
+
+// LoadLootTables 从JSON文件加载战利品表，替换全局战利品表注册表
+// 战利品表引用物品名称，需要先加载物品
+func LoadLootTables(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	registry, err := ParseLootTables(data)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	LootTables = registry
+	return nil
+}
+
+// ParseLootTables 解析JSON格式的战利品表，检查嵌套的战利品表是否存在以及是否循环引用
+func ParseLootTables(data []byte) (*LootTableRegistry, error) {
+	var file lootTableFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, err
+	}
+
+	registry := NewLootTableRegistry()
+	for _, entry := range file.Tables {
+		table, err := entry.toTable()
+		if err != nil {
+			return nil, fmt.Errorf("loot table %q: %w", entry.Name, err)
+		}
+		if registry.Has(table.Name) {
+			return nil, fmt.Errorf("loot table %q: duplicate name", table.Name)
+		}
+		registry.Register(table)
+	}
+
+	for _, name := range registry.Names() {
+		if err := registry.checkNested(name, nil); err != nil {
+			return nil, fmt.Errorf("loot table %q: %w", name, err)
+		}
+	}
+	return registry, nil
+}
+
+// checkNested 检查战利品表引用的嵌套战利品表都存在并且没有循环引用，path是引用链
+func (r *LootTableRegistry) checkNested(name string, path []string) error {
+	for _, visited := range path {
+		if visited == name {
+			return fmt.Errorf("nested tables form a cycle: %s", strings.Join(append(path, name), " -> "))
+		}
+	}
+	path = append(path, name)
+	for _, pool := range r.tables[name].Pools {
+		for _, entry := range pool.Entries {
+			if entry.Table == "" {
+				continue
+			}
+			if !r.Has(entry.Table) {
+				return fmt.Errorf("unknown nested table %q", entry.Table)
+			}
+			if err := r.checkNested(entry.Table, path); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// toTable 把数据文件中的战利品表转换为战利品表
+func (d lootTableData) toTable() (LootTable, error) {
+	if d.Name == "" {
+		return LootTable{}, fmt.Errorf("missing name")
+	}
+	table := LootTable{Name: d.Name}
+	for i, poolData := range d.Pools {
+		pool := LootPool{RollsMin: 1, RollsMax: 1, Distinct: poolData.Distinct}
+		if poolData.Rolls != nil {
+			pool.RollsMin, pool.RollsMax = poolData.Rolls.Min, poolData.Rolls.Max
+			if pool.RollsMax < pool.RollsMin {
+				pool.RollsMax = pool.RollsMin
+			}
+		}
+		if pool.RollsMin < 0 {
+			return table, fmt.Errorf("pool %d: rolls must not be negative", i+1)
+		}
+		condition, err := poolData.Conditions.toCondition()
+		if err != nil {
+			return table, fmt.Errorf("pool %d: %w", i+1, err)
+		}
+		pool.Condition = condition
+		if len(poolData.Entries) == 0 {
+			return table, fmt.Errorf("pool %d: missing entries", i+1)
+		}
+
+		for _, entryData := range poolData.Entries {
+			entry := LootEntry{Table: entryData.Table, Weight: 1, Min: entryData.Min, Max: entryData.Max}
+			if entryData.Weight != nil {
+				entry.Weight = *entryData.Weight
+			}
+			if entry.Weight <= 0 {
+				return table, fmt.Errorf("pool %d: weight must be positive", i+1)
+			}
+			if entryData.Item != "" && entryData.Table != "" {
+				return table, fmt.Errorf("pool %d: entry has both item %q and table %q", i+1, entryData.Item, entryData.Table)
+			}
+			if entryData.Item != "" {
+				item, ok := ParseItemName(entryData.Item)
+				if !ok {
+					return table, fmt.Errorf("pool %d: unknown item %q", i+1, entryData.Item)
+				}
+				entry.Item = item
+			}
+			if entry.Min <= 0 {
+				entry.Min = 1
+			}
+			if entry.Max < entry.Min {
+				entry.Max = entry.Min
+			}
+			condition, err := entryData.Conditions.toCondition()
+			if err != nil {
+				return table, fmt.Errorf("pool %d: %w", i+1, err)
+			}
+			entry.Condition = condition
+			pool.Entries = append(pool.Entries, entry)
+		}
+		table.Pools = append(table.Pools, pool)
+	}
+	return table, nil
+}
+
+// toCondition 把数据文件中的条件转换为战利品条件，"none"表示空手
+func (d lootConditionData) toCondition() (LootCondition, error) {
+	condition := LootCondition{MinDepth: d.MinDepth, MaxDepth: d.MaxDepth, Biomes: d.Biomes}
+	for _, name := range d.Tools {
+		if name == "none" {
+			condition.Tools = append(condition.Tools, ToolNone)
+			continue
+		}
+		tool, ok := toolKindNames[name]
+		if !ok {
+			return condition, fmt.Errorf("unknown tool %q", name)
+		}
+		condition.Tools = append(condition.Tools, tool)
+	}
+	if d.MinDepth != nil && d.MaxDepth != nil && *d.MaxDepth < *d.MinDepth {
+		return condition, fmt.Errorf("max depth is less than min depth")
+	}
+	return condition, nil
+}
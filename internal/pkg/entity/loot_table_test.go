@@ -0,0 +1,137 @@
+package entity
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestLootTablesDataFile(t *testing.T) {
+	for _, name := range []string{"blocks/leaves", "chests/cave", "mobs/slime", "mobs/cave_crawler", "mobs/critter"} {
+		if !LootTables.Has(name) {
+			t.Errorf("Expected loot table %q in the data file", name)
+		}
+	}
+	// 每种可以破坏的方块都有战利品表
+	for blockType, name := range blockNames {
+		if !GetBlockProperties(blockType).Unbreakable && !LootTables.Has("blocks/"+name) {
+			t.Errorf("Expected a loot table for breakable block %q", name)
+		}
+	}
+	if _, ok := ParseItemName("sapling"); !ok {
+		t.Error("Expected sapling item in the data file")
+	}
+
+	// 树叶总是掉落自己，偶尔额外掉落苹果或树苗
+	apple, _ := ParseItemName("apple")
+	sapling, _ := ParseItemName("sapling")
+	rng := rand.New(rand.NewSource(1))
+	extras := 0
+	for i := 0; i < 500; i++ {
+		stacks, _ := LootTables.Roll("blocks/leaves", LootContext{Biome: "Plains", Rng: rng})
+		if len(stacks) == 0 || stacks[0].Type != Leaves {
+			t.Fatalf("Expected leaves to drop leaves first, got %v", stacks)
+		}
+		for _, stack := range stacks[1:] {
+			if stack.Type == apple || stack.Type == sapling {
+				extras++
+			}
+		}
+	}
+	if extras == 0 || extras > 150 {
+		t.Errorf("Expected leaves to occasionally drop extras, got %d in 500", extras)
+	}
+}
+
+func TestLootTableRollIsSeeded(t *testing.T) {
+	roll := func(seed int64) []ItemStack {
+		stacks, _ := LootTables.Roll("chests/cave", LootContext{Depth: 50, Rng: rand.New(rand.NewSource(seed))})
+		return stacks
+	}
+	a, b := roll(3), roll(3)
+	if len(a) != len(b) {
+		t.Fatalf("Expected the same seed to roll the same loot, got %v and %v", a, b)
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			t.Fatalf("Expected the same seed to roll the same loot, got %v and %v", a, b)
+		}
+	}
+	if _, exists := LootTables.Roll("blocks/no_such_block", LootContext{Rng: rand.New(rand.NewSource(1))}); exists {
+		t.Error("Expected missing table to report false")
+	}
+}
+
+func TestLootTableConditionsAndNesting(t *testing.T) {
+	registry, err := ParseLootTables([]byte(`{"tables": [
+		{"name": "ore", "pools": [
+			{"entries": [{"item": "stone"}]},
+			{"conditions": {"tools": ["pickaxe"]}, "entries": [{"item": "coal", "min": 2, "max": 2}]},
+			{"entries": [{"table": "deep", "conditions": {"min_depth": 10}}]},
+			{"entries": [{"item": "apple", "conditions": {"biomes": ["desert"]}}]}
+		]},
+		{"name": "deep", "pools": [{"rolls": {"min": 2, "max": 2}, "entries": [{"item": "iron_ingot"}]}]},
+		{"name": "nothing", "pools": [{"entries": [{"weight": 5}]}]}
+	]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rng := rand.New(rand.NewSource(1))
+	apple, _ := ParseItemName("apple")
+
+	stacks, _ := registry.Roll("ore", LootContext{Tool: ToolNone, Depth: 0, Biome: "Plains", Rng: rng})
+	if len(stacks) != 1 || stacks[0] != (ItemStack{Type: Stone, Count: 1}) {
+		t.Errorf("Expected only stone without conditions met, got %v", stacks)
+	}
+
+	stacks, _ = registry.Roll("ore", LootContext{Tool: ToolPickaxe, Depth: 12, Biome: "Desert", Rng: rng})
+	expected := []ItemStack{{Type: Stone, Count: 1}, {Type: Coal, Count: 2}, {Type: IronIngot, Count: 2}, {Type: apple, Count: 1}}
+	if len(stacks) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, stacks)
+	}
+	for i := range expected {
+		if stacks[i] != expected[i] {
+			t.Errorf("Expected %v at %d, got %v", expected[i], i, stacks[i])
+		}
+	}
+
+	if stacks, exists := registry.Roll("nothing", LootContext{Rng: rng}); !exists || len(stacks) != 0 {
+		t.Errorf("Expected empty entry to drop nothing, got %v", stacks)
+	}
+}
+
+func TestLootTableDistinctPool(t *testing.T) {
+	registry, err := ParseLootTables([]byte(`{"tables": [{"name": "chest", "pools": [
+		{"rolls": {"min": 3, "max": 3}, "distinct": true, "entries": [{"item": "stone"}, {"item": "dirt"}, {"item": "wood"}]}
+	]}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for seed := int64(0); seed < 10; seed++ {
+		stacks, _ := registry.Roll("chest", LootContext{Rng: rand.New(rand.NewSource(seed))})
+		if len(stacks) != 3 {
+			t.Fatalf("Expected 3 distinct stacks, got %v", stacks)
+		}
+	}
+}
+
+func TestParseLootTablesErrors(t *testing.T) {
+	bad := []string{
+		`{"tables": [{"pools": [{"entries": [{"item": "stone"}]}]}]}`,
+		`{"tables": [{"name": "a", "pools": [{"entries": []}]}]}`,
+		`{"tables": [{"name": "a", "pools": [{"entries": [{"item": "no_such_item"}]}]}]}`,
+		`{"tables": [{"name": "a", "pools": [{"entries": [{"item": "stone", "weight": 0}]}]}]}`,
+		`{"tables": [{"name": "a", "pools": [{"entries": [{"item": "stone", "table": "a"}]}]}]}`,
+		`{"tables": [{"name": "a", "pools": [{"entries": [{"table": "missing"}]}]}]}`,
+		`{"tables": [{"name": "a", "pools": [{"entries": [{"table": "b"}]}]}, {"name": "b", "pools": [{"entries": [{"table": "a"}]}]}]}`,
+		`{"tables": [{"name": "a", "pools": [{"entries": [{"item": "stone", "conditions": {"tools": ["hammer"]}}]}]}]}`,
+		`{"tables": [{"name": "a", "pools": [{"conditions": {"min_depth": 5, "max_depth": 1}, "entries": [{"item": "stone"}]}]}]}`,
+		`{"tables": [{"name": "a", "pools": [{"rolls": {"min": -1}, "entries": [{"item": "stone"}]}]}]}`,
+		`{"tables": [{"name": "a", "pools": [{"entries": [{"item": "stone"}]}]}, {"name": "a", "pools": [{"entries": [{"item": "dirt"}]}]}]}`,
+		`not json`,
+	}
+	for _, data := range bad {
+		if _, err := ParseLootTables([]byte(data)); err == nil {
+			t.Errorf("Expected error for %s", data)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package entity
+
+import (
+	"math"
+)
+
+const (
+	CrackStages       = 5  // 破坏进度的裂纹阶段数
+	FramesPerHardness = 60 // 每点硬度徒手挖掘所需帧数
+)
+
+// MiningState 持续挖掘方块的进度
+type MiningState struct {
+	Active   bool // 是否正在挖掘
+	TargetX  int  // 目标方块的网格坐标
+	TargetY  int
+	Progress float64 // 破坏进度，0到1
+}
+
+// GetMiningSpeed 获取使用指定物品挖掘指定方块的速度倍率
+// 徒手、非工具物品或不适合该方块的工具的倍率为1
+func GetMiningSpeed(tool ItemType, blockType BlockType) float64 {
+	props, isTool := GetToolProperties(tool)
+	if !isTool || props.Kind == ToolNone || props.Kind != GetBlockProperties(blockType).Tool {
+		return 1.0
+	}
+	return props.Speed
+}
+
+// GetBreakTime 获取以指定速度倍率挖掘方块所需的帧数
+func GetBreakTime(blockType BlockType, speed float64) int {
+	hardness := GetBlockProperties(blockType).Hardness
+	if hardness <= 0 || speed <= 0 {
+		return 1
+	}
+	return int(math.Max(1, math.Ceil(hardness*FramesPerHardness/speed)))
+}
+
+// Mine 对目标方块挖掘一帧，目标改变时重新开始计算进度
+// 返回方块是否在这一帧被破坏；破坏后进度自动重置
+func (m *MiningState) Mine(x, y int, blockType BlockType, speed float64) bool {
+	if !m.Active || m.TargetX != x || m.TargetY != y {
+		m.Active = true
+		m.TargetX, m.TargetY = x, y
+		m.Progress = 0
+	}
+
+	m.Progress += 1.0 / float64(GetBreakTime(blockType, speed))
+	if m.Progress >= 1.0-1e-9 {
+		m.Reset()
+		return true
+	}
+	return false
+}
+
+// Reset 停止挖掘并清除进度
+func (m *MiningState) Reset() {
+	m.Active = false
+	m.Progress = 0
+}
+
+// Stage 获取当前进度对应的裂纹阶段（0到CrackStages-1）
+func (m *MiningState) Stage() int {
+	stage := int(m.Progress * CrackStages)
+	if stage >= CrackStages {
+		stage = CrackStages - 1
+	}
+	return stage
+}
@@ -0,0 +1,46 @@
+package entity
+
+import "testing"
+
+func TestBreakTimeUsesHardness(t *testing.T) {
+	if GetBreakTime(StoneBlock, 1) <= GetBreakTime(DirtBlock, 1) {
+		t.Error("Expected stone to take longer to mine than dirt")
+	}
+	if GetBreakTime(StoneBlock, 2) >= GetBreakTime(StoneBlock, 1) {
+		t.Error("Expected a higher mining speed to shorten break time")
+	}
+	if GetBreakTime(VineBlock, 100) != 1 {
+		t.Error("Expected break time to be at least one frame")
+	}
+}
+
+func TestMiningStateProgress(t *testing.T) {
+	var m MiningState
+	frames := GetBreakTime(DirtBlock, 1)
+
+	for i := 0; i < frames/2; i++ {
+		m.Mine(1, 1, DirtBlock, 1)
+	}
+	if !m.Active || m.Progress <= 0 {
+		t.Fatal("Expected mining to be in progress")
+	}
+	before := m.Progress
+
+	// 换一个目标方块后进度从头开始
+	m.Mine(2, 1, DirtBlock, 1)
+	if m.TargetX != 2 || m.Progress >= before {
+		t.Errorf("Expected progress to restart on new target, got %v", m.Progress)
+	}
+
+	m.Reset()
+	broken := false
+	for i := 0; i < frames; i++ {
+		broken = m.Mine(1, 1, DirtBlock, 1)
+	}
+	if !broken {
+		t.Error("Expected block to break after its break time")
+	}
+	if m.Active || m.Progress != 0 {
+		t.Error("Expected mining state to reset after breaking")
+	}
+}
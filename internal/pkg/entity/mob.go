@@ -0,0 +1,606 @@
+package entity
+
+import (
+	"math"
+	"math/rand"
+)
+
+const (
+	MobMaxFallSpeed      = 12.0 // 生物的最大下落速度
+	MobHurtDuration      = 10   // 受伤后闪烁的帧数
+	MobHopInterval       = 40   // 跳跃移动的生物（史莱姆）两次起跳之间的帧数
+	MobRepathFrames      = 15   // 追击时重新寻路的间隔帧数
+	MobMaxDrop           = 4    // 生物寻路时愿意跳下的最大高度（格）
+	MobKnockbackDuration = 12   // 被击退后不受AI控制移动的帧数
+	MobKnockbackDrag     = 0.9  // 被击退时每帧水平速度的衰减
+)
+
+// MobKind 生物种类
+type MobKind int
+
+const (
+	MobSlime       MobKind = iota // 史莱姆：夜晚出现在地表，跳跃着追击玩家
+	MobCaveCrawler                // 洞穴爬虫：生活在黑暗的深处，速度快
+	MobCritter                    // 小动物：白天出现在草地上，被动，受惊后逃跑
+)
+
+// MobProperties 生物种类的属性
+type MobProperties struct {
+	Name           string
+	MaxHealth      int
+	Width, Height  float64
+	Speed          float64 // 水平移动速度
+	JumpPower      float64 // 起跳速度
+	Hops           bool    // 是否只能通过跳跃移动
+	Flying         bool    // 是否飞行（不受重力影响，按飞行规则寻路）
+	Hostile        bool    // 是否主动攻击玩家
+	Damage         int     // 每次攻击的伤害
+	AttackRange    float64 // 攻击距离（中心之间）
+	AttackCooldown int     // 两次攻击之间的帧数
+	SightRange     float64 // 发现玩家的距离，敌对生物开始追击，被动生物开始逃跑
+	Sprite         int
+}
+
+// mobProperties 所有生物种类的属性表
+var mobProperties = map[MobKind]MobProperties{
+	MobSlime: {
+		Name: "Slime", MaxHealth: 8, Width: 28, Height: 20, Speed: 2.5, JumpPower: 8, Hops: true,
+		Hostile: true, Damage: 2, AttackRange: 28, AttackCooldown: 60, SightRange: 8 * BlockSize, Sprite: SlimeSprite,
+	},
+	MobCaveCrawler: {
+		Name: "Cave Crawler", MaxHealth: 12, Width: 30, Height: 16, Speed: 3, JumpPower: 9,
+		Hostile: true, Damage: 3, AttackRange: 30, AttackCooldown: 45, SightRange: 10 * BlockSize, Sprite: CaveCrawlerSprite,
+	},
+	MobCritter: {
+		Name: "Critter", MaxHealth: 4, Width: 18, Height: 14, Speed: 2, JumpPower: 7,
+		SightRange: 4 * BlockSize, Sprite: CritterSprite,
+	},
+}
+
+// MoveRules 根据生物的体型和跳跃能力得到寻路规则
+func (p MobProperties) MoveRules() MoveRules {
+	jumpHeight := p.JumpPower * p.JumpPower / (2 * Gravity)
+	return MoveRules{
+		Flying:  p.Flying,
+		Height:  int(math.Ceil(p.Height / BlockSize)),
+		MaxJump: int(jumpHeight / BlockSize),
+		MaxDrop: MobMaxDrop,
+	}
+}
+
+// GetMobProperties 获取生物种类的属性
+func GetMobProperties(kind MobKind) MobProperties {
+	return mobProperties[kind]
+}
+
+// String 返回生物种类的名称
+func (k MobKind) String() string {
+	if props, exists := mobProperties[k]; exists {
+		return props.Name
+	}
+	return "Unknown"
+}
+
+// mobNames 生物种类在数据文件（如战利品表）中使用的名称
+var mobNames = map[MobKind]string{
+	MobSlime:       "slime",
+	MobCaveCrawler: "cave_crawler",
+	MobCritter:     "critter",
+}
+
+// GetMobName 获取生物种类在数据文件中使用的名称
+func GetMobName(kind MobKind) string {
+	if name, exists := mobNames[kind]; exists {
+		return name
+	}
+	return "unknown"
+}
+
+// MobState AI状态
+type MobState int
+
+const (
+	StateIdle   MobState = iota // 原地停留
+	StateWander                 // 随机游荡
+	StateChase                  // 追击玩家
+	StateAttack                 // 攻击玩家
+	StateFlee                   // 逃离玩家
+)
+
+// String 返回AI状态的名称
+func (s MobState) String() string {
+	switch s {
+	case StateIdle:
+		return "Idle"
+	case StateWander:
+		return "Wander"
+	case StateChase:
+		return "Chase"
+	case StateAttack:
+		return "Attack"
+	case StateFlee:
+		return "Flee"
+	}
+	return "Unknown"
+}
+
+// AIState 一个AI状态的行为：每帧调用一次，设置生物的移动意图并返回下一帧的状态
+type AIState func(m *Mob, player *Player) MobState
+
+// MobAI 可替换的AI状态机，不同的生物通过组合不同的状态行为得到各自的AI
+type MobAI struct {
+	states map[MobState]AIState
+}
+
+// NewMobAI 创建空的AI状态机
+func NewMobAI() *MobAI {
+	return &MobAI{states: make(map[MobState]AIState)}
+}
+
+// Set 设置某个状态的行为，返回状态机本身以便链式调用
+func (ai *MobAI) Set(state MobState, behavior AIState) *MobAI {
+	ai.states[state] = behavior
+	return ai
+}
+
+// Has 检查状态机是否包含某个状态
+func (ai *MobAI) Has(state MobState) bool {
+	_, exists := ai.states[state]
+	return exists
+}
+
+// Update 执行当前状态的行为并切换到它返回的状态，没有对应行为的状态回到空闲
+func (ai *MobAI) Update(m *Mob, player *Player) {
+	behavior, exists := ai.states[m.State]
+	if !exists {
+		m.SetState(StateIdle)
+		return
+	}
+	if next := behavior(m, player); next != m.State {
+		m.SetState(next)
+	} else {
+		m.StateTimer++
+	}
+}
+
+// NewMobAIFor 创建生物种类默认的AI：敌对生物会追击和攻击，被动生物会逃跑
+func NewMobAIFor(kind MobKind) *MobAI {
+	ai := NewMobAI().Set(StateIdle, IdleState).Set(StateWander, WanderState)
+	if GetMobProperties(kind).Hostile {
+		return ai.Set(StateChase, ChaseState).Set(StateAttack, AttackState)
+	}
+	return ai.Set(StateFlee, FleeState)
+}
+
+// Mob 生物
+type Mob struct {
+	Kind              MobKind
+	X, Y              float64 // 中心位置
+	VX, VY            float64
+	OnGround          bool
+	Blocked           bool // 上一帧水平移动是否被方块挡住
+	Facing            int  // 朝向：-1=左, 1=右
+	Health, MaxHealth int
+	Dead              bool
+	HurtTimer         int       // 剩余的受伤闪烁帧数，期间不会被攻击命中
+	KnockbackTimer    int       // 剩余的击退帧数
+	State             MobState  // 当前AI状态
+	StateTimer        int       // 当前状态已经持续的帧数
+	StateDuration     int       // 空闲和游荡状态的持续帧数，进入状态时随机决定
+	AttackTimer       int       // 剩余的攻击冷却帧数
+	MoveDir           int       // AI设置的本帧移动方向：-1=左, 1=右, 0=停止
+	MoveDirY          int       // 飞行生物的竖直移动方向：-1=上, 1=下, 0=停止
+	WantJump          bool      // AI要求本帧起跳（路径的下一格更高）
+	Path              []GridPos // 追击时寻路得到的路径
+	PathTimer         int       // 距离重新寻路的帧数
+	AI                *MobAI
+	World             World
+	Rng               *rand.Rand // AI使用的随机数，在世界中更新后使用世界的随机数，之前使用全局随机数
+}
+
+// NewMob 在指定位置（中心）创建生物
+func NewMob(kind MobKind, x, y float64) *Mob {
+	props := GetMobProperties(kind)
+	m := &Mob{
+		Kind:      kind,
+		X:         x,
+		Y:         y,
+		Facing:    1,
+		Health:    props.MaxHealth,
+		MaxHealth: props.MaxHealth,
+		AI:        NewMobAIFor(kind),
+	}
+	m.SetState(StateIdle)
+	return m
+}
+
+// Props 获取生物的属性
+func (m *Mob) Props() MobProperties {
+	return GetMobProperties(m.Kind)
+}
+
+// SetWorld 设置世界引用
+func (m *Mob) SetWorld(world World) {
+	m.World = world
+}
+
+// GetPosition 获取生物的中心位置
+func (m *Mob) GetPosition() (float64, float64) {
+	return m.X, m.Y
+}
+
+// GetHealth 获取当前生命值和最大生命值
+func (m *Mob) GetHealth() (int, int) {
+	return m.Health, m.MaxHealth
+}
+
+// OverlapsCell 检查生物的碰撞箱是否与网格重叠
+func (m *Mob) OverlapsCell(gridX, gridY int) bool {
+	props := m.Props()
+	cellX, cellY := float64(gridX*BlockSize), float64(gridY*BlockSize)
+	return m.X+props.Width/2 > cellX && m.X-props.Width/2 < cellX+BlockSize &&
+		m.Y+props.Height/2 > cellY && m.Y-props.Height/2 < cellY+BlockSize
+}
+
+// Hitbox 获取生物的碰撞箱
+func (m *Mob) Hitbox() Hitbox {
+	props := m.Props()
+	return NewHitbox(m.X, m.Y, props.Width, props.Height)
+}
+
+// Tick 更新一帧（实现Entity）：AI改用世界由种子决定的随机数，第一次更新时按它重新决定当前状态的持续时间
+func (m *Mob) Tick(world EntityWorld) {
+	first := m.Rng == nil
+	m.Rng = world.Rng()
+	if first && m.Rng != nil {
+		m.SetState(m.State)
+	}
+	m.Update(world.GetPlayer())
+}
+
+// Bounds 获取生物占据的范围（实现Entity）
+func (m *Mob) Bounds() Hitbox {
+	return m.Hitbox()
+}
+
+// IsRemoved 死亡的生物从世界中移除（实现Entity）
+func (m *Mob) IsRemoved() bool {
+	return m.Dead
+}
+
+// DrawLayer 获取生物的绘制层（实现Entity）
+func (m *Mob) DrawLayer() DrawLayer {
+	return LayerMobs
+}
+
+// SetState 切换AI状态并重置计时，空闲和游荡状态随机持续1到3秒
+func (m *Mob) SetState(state MobState) {
+	m.State = state
+	m.StateTimer = 0
+	m.StateDuration = 60 + m.intn(120)
+	if state == StateWander {
+		m.Facing = 1
+		if m.intn(2) == 0 {
+			m.Facing = -1
+		}
+	}
+}
+
+// intn 用生物的随机数取[0, n)中的随机整数，还没有随机数时使用全局随机数
+func (m *Mob) intn(n int) int {
+	if m.Rng == nil {
+		return rand.Intn(n)
+	}
+	return m.Rng.Intn(n)
+}
+
+// TakeDamage 对生物造成伤害，被动生物受伤后逃跑，敌对生物开始追击；返回伤害是否生效
+func (m *Mob) TakeDamage(amount int) bool {
+	if amount <= 0 || m.Dead {
+		return false
+	}
+	m.Health -= amount
+	m.HurtTimer = MobHurtDuration
+	if m.Health <= 0 {
+		m.Health = 0
+		m.Dead = true
+		return true
+	}
+	if m.AI.Has(StateFlee) {
+		m.SetState(StateFlee)
+	} else if m.AI.Has(StateChase) {
+		m.SetState(StateChase)
+	}
+	return true
+}
+
+// Hit 被武器或投射物命中：受到伤害并被击退，受伤闪烁期间无敌；返回是否命中
+func (m *Mob) Hit(damage int, knockbackX, knockbackY float64) bool {
+	if m.HurtTimer > 0 || !m.TakeDamage(damage) {
+		return false
+	}
+	m.VX, m.VY = knockbackX, knockbackY
+	m.OnGround = false
+	m.KnockbackTimer = MobKnockbackDuration
+	return true
+}
+
+// Update 更新一帧：AI决定移动意图，然后应用物理；被击退时不受AI控制
+func (m *Mob) Update(player *Player) {
+	if m.Dead {
+		return
+	}
+	if m.HurtTimer > 0 {
+		m.HurtTimer--
+	}
+	if m.AttackTimer > 0 {
+		m.AttackTimer--
+	}
+
+	if m.PathTimer > 0 {
+		m.PathTimer--
+	}
+
+	m.MoveDir, m.MoveDirY, m.WantJump = 0, 0, false
+	if m.AI != nil {
+		m.AI.Update(m, player)
+	}
+	if m.KnockbackTimer > 0 {
+		m.KnockbackTimer--
+		m.VX *= MobKnockbackDrag
+	} else {
+		m.applyMovement()
+	}
+	m.updatePhysics()
+}
+
+// applyMovement 根据移动意图设置速度，被方块挡住时跳跃
+func (m *Mob) applyMovement() {
+	props := m.Props()
+	if m.MoveDir != 0 {
+		m.Facing = m.MoveDir
+	}
+
+	if props.Hops {
+		// 跳跃移动：只在空中水平移动，落地后隔一段时间再起跳
+		if m.OnGround {
+			m.VX = 0
+			if m.MoveDir != 0 && m.StateTimer%MobHopInterval == 0 {
+				m.VX = float64(m.MoveDir) * props.Speed
+				m.VY = -props.JumpPower
+				m.OnGround = false
+			}
+		}
+		return
+	}
+
+	m.VX = float64(m.MoveDir) * props.Speed
+	if props.Flying {
+		m.VY = float64(m.MoveDirY) * props.Speed
+		return
+	}
+	if (m.WantJump || m.MoveDir != 0 && m.Blocked) && m.OnGround {
+		m.VY = -props.JumpPower
+		m.OnGround = false
+	}
+}
+
+// updatePhysics 应用重力（飞行生物除外）并逐像素移动，碰到实心方块时停下
+func (m *Mob) updatePhysics() {
+	if !m.Props().Flying {
+		m.VY += Gravity
+		if m.VY > MobMaxFallSpeed {
+			m.VY = MobMaxFallSpeed
+		}
+	}
+	if m.World == nil {
+		m.X += m.VX
+		m.Y += m.VY
+		return
+	}
+
+	m.Blocked = false
+	if m.moveAxis(m.VX, 0) {
+		m.Blocked = true
+		m.VX = 0
+	}
+	m.OnGround = false
+	if m.moveAxis(0, m.VY) {
+		if m.VY > 0 {
+			m.OnGround = true
+		}
+		m.VY = 0
+	}
+}
+
+// moveAxis 沿一个方向逐像素移动，返回是否被方块挡住
+func (m *Mob) moveAxis(dx, dy float64) bool {
+	props := m.Props()
+	return moveBox(m.World, &m.X, &m.Y, props.Width, props.Height, dx, dy)
+}
+
+// moveBox 把中心位于(x, y)的碰撞箱沿一个方向逐像素移动，返回是否被方块挡住（生物和首领共用）
+func moveBox(world World, x, y *float64, width, height, dx, dy float64) bool {
+	distance := math.Abs(dx + dy)
+	stepX, stepY := sign(dx), sign(dy)
+	for distance > 0 {
+		step := math.Min(1, distance)
+		if boxCollides(world, *x+stepX*step, *y+stepY*step, width, height) {
+			return true
+		}
+		*x += stepX * step
+		*y += stepY * step
+		distance -= step
+	}
+	return false
+}
+
+// boxCollides 检查中心位于(x, y)的碰撞箱是否与实心方块重叠
+func boxCollides(world World, x, y, width, height float64) bool {
+	left := int(math.Floor((x - width/2) / BlockSize))
+	right := int(math.Ceil((x+width/2)/BlockSize)) - 1
+	top := int(math.Floor((y - height/2) / BlockSize))
+	bottom := int(math.Ceil((y+height/2)/BlockSize)) - 1
+	for gridY := top; gridY <= bottom; gridY++ {
+		for gridX := left; gridX <= right; gridX++ {
+			if IsSolidBlockAt(world, gridX, gridY) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// sign 返回数值的符号
+func sign(v float64) float64 {
+	switch {
+	case v > 0:
+		return 1
+	case v < 0:
+		return -1
+	}
+	return 0
+}
+
+// GridPos 获取生物脚所在的网格位置
+func (m *Mob) GridPos() GridPos {
+	return GridPos{
+		X: int(math.Floor(m.X / BlockSize)),
+		Y: int(math.Ceil((m.Y+m.Props().Height/2)/BlockSize)) - 1,
+	}
+}
+
+// playerGridPos 获取玩家脚所在的网格位置
+func playerGridPos(player *Player) GridPos {
+	return GridPos{
+		X: int(math.Floor(player.X / BlockSize)),
+		Y: int(math.Ceil((player.Y+PlayerSize/2)/BlockSize)) - 1,
+	}
+}
+
+// moveTowards 沿寻路得到的路径走向玩家，世界不支持寻路或找不到路径时直接朝玩家移动
+func (m *Mob) moveTowards(player *Player) {
+	pathfinder, canPathfind := m.World.(Pathfinder)
+	if !canPathfind {
+		m.MoveDir = m.directionTo(player)
+		return
+	}
+	if m.PathTimer == 0 {
+		m.Path, _ = pathfinder.FindPath(m.GridPos(), playerGridPos(player), m.Props().MoveRules())
+		m.PathTimer = MobRepathFrames
+	}
+
+	// 跳过已经到达的节点
+	current := m.GridPos()
+	for len(m.Path) > 0 && m.Path[0] == current {
+		m.Path = m.Path[1:]
+	}
+	if len(m.Path) == 0 {
+		m.MoveDir = m.directionTo(player)
+		return
+	}
+
+	next := m.Path[0]
+	m.MoveDir = int(sign(float64(next.X - current.X)))
+	if m.Props().Flying {
+		m.MoveDirY = int(sign(float64(next.Y - current.Y)))
+	} else if next.Y < current.Y {
+		m.WantJump = true
+	}
+}
+
+// distanceTo 计算生物与玩家中心之间的距离
+func (m *Mob) distanceTo(player *Player) float64 {
+	return math.Hypot(player.X-m.X, player.Y-m.Y)
+}
+
+// canSee 检查玩家是否在视野范围内（死亡的玩家不会被发现）
+func (m *Mob) canSee(player *Player) bool {
+	return player != nil && !player.Dead && m.distanceTo(player) <= m.Props().SightRange
+}
+
+// directionTo 获取朝向玩家的水平方向
+func (m *Mob) directionTo(player *Player) int {
+	if player.X < m.X {
+		return -1
+	}
+	return 1
+}
+
+// noticePlayer 发现玩家时的反应：能追击就追击，否则能逃跑就逃跑
+func (m *Mob) noticePlayer(player *Player) (MobState, bool) {
+	if !m.canSee(player) {
+		return m.State, false
+	}
+	if m.AI.Has(StateChase) {
+		return StateChase, true
+	}
+	if m.AI.Has(StateFlee) {
+		return StateFlee, true
+	}
+	return m.State, false
+}
+
+// IdleState 原地停留一段时间后开始游荡
+func IdleState(m *Mob, player *Player) MobState {
+	if next, noticed := m.noticePlayer(player); noticed {
+		return next
+	}
+	if m.StateTimer >= m.StateDuration && m.AI.Has(StateWander) {
+		return StateWander
+	}
+	return StateIdle
+}
+
+// WanderState 朝进入状态时随机选择的方向游荡，碰壁时掉头
+func WanderState(m *Mob, player *Player) MobState {
+	if next, noticed := m.noticePlayer(player); noticed {
+		return next
+	}
+	if m.StateTimer >= m.StateDuration {
+		return StateIdle
+	}
+	if m.Blocked && m.OnGround && m.StateTimer%30 == 29 {
+		m.Facing = -m.Facing
+	}
+	m.MoveDir = m.Facing
+	return StateWander
+}
+
+// ChaseState 沿路径追向玩家，进入攻击距离后攻击，玩家跑远后放弃
+func ChaseState(m *Mob, player *Player) MobState {
+	if player == nil || player.Dead || m.distanceTo(player) > m.Props().SightRange*1.5 {
+		return StateIdle
+	}
+	if m.distanceTo(player) <= m.Props().AttackRange {
+		return StateAttack
+	}
+	m.moveTowards(player)
+	return StateChase
+}
+
+// AttackState 冷却结束时攻击攻击距离内的玩家，玩家离开攻击距离后继续追击
+func AttackState(m *Mob, player *Player) MobState {
+	if player == nil || player.Dead {
+		return StateIdle
+	}
+	props := m.Props()
+	if m.distanceTo(player) > props.AttackRange {
+		return StateChase
+	}
+	m.Facing = m.directionTo(player)
+	if m.AttackTimer == 0 {
+		player.TakeDamage(props.Damage, DamageMob)
+		m.AttackTimer = props.AttackCooldown
+	}
+	return StateAttack
+}
+
+// FleeState 背向玩家逃跑，直到距离足够远
+func FleeState(m *Mob, player *Player) MobState {
+	if player == nil || player.Dead || m.distanceTo(player) > m.Props().SightRange*2 {
+		return StateIdle
+	}
+	m.MoveDir = -m.directionTo(player)
+	return StateFlee
+}
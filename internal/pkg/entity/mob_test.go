@@ -0,0 +1,173 @@
+package entity
+
+import "testing"
+
+// newMobTestWorld 创建一个在y=5处有一行地面的模拟世界
+func newMobTestWorld() *MockWorld {
+	world := NewMockWorld()
+	for x := 0; x < 30; x++ {
+		world.AddBlockWithType(x, 5, StoneBlock)
+	}
+	return world
+}
+
+func TestMobFallsAndLands(t *testing.T) {
+	mob := NewMob(MobCritter, 10*BlockSize, 2*BlockSize)
+	mob.SetWorld(newMobTestWorld())
+	mob.AI = NewMobAI()
+
+	for i := 0; i < 60; i++ {
+		mob.Update(nil)
+	}
+	if !mob.OnGround {
+		t.Fatal("Expected mob to land on the ground")
+	}
+	want := 5*BlockSize - mob.Props().Height/2
+	if mob.Y != want {
+		t.Errorf("Expected mob to rest at y=%v, got %v", want, mob.Y)
+	}
+}
+
+func TestHostileMobChasesAndAttacks(t *testing.T) {
+	world := newMobTestWorld()
+	mob := NewMob(MobCaveCrawler, 6*BlockSize, 5*BlockSize-8)
+	mob.SetWorld(world)
+	player := NewPlayer(10*BlockSize, 5*BlockSize-16)
+	player.SetWorld(world)
+
+	mob.Update(player)
+	if mob.State != StateChase {
+		t.Fatalf("Expected crawler to chase a visible player, got %v", mob.State)
+	}
+
+	for i := 0; i < 120 && player.Health == player.MaxHealth; i++ {
+		mob.Update(player)
+	}
+	if mob.X <= 6*BlockSize {
+		t.Error("Expected crawler to move towards the player")
+	}
+	if player.Health != player.MaxHealth-GetMobProperties(MobCaveCrawler).Damage || player.LastDamageSource != DamageMob {
+		t.Errorf("Expected crawler to attack the player, health %d", player.Health)
+	}
+	if mob.State != StateAttack {
+		t.Errorf("Expected crawler to be attacking, got %v", mob.State)
+	}
+}
+
+func TestCritterFleesFromPlayer(t *testing.T) {
+	world := newMobTestWorld()
+	mob := NewMob(MobCritter, 12*BlockSize, 5*BlockSize-7)
+	mob.SetWorld(world)
+	player := NewPlayer(10*BlockSize, 5*BlockSize-16)
+
+	for i := 0; i < 30; i++ {
+		mob.Update(player)
+	}
+	if mob.State != StateFlee || mob.X <= 12*BlockSize {
+		t.Errorf("Expected critter to flee away from the player, state %v x %v", mob.State, mob.X)
+	}
+	if player.Health != player.MaxHealth {
+		t.Error("Expected passive critter not to attack")
+	}
+}
+
+func TestMobAIIsPluggable(t *testing.T) {
+	mob := NewMob(MobSlime, 10*BlockSize, 5*BlockSize-10)
+	mob.SetWorld(newMobTestWorld())
+
+	// 只有空闲状态的AI：切换到不存在的状态时回到空闲
+	calls := 0
+	mob.AI = NewMobAI().Set(StateIdle, func(m *Mob, player *Player) MobState {
+		calls++
+		return StateWander
+	})
+	mob.Update(nil)
+	if mob.State != StateWander {
+		t.Fatalf("Expected custom state to switch to wander, got %v", mob.State)
+	}
+	mob.Update(nil)
+	if mob.State != StateIdle || calls != 1 {
+		t.Errorf("Expected missing state to fall back to idle, got %v after %d calls", mob.State, calls)
+	}
+	if NewMobAIFor(MobCritter).Has(StateChase) || !NewMobAIFor(MobSlime).Has(StateAttack) {
+		t.Error("Expected default AIs to match hostility")
+	}
+}
+
+func TestMobTakeDamage(t *testing.T) {
+	mob := NewMob(MobCritter, 0, 0)
+	if !mob.TakeDamage(1) || mob.State != StateFlee || mob.HurtTimer != MobHurtDuration {
+		t.Error("Expected hurt critter to flee")
+	}
+	mob.TakeDamage(mob.MaxHealth)
+	if !mob.Dead || mob.Health != 0 {
+		t.Error("Expected mob to die")
+	}
+	if mob.TakeDamage(1) {
+		t.Error("Expected dead mob to ignore damage")
+	}
+	if !mob.OverlapsCell(0, 0) || !mob.OverlapsCell(-1, -1) || mob.OverlapsCell(1, 0) {
+		t.Error("Expected hitbox to cover only the mob")
+	}
+}
+
+// pathWorld 支持寻路的模拟世界
+type pathWorld struct {
+	*MockWorld
+	paths *PathCache
+}
+
+func (w *pathWorld) FindPath(start, goal GridPos, rules MoveRules) ([]GridPos, bool) {
+	return w.paths.FindPath(w, start, goal, rules)
+}
+
+func TestChasingMobFollowsPathOverWall(t *testing.T) {
+	world := &pathWorld{MockWorld: newMobTestWorld(), paths: NewPathCache()}
+	world.AddBlockWithType(7, 4, StoneBlock)
+	world.AddBlockWithType(7, 3, StoneBlock)
+	mob := NewMob(MobCaveCrawler, 3.5*BlockSize, 5*BlockSize-8)
+	mob.SetWorld(world)
+	player := NewPlayer(11*BlockSize, 5*BlockSize-16)
+	player.SetWorld(world)
+
+	// 第一帧发现玩家，第二帧开始沿路径追击
+	mob.Update(player)
+	mob.Update(player)
+	if mob.State != StateChase || len(mob.Path) == 0 {
+		t.Fatalf("Expected crawler to chase along a path, state %v path %v", mob.State, mob.Path)
+	}
+	if mob.GridPos() != (GridPos{3, 4}) {
+		t.Errorf("Expected crawler feet at (3, 4), got %v", mob.GridPos())
+	}
+
+	for i := 0; i < 240 && mob.X < 8*BlockSize; i++ {
+		mob.Update(player)
+	}
+	if mob.X < 8*BlockSize {
+		t.Errorf("Expected crawler to get over the wall, x %v", mob.X)
+	}
+}
+
+func TestMobKnockback(t *testing.T) {
+	world := newMobTestWorld()
+	mob := NewMob(MobCaveCrawler, 10*BlockSize, 5*BlockSize-8)
+	mob.SetWorld(world)
+	mob.AI = NewMobAI()
+
+	if !mob.Hit(2, 6, -3) {
+		t.Fatal("Expected the hit to land")
+	}
+	if mob.Hit(2, 6, -3) {
+		t.Error("Expected the mob to be invulnerable while hurt")
+	}
+	startX := mob.X
+	for i := 0; i < MobKnockbackDuration; i++ {
+		mob.Update(nil)
+	}
+	if mob.X <= startX+BlockSize {
+		t.Errorf("Expected the mob to be pushed back, moved %v", mob.X-startX)
+	}
+	if mob.KnockbackTimer != 0 || mob.HurtTimer != 0 {
+		t.Error("Expected knockback and invulnerability to wear off")
+	}
+}
@@ -0,0 +1,331 @@
+package entity
+
+import (
+	"container/heap"
+	"math"
+)
+
+const (
+	DefaultMaxPathNodes = 2000 // 一次寻路最多展开的节点数
+	MaxCachedPaths      = 256  // 缓存的寻路结果数量上限，超过时清空
+	settleDepth         = 8    // 起点和终点悬空时向下寻找落脚点的最大格数
+)
+
+// GridPos 网格位置
+type GridPos struct {
+	X, Y int
+}
+
+// MoveRules 寻路时使用的移动规则
+// 地面实体的位置是脚所在的格子，可以行走、走下台阶、跳上不超过MaxJump格的高度、跳下不超过MaxDrop格的高度，
+// CanClimb时还可以沿梯子等可攀爬方块上下移动；飞行实体可以在任意相邻的非实心格子之间移动
+type MoveRules struct {
+	Flying   bool
+	CanClimb bool
+	Height   int // 实体占据的格数（从脚向上），至少为1
+	MaxJump  int
+	MaxDrop  int
+	MaxNodes int // 搜索节点上限，0表示使用DefaultMaxPathNodes
+}
+
+// Pathfinder 可以寻路的世界（由world.World实现，带有结果缓存）
+type Pathfinder interface {
+	FindPath(start, goal GridPos, rules MoveRules) ([]GridPos, bool)
+}
+
+// FindPath 使用A*算法在方块网格上寻找从起点到终点的路径
+// 返回的路径包括起点和终点，找不到路径或超过搜索上限时返回false
+func FindPath(world World, start, goal GridPos, rules MoveRules) ([]GridPos, bool) {
+	path, found, _ := findPath(world, start, goal, rules)
+	return path, found
+}
+
+// pathBounds 寻路涉及的网格范围
+type pathBounds struct {
+	minX, minY, maxX, maxY int
+}
+
+func newPathBounds(p GridPos) pathBounds {
+	return pathBounds{p.X, p.Y, p.X, p.Y}
+}
+
+func (b *pathBounds) add(p GridPos) {
+	b.minX = minInt(b.minX, p.X)
+	b.minY = minInt(b.minY, p.Y)
+	b.maxX = maxInt(b.maxX, p.X)
+	b.maxY = maxInt(b.maxY, p.Y)
+}
+
+func (b pathBounds) contains(x, y, margin int) bool {
+	return x >= b.minX-margin && x <= b.maxX+margin && y >= b.minY-margin && y <= b.maxY+margin
+}
+
+// findPath 执行A*搜索，同时返回找到的路径所经过的范围（找不到时为搜索过的范围）
+func findPath(world World, start, goal GridPos, rules MoveRules) ([]GridPos, bool, pathBounds) {
+	if rules.Height < 1 {
+		rules.Height = 1
+	}
+	maxNodes := rules.MaxNodes
+	if maxNodes <= 0 {
+		maxNodes = DefaultMaxPathNodes
+	}
+	if !rules.Flying {
+		start = settle(world, start, rules)
+		goal = settle(world, goal, rules)
+	}
+
+	explored := newPathBounds(start)
+	open := &pathQueue{}
+	heap.Push(open, &pathItem{pos: start, priority: heuristic(start, goal, rules)})
+	cost := map[GridPos]float64{start: 0}
+	cameFrom := make(map[GridPos]GridPos)
+	closed := make(map[GridPos]bool)
+
+	for open.Len() > 0 && len(closed) < maxNodes {
+		current := heap.Pop(open).(*pathItem).pos
+		if closed[current] {
+			continue
+		}
+		if current == goal {
+			path := reconstructPath(cameFrom, start, goal)
+			bounds := newPathBounds(start)
+			for _, p := range path {
+				bounds.add(p)
+			}
+			return path, true, bounds
+		}
+		closed[current] = true
+		explored.add(current)
+
+		for _, step := range neighbors(world, current, rules) {
+			if closed[step.pos] {
+				continue
+			}
+			newCost := cost[current] + step.cost
+			if old, seen := cost[step.pos]; seen && newCost >= old {
+				continue
+			}
+			cost[step.pos] = newCost
+			cameFrom[step.pos] = current
+			heap.Push(open, &pathItem{pos: step.pos, priority: newCost + heuristic(step.pos, goal, rules)})
+		}
+	}
+	return nil, false, explored
+}
+
+// reconstructPath 从终点沿来源回溯得到路径
+func reconstructPath(cameFrom map[GridPos]GridPos, start, goal GridPos) []GridPos {
+	path := []GridPos{goal}
+	for current := goal; current != start; {
+		current = cameFrom[current]
+		path = append(path, current)
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
+
+// heuristic 估计剩余代价：地面实体使用曼哈顿距离，飞行实体使用八方向距离
+func heuristic(p, goal GridPos, rules MoveRules) float64 {
+	dx := math.Abs(float64(p.X - goal.X))
+	dy := math.Abs(float64(p.Y - goal.Y))
+	if rules.Flying {
+		return math.Max(dx, dy) + (math.Sqrt2-1)*math.Min(dx, dy)
+	}
+	return dx + dy
+}
+
+// pathStep 从一个节点可以到达的相邻节点及代价
+type pathStep struct {
+	pos  GridPos
+	cost float64
+}
+
+// neighbors 按移动规则列出可以到达的相邻节点
+func neighbors(world World, p GridPos, rules MoveRules) []pathStep {
+	if rules.Flying {
+		return flyingNeighbors(world, p, rules)
+	}
+
+	steps := make([]pathStep, 0, 6)
+	for _, dir := range []int{-1, 1} {
+		x := p.X + dir
+
+		// 行走、走下台阶或跳下：沿相邻一列向下找到第一个落脚点
+		for drop := 0; drop <= rules.MaxDrop && isClear(world, x, p.Y+drop, rules); drop++ {
+			if canStand(world, GridPos{x, p.Y + drop}, rules) {
+				steps = append(steps, pathStep{GridPos{x, p.Y + drop}, 1 + float64(drop)})
+				break
+			}
+		}
+
+		// 跳上：头顶需要有足够的空间
+		for jump := 1; jump <= rules.MaxJump && isClear(world, p.X, p.Y-jump, rules); jump++ {
+			target := GridPos{x, p.Y - jump}
+			if canStand(world, target, rules) {
+				steps = append(steps, pathStep{target, 1 + float64(jump)})
+				break
+			}
+		}
+	}
+
+	if !rules.CanClimb {
+		return steps
+	}
+
+	// 沿可攀爬方块上下移动
+	if IsClimbableBlockAt(world, p.X, p.Y) {
+		if up := (GridPos{p.X, p.Y - 1}); isClear(world, up.X, up.Y, rules) {
+			steps = append(steps, pathStep{up, 1})
+		}
+	}
+	if down := (GridPos{p.X, p.Y + 1}); IsClimbableBlockAt(world, down.X, down.Y) && isClear(world, down.X, down.Y, rules) {
+		steps = append(steps, pathStep{down, 1})
+	}
+	return steps
+}
+
+// flyingNeighbors 飞行实体的相邻节点：八个方向，斜向移动不能穿过方块的拐角
+func flyingNeighbors(world World, p GridPos, rules MoveRules) []pathStep {
+	steps := make([]pathStep, 0, 8)
+	for dy := -1; dy <= 1; dy++ {
+		for dx := -1; dx <= 1; dx++ {
+			if dx == 0 && dy == 0 || !isClear(world, p.X+dx, p.Y+dy, rules) {
+				continue
+			}
+			if dx != 0 && dy != 0 {
+				if !isClear(world, p.X+dx, p.Y, rules) || !isClear(world, p.X, p.Y+dy, rules) {
+					continue
+				}
+				steps = append(steps, pathStep{GridPos{p.X + dx, p.Y + dy}, math.Sqrt2})
+				continue
+			}
+			steps = append(steps, pathStep{GridPos{p.X + dx, p.Y + dy}, 1})
+		}
+	}
+	return steps
+}
+
+// isClear 检查实体的脚在(x, y)时身体占据的格子都不是实心方块
+func isClear(world World, x, y int, rules MoveRules) bool {
+	for i := 0; i < maxInt(rules.Height, 1); i++ {
+		if IsSolidBlockAt(world, x, y-i) {
+			return false
+		}
+	}
+	return true
+}
+
+// canStand 检查地面实体能否停留在该位置：身体不被方块阻挡，并且脚下是实心方块（或者可以攀爬时抓着可攀爬方块）
+func canStand(world World, p GridPos, rules MoveRules) bool {
+	if !isClear(world, p.X, p.Y, rules) {
+		return false
+	}
+	return IsSolidBlockAt(world, p.X, p.Y+1) || rules.CanClimb && IsClimbableBlockAt(world, p.X, p.Y)
+}
+
+// settle 悬空的位置（跳跃中的玩家或下落中的生物）向下找到落脚点
+func settle(world World, p GridPos, rules MoveRules) GridPos {
+	for i := 0; i < settleDepth; i++ {
+		below := GridPos{p.X, p.Y + i}
+		if !isClear(world, below.X, below.Y, rules) {
+			break
+		}
+		if canStand(world, below, rules) {
+			return below
+		}
+	}
+	return p
+}
+
+// PathCache 缓存寻路结果，方块变化时使涉及附近格子的结果失效
+type PathCache struct {
+	entries map[pathKey]pathEntry
+}
+
+type pathKey struct {
+	start, goal GridPos
+	rules       MoveRules
+}
+
+type pathEntry struct {
+	path   []GridPos
+	found  bool
+	bounds pathBounds
+	margin int // 方块变化影响路径的距离
+}
+
+// NewPathCache 创建空的寻路缓存
+func NewPathCache() *PathCache {
+	return &PathCache{entries: make(map[pathKey]pathEntry)}
+}
+
+// FindPath 寻路，相同的起点、终点和规则直接使用缓存的结果
+func (c *PathCache) FindPath(world World, start, goal GridPos, rules MoveRules) ([]GridPos, bool) {
+	key := pathKey{start, goal, rules}
+	if entry, exists := c.entries[key]; exists {
+		return entry.path, entry.found
+	}
+
+	path, found, bounds := findPath(world, start, goal, rules)
+	if len(c.entries) >= MaxCachedPaths {
+		c.Clear()
+	}
+	margin := maxInt(maxInt(rules.MaxJump, rules.MaxDrop), rules.Height) + 1
+	c.entries[key] = pathEntry{path: path, found: found, bounds: bounds, margin: margin}
+	return path, found
+}
+
+// Invalidate 网格位置的方块发生变化，移除可能受影响的结果
+func (c *PathCache) Invalidate(x, y int) {
+	for key, entry := range c.entries {
+		if entry.bounds.contains(x, y, entry.margin) {
+			delete(c.entries, key)
+		}
+	}
+}
+
+// Clear 清空缓存
+func (c *PathCache) Clear() {
+	c.entries = make(map[pathKey]pathEntry)
+}
+
+// Len 获取缓存的结果数量
+func (c *PathCache) Len() int {
+	return len(c.entries)
+}
+
+// pathItem A*开放列表中的节点
+type pathItem struct {
+	pos      GridPos
+	priority float64
+}
+
+// pathQueue 按优先级排序的开放列表（实现heap.Interface）
+type pathQueue []*pathItem
+
+func (q pathQueue) Len() int            { return len(q) }
+func (q pathQueue) Less(i, j int) bool  { return q[i].priority < q[j].priority }
+func (q pathQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *pathQueue) Push(x interface{}) { *q = append(*q, x.(*pathItem)) }
+func (q *pathQueue) Pop() interface{} {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return item
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,189 @@
+package entity
+
+import "testing"
+
+// newPathTestWorld 创建一个在y=10处有一行地面的模拟世界，地面上方的格子是y=9
+func newPathTestWorld() *MockWorld {
+	world := NewMockWorld()
+	for x := 1; x <= 30; x++ {
+		world.AddBlockWithType(x, 10, StoneBlock)
+	}
+	return world
+}
+
+// addWall 在x列从地面向上添加指定高度的墙
+func addWall(world *MockWorld, x, height int) {
+	for i := 1; i <= height; i++ {
+		world.AddBlockWithType(x, 10-i, StoneBlock)
+	}
+}
+
+var groundRules = MoveRules{Height: 1, MaxJump: 2, MaxDrop: 4}
+
+func TestFindPathWalksOnFlatGround(t *testing.T) {
+	world := newPathTestWorld()
+	path, found := FindPath(world, GridPos{2, 9}, GridPos{8, 9}, groundRules)
+	if !found {
+		t.Fatal("Expected a path along flat ground")
+	}
+	if len(path) != 7 || path[0] != (GridPos{2, 9}) || path[6] != (GridPos{8, 9}) {
+		t.Fatalf("Expected a straight 7-node path from start to goal, got %v", path)
+	}
+	for _, p := range path {
+		if p.Y != 9 {
+			t.Errorf("Expected the path to stay on the ground, got %v", p)
+		}
+	}
+}
+
+func TestFindPathJumpHeight(t *testing.T) {
+	world := newPathTestWorld()
+	addWall(world, 5, 2)
+	path, found := FindPath(world, GridPos{2, 9}, GridPos{8, 9}, groundRules)
+	if !found {
+		t.Fatal("Expected to jump over a wall of MaxJump height")
+	}
+	climbed := false
+	for _, p := range path {
+		if p == (GridPos{5, 7}) {
+			climbed = true
+		}
+	}
+	if !climbed {
+		t.Errorf("Expected the path to cross the top of the wall, got %v", path)
+	}
+
+	addWall(world, 5, 3)
+	if _, found := FindPath(world, GridPos{2, 9}, GridPos{8, 9}, groundRules); found {
+		t.Error("Expected a wall higher than MaxJump to block the path")
+	}
+}
+
+func TestFindPathDropHeight(t *testing.T) {
+	world := newPathTestWorld()
+	for x := 2; x <= 4; x++ {
+		world.AddBlockWithType(x, 6, StoneBlock)
+	}
+
+	if _, found := FindPath(world, GridPos{3, 5}, GridPos{8, 9}, groundRules); !found {
+		t.Error("Expected to drop down from a ledge of MaxDrop height")
+	}
+	rules := groundRules
+	rules.MaxDrop = 3
+	if _, found := FindPath(world, GridPos{3, 5}, GridPos{8, 9}, rules); found {
+		t.Error("Expected a drop higher than MaxDrop to be refused")
+	}
+	if _, found := FindPath(world, GridPos{8, 9}, GridPos{3, 5}, groundRules); found {
+		t.Error("Expected the ledge to be out of jump reach from below")
+	}
+}
+
+func TestFindPathSettlesAirborneEndpoints(t *testing.T) {
+	world := newPathTestWorld()
+	path, found := FindPath(world, GridPos{2, 6}, GridPos{8, 7}, groundRules)
+	if !found {
+		t.Fatal("Expected airborne start and goal to settle onto the ground")
+	}
+	if path[0] != (GridPos{2, 9}) || path[len(path)-1] != (GridPos{8, 9}) {
+		t.Errorf("Expected the path to run between the settled positions, got %v", path)
+	}
+}
+
+func TestFindPathClimbing(t *testing.T) {
+	world := newPathTestWorld()
+	for y := 5; y <= 9; y++ {
+		world.AddBlockWithType(5, y, RopeBlock)
+	}
+	for x := 6; x <= 8; x++ {
+		world.AddBlockWithType(x, 6, StoneBlock)
+	}
+
+	rules := MoveRules{Height: 1, MaxJump: 1, MaxDrop: 4}
+	if _, found := FindPath(world, GridPos{2, 9}, GridPos{7, 5}, rules); found {
+		t.Error("Expected the platform to be unreachable without climbing")
+	}
+	rules.CanClimb = true
+	if _, found := FindPath(world, GridPos{2, 9}, GridPos{7, 5}, rules); !found {
+		t.Error("Expected to reach the platform by climbing the rope")
+	}
+}
+
+func TestFindPathFlying(t *testing.T) {
+	world := newPathTestWorld()
+	addWall(world, 5, 7)
+
+	if _, found := FindPath(world, GridPos{2, 9}, GridPos{8, 9}, groundRules); found {
+		t.Fatal("Expected the wall to block ground movement")
+	}
+
+	rules := MoveRules{Flying: true, Height: 1}
+	path, found := FindPath(world, GridPos{2, 9}, GridPos{8, 9}, rules)
+	if !found {
+		t.Fatal("Expected a flying path over the wall")
+	}
+	for i := 1; i < len(path); i++ {
+		dx, dy := path[i].X-path[i-1].X, path[i].Y-path[i-1].Y
+		if dx < -1 || dx > 1 || dy < -1 || dy > 1 {
+			t.Fatalf("Expected adjacent steps, got %v -> %v", path[i-1], path[i])
+		}
+		if IsSolidBlockAt(world, path[i].X, path[i].Y) {
+			t.Fatalf("Expected the flying path to avoid solid blocks, got %v", path[i])
+		}
+	}
+}
+
+func TestFindPathNodeLimit(t *testing.T) {
+	world := newPathTestWorld()
+	rules := MoveRules{Flying: true, Height: 1, MaxNodes: 50}
+	// 目标被完全包围，飞行实体在开阔的空间中搜索到上限后放弃
+	for dy := -1; dy <= 1; dy++ {
+		for dx := -1; dx <= 1; dx++ {
+			if dx != 0 || dy != 0 {
+				world.AddBlockWithType(20+dx, 5+dy, StoneBlock)
+			}
+		}
+	}
+	if _, found := FindPath(world, GridPos{2, 5}, GridPos{20, 5}, rules); found {
+		t.Error("Expected no path to an enclosed goal")
+	}
+}
+
+func TestPathCache(t *testing.T) {
+	world := newPathTestWorld()
+	cache := NewPathCache()
+
+	if _, found := cache.FindPath(world, GridPos{2, 9}, GridPos{8, 9}, groundRules); !found {
+		t.Fatal("Expected a path on flat ground")
+	}
+	if cache.Len() != 1 {
+		t.Fatalf("Expected 1 cached path, got %d", cache.Len())
+	}
+
+	// 没有失效时直接返回缓存的结果
+	addWall(world, 5, 3)
+	if _, found := cache.FindPath(world, GridPos{2, 9}, GridPos{8, 9}, groundRules); !found {
+		t.Error("Expected the cached path to be returned")
+	}
+
+	cache.Invalidate(25, 9)
+	if cache.Len() != 1 {
+		t.Error("Expected a distant change to keep the cached path")
+	}
+	cache.Invalidate(5, 7)
+	if cache.Len() != 0 {
+		t.Error("Expected a change along the path to invalidate it")
+	}
+	if _, found := cache.FindPath(world, GridPos{2, 9}, GridPos{8, 9}, groundRules); found {
+		t.Error("Expected the recomputed path to be blocked by the wall")
+	}
+}
+
+func TestMobMoveRules(t *testing.T) {
+	rules := GetMobProperties(MobCaveCrawler).MoveRules()
+	if rules.Flying || rules.Height != 1 || rules.MaxJump != 2 || rules.MaxDrop != MobMaxDrop {
+		t.Errorf("Unexpected crawler move rules: %+v", rules)
+	}
+	if rules := GetMobProperties(MobCritter).MoveRules(); rules.MaxJump != 1 {
+		t.Errorf("Expected critter to jump 1 block, got %d", rules.MaxJump)
+	}
+}
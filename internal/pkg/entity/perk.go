@@ -0,0 +1,270 @@
+package entity
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"os"
+	"sort"
+)
+
+// PerkOfferSize 每次提供给玩家选择的天赋数量
+const PerkOfferSize = 3
+
+// rarityWeights 没有指定权重的天赋按稀有度决定被抽中的权重
+var rarityWeights = map[Rarity]int{
+	RarityCommon:   60,
+	RarityUncommon: 25,
+	RarityRare:     10,
+	RarityEpic:     5,
+}
+
+// PerkModifiers 天赋每叠加一层对玩家能力的加成
+type PerkModifiers struct {
+	AirJumps     int     // 额外的空中跳跃次数
+	DashDistance float64 // 每帧冲刺距离
+	DashDuration int     // 冲刺持续帧数
+	MiningSpeed  float64 // 挖掘速度倍率的加成
+	MagnetRange  float64 // 吸附半径增加的像素
+	Speed        float64 // 移动速度倍率的加成
+	LifeSteal    float64 // 造成伤害时回复生命值的比例
+}
+
+// Perk 天赋：在一局游戏中到达新的层或使用天赋祭坛时从随机提供的天赋中选择一个，效果可以叠加
+type Perk struct {
+	Name        string
+	DisplayName string
+	Description string
+	Rarity      Rarity
+	Weight      int // 被抽中的权重
+	MaxStacks   int // 最多叠加的层数
+	Modifiers   PerkModifiers
+}
+
+// PerkRegistry 天赋注册表，按名称查找天赋
+type PerkRegistry struct {
+	perks map[string]*Perk
+}
+
+// Perks 全局天赋注册表，启动时通过LoadPerks从数据文件加载
+var Perks = NewPerkRegistry()
+
+// NewPerkRegistry 创建空的天赋注册表
+func NewPerkRegistry() *PerkRegistry {
+	return &PerkRegistry{perks: make(map[string]*Perk)}
+}
+
+// Register 注册天赋，同名的天赋会被替换
+func (r *PerkRegistry) Register(perk Perk) {
+	r.perks[perk.Name] = &perk
+}
+
+// Get 根据名称获取天赋
+func (r *PerkRegistry) Get(name string) (Perk, bool) {
+	perk, exists := r.perks[name]
+	if !exists {
+		return Perk{}, false
+	}
+	return *perk, true
+}
+
+// All 获取所有天赋，按名称排序
+func (r *PerkRegistry) All() []Perk {
+	all := make([]Perk, 0, len(r.perks))
+	for _, perk := range r.perks {
+		all = append(all, *perk)
+	}
+	sort.Slice(all, func(i, j int) bool {
+		return all[i].Name < all[j].Name
+	})
+	return all
+}
+
+// RollOffer 按权重随机选出最多count个不同的天赋，已经叠加到上限的天赋不会被选中
+func (r *PerkRegistry) RollOffer(count int, player *Player, rng *rand.Rand) []Perk {
+	candidates := make([]Perk, 0, len(r.perks))
+	for _, perk := range r.All() {
+		if player.PerkStacks(perk.Name) < perk.MaxStacks {
+			candidates = append(candidates, perk)
+		}
+	}
+
+	offer := make([]Perk, 0, count)
+	for len(offer) < count && len(candidates) > 0 {
+		total := 0
+		for _, perk := range candidates {
+			total += perk.Weight
+		}
+		pick := rng.Intn(total)
+		index := len(candidates) - 1
+		for i, perk := range candidates {
+			pick -= perk.Weight
+			if pick < 0 {
+				index = i
+				break
+			}
+		}
+		offer = append(offer, candidates[index])
+		candidates = append(candidates[:index:index], candidates[index+1:]...)
+	}
+	return offer
+}
+
+// AddPerk 给玩家加上一层天赋
+func (p *Player) AddPerk(name string) {
+	p.Perks = append(p.Perks, name)
+}
+
+// PerkStacks 获取玩家叠加的天赋层数
+func (p *Player) PerkStacks(name string) int {
+	stacks := 0
+	for _, perk := range p.Perks {
+		if perk == name {
+			stacks++
+		}
+	}
+	return stacks
+}
+
+// applyPerks 把玩家选择的天赋叠加到能力数值上，注册表中不存在的天赋被忽略
+func (p *Player) applyPerks(stats *PlayerStats) {
+	speed := 0.0
+	for _, name := range p.Perks {
+		perk, exists := Perks.Get(name)
+		if !exists {
+			continue
+		}
+		m := perk.Modifiers
+		stats.AirJumps += m.AirJumps
+		stats.DashDistance += m.DashDistance
+		stats.DashDuration += m.DashDuration
+		stats.MiningSpeed += m.MiningSpeed
+		stats.MagnetBonus += m.MagnetRange
+		stats.LifeSteal += m.LifeSteal
+		speed += m.Speed
+	}
+	stats.Speed *= 1 + speed
+}
+
+// StealLife 玩家造成伤害后按生命偷取比例回复生命值，不足1点的部分累计到下一次
+func (p *Player) StealLife(damage int) {
+	lifeSteal := p.Stats().LifeSteal
+	if lifeSteal <= 0 || damage <= 0 || p.Dead {
+		return
+	}
+	p.lifeStealPool += float64(damage) * lifeSteal
+	if heal := int(p.lifeStealPool); heal > 0 {
+		p.lifeStealPool -= float64(heal)
+		p.Heal(heal)
+	}
+}
+
+// perkFile 天赋数据文件的格式
+type perkFile struct {
+	Perks []perkData `json:"perks"`
+}
+
+// perkData 数据文件中的一个天赋
+type perkData struct {
+	Name        string             `json:"name"`
+	DisplayName string             `json:"display_name"`
+	Description string             `json:"description"`
+	Rarity      string             `json:"rarity"`
+	Weight      int                `json:"weight"`
+	MaxStacks   int                `json:"max_stacks"`
+	Stats       map[string]float64 `json:"stats"`
+}
+
+// LoadPerks 从JSON文件加载天赋，替换全局天赋注册表
+func LoadPerks(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	registry, err := ParsePerks(data)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	Perks = registry
+	return nil
+}
+
+// ParsePerks 解析JSON格式的天赋数据
+func ParsePerks(data []byte) (*PerkRegistry, error) {
+	var file perkFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, err
+	}
+
+	registry := NewPerkRegistry()
+	for _, entry := range file.Perks {
+		perk, err := entry.toPerk()
+		if err != nil {
+			return nil, fmt.Errorf("perk %q: %w", entry.Name, err)
+		}
+		if _, exists := registry.Get(perk.Name); exists {
+			return nil, fmt.Errorf("perk %q: duplicate name", perk.Name)
+		}
+		registry.Register(perk)
+	}
+	return registry, nil
+}
+
+// toPerk 把数据文件中的天赋转换为天赋
+func (d perkData) toPerk() (Perk, error) {
+	if d.Name == "" {
+		return Perk{}, fmt.Errorf("missing name")
+	}
+	perk := Perk{
+		Name:        d.Name,
+		DisplayName: d.DisplayName,
+		Description: d.Description,
+		Weight:      d.Weight,
+		MaxStacks:   d.MaxStacks,
+	}
+	if perk.DisplayName == "" {
+		perk.DisplayName = d.Name
+	}
+	if d.Rarity != "" {
+		rarity, ok := rarityNames[d.Rarity]
+		if !ok {
+			return perk, fmt.Errorf("unknown rarity %q", d.Rarity)
+		}
+		perk.Rarity = rarity
+	}
+	if perk.Weight < 0 {
+		return perk, fmt.Errorf("weight must not be negative")
+	}
+	if perk.Weight == 0 {
+		perk.Weight = rarityWeights[perk.Rarity]
+	}
+	if perk.MaxStacks <= 0 {
+		perk.MaxStacks = 1
+	}
+	if len(d.Stats) == 0 {
+		return perk, fmt.Errorf("missing stats")
+	}
+
+	for stat, value := range d.Stats {
+		m := &perk.Modifiers
+		switch stat {
+		case "air_jumps":
+			m.AirJumps = int(value)
+		case "dash_distance":
+			m.DashDistance = value
+		case "dash_duration":
+			m.DashDuration = int(value)
+		case "mining_speed":
+			m.MiningSpeed = value
+		case "magnet_range":
+			m.MagnetRange = value
+		case "speed":
+			m.Speed = value
+		case "life_steal":
+			m.LifeSteal = value
+		default:
+			return perk, fmt.Errorf("unknown stat %q", stat)
+		}
+	}
+	return perk, nil
+}
@@ -0,0 +1,131 @@
+package entity
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestPerksDataFile(t *testing.T) {
+	for _, name := range []string{"air_jump", "long_dash", "quick_hands", "magnet", "vampirism"} {
+		if _, ok := Perks.Get(name); !ok {
+			t.Errorf("Expected perk %q in the data file", name)
+		}
+	}
+	magnet, _ := Perks.Get("magnet")
+	if magnet.Rarity != RarityCommon || magnet.Weight != rarityWeights[RarityCommon] || magnet.MaxStacks != 3 {
+		t.Errorf("Expected magnet to use the common weight, got %+v", magnet)
+	}
+}
+
+func TestPerksModifyStats(t *testing.T) {
+	player := NewPlayer(0, 0)
+	base := player.Stats()
+	player.AddPerk("air_jump")
+	player.AddPerk("long_dash")
+	player.AddPerk("long_dash")
+	player.AddPerk("quick_hands")
+	player.AddPerk("no_such_perk")
+
+	stats := player.Stats()
+	if stats.AirJumps != base.AirJumps+1 {
+		t.Errorf("Expected one more air jump, got %d", stats.AirJumps)
+	}
+	if stats.DashDistance != base.DashDistance+4 || stats.DashDuration != base.DashDuration+8 {
+		t.Errorf("Expected stacked dash bonus, got %.0f x %d", stats.DashDistance, stats.DashDuration)
+	}
+	if stats.MiningSpeed != 1.25 {
+		t.Errorf("Expected faster mining, got %.2f", stats.MiningSpeed)
+	}
+	if player.PerkStacks("long_dash") != 2 {
+		t.Errorf("Expected 2 stacks of long dash, got %d", player.PerkStacks("long_dash"))
+	}
+}
+
+func TestStealLife(t *testing.T) {
+	player := NewPlayer(0, 0)
+	player.Health = 5
+	player.StealLife(10)
+	if player.Health != 5 {
+		t.Fatal("Expected no healing without life steal")
+	}
+
+	player.AddPerk("vampirism")
+	player.StealLife(3) // 0.6点，累计到下一次
+	if player.Health != 5 {
+		t.Errorf("Expected fractional healing to be pooled, got %d", player.Health)
+	}
+	player.StealLife(3)
+	if player.Health != 6 {
+		t.Errorf("Expected pooled healing to heal 1, got %d", player.Health)
+	}
+}
+
+func TestRollPerkOffer(t *testing.T) {
+	registry, err := ParsePerks([]byte(`{"perks": [
+		{"name": "a", "rarity": "common", "stats": {"speed": 0.1}},
+		{"name": "b", "rarity": "epic", "max_stacks": 2, "stats": {"air_jumps": 1}},
+		{"name": "c", "weight": 3, "stats": {"life_steal": 0.1}},
+		{"name": "d", "rarity": "rare", "stats": {"magnet_range": 32}}
+	]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	player := NewPlayer(0, 0)
+	rng := rand.New(rand.NewSource(5))
+
+	offer := registry.RollOffer(PerkOfferSize, player, rng)
+	if len(offer) != PerkOfferSize {
+		t.Fatalf("Expected %d perks, got %d", PerkOfferSize, len(offer))
+	}
+	seen := make(map[string]bool)
+	for _, perk := range offer {
+		if seen[perk.Name] {
+			t.Errorf("Expected distinct perks, got %q twice", perk.Name)
+		}
+		seen[perk.Name] = true
+	}
+
+	// 叠加到上限的天赋不再提供
+	player.AddPerk("a")
+	player.AddPerk("c")
+	player.AddPerk("d")
+	offer = registry.RollOffer(PerkOfferSize, player, rng)
+	if len(offer) != 1 || offer[0].Name != "b" {
+		t.Fatalf("Expected only perk b to be offered, got %v", offer)
+	}
+
+	// 相同的种子提供相同的天赋
+	first := registry.RollOffer(PerkOfferSize, NewPlayer(0, 0), rand.New(rand.NewSource(9)))
+	second := registry.RollOffer(PerkOfferSize, NewPlayer(0, 0), rand.New(rand.NewSource(9)))
+	for i := range first {
+		if first[i].Name != second[i].Name {
+			t.Fatal("Expected the same seed to offer the same perks")
+		}
+	}
+
+	// 权重高的稀有度更常出现
+	counts := make(map[string]int)
+	for i := 0; i < 1000; i++ {
+		counts[registry.RollOffer(1, NewPlayer(0, 0), rng)[0].Name]++
+	}
+	if counts["a"] <= counts["d"] || counts["d"] <= counts["b"] {
+		t.Errorf("Expected common perks to be offered more often than rare and epic ones, got %v", counts)
+	}
+}
+
+func TestParsePerksErrors(t *testing.T) {
+	bad := []string{
+		`{"perks": [{"stats": {"speed": 0.1}}]}`,
+		`{"perks": [{"name": "a"}]}`,
+		`{"perks": [{"name": "a", "stats": {"flying": 1}}]}`,
+		`{"perks": [{"name": "a", "rarity": "legendary", "stats": {"speed": 0.1}}]}`,
+		`{"perks": [{"name": "a", "weight": -1, "stats": {"speed": 0.1}}]}`,
+		`{"perks": [{"name": "a", "stats": {"speed": 0.1}}, {"name": "a", "stats": {"speed": 0.2}}]}`,
+		`not json`,
+	}
+	for _, data := range bad {
+		if _, err := ParsePerks([]byte(data)); err == nil {
+			t.Errorf("Expected error for %s", data)
+		}
+	}
+}
@@ -13,6 +13,11 @@ const (
 	DoubleJumpMax  = 2
 	DashDistance   = 15 // 提升冲刺距离
 	DashDuration   = 25  // 延长冲刺持续时间
+	WallSlideSpeed = 2.0 // 贴墙滑落时的最大下落速度
+	WallJumpKick   = 6.0 // 蹬墙跳时远离墙壁的水平速度
+	WallJumpLock   = 10  // 蹬墙跳后忽略朝向墙壁输入的帧数
+	ClimbSpeed     = 3.0 // 攀爬速度
+	PlayerReach    = 5 * BlockSize // 默认可以放置和挖掘方块的距离
 )
 
 // DashTrail 表示冲刺残影
@@ -21,6 +26,7 @@ type DashTrail struct {
 	Alpha      float64
 	Timer      int
 	Duration   int
+	Strike     bool // 冲刺攻击命中目标后留下的残影，以不同颜色绘制
 }
 
 type Player struct {
@@ -31,6 +37,32 @@ type Player struct {
 	Dashing       bool
 	DashTimer     int
 	DashDirection int // 0=right, 1=left, 2=up, 3=down
+	DashCooldownTimer int  // 距离可以再次冲刺的剩余帧数
+	DashStrike    bool // 是否开启冲刺攻击模式：冲刺伤害经过的目标，期间无敌
+	DashHit       bool // 本次冲刺攻击是否命中了目标
+	dashStruck    []Damageable // 本次冲刺已经攻击过的目标
+	WallDirection int  // 接触的墙壁方向：-1=左侧, 1=右侧, 0=无
+	WallSliding   bool // 是否正在贴墙滑落
+	WallJumpTimer int  // 蹬墙跳后的输入锁定计时
+	WallJumpSide  int  // 最近一次蹬墙跳离开的墙壁方向
+	MoveInput     int  // 本帧的水平移动输入：-1=左, 1=右, 0=无
+	Facing        int  // 朝向：-1=左, 1=右
+	OnClimbable   bool // 是否与可攀爬方块（梯子、藤蔓、绳索）重叠
+	Climbing      bool // 是否正抓在可攀爬方块上
+	ClimbInput    int  // 本帧的攀爬输入：-1=上, 1=下, 0=无
+	Health            int          // 当前生命值
+	MaxHealth         int          // 最大生命值
+	Dead              bool         // 是否死亡
+	InvulnerableTimer int          // 剩余无敌帧数
+	AttackTimer       int          // 武器攻击的剩余冷却帧数
+	RegenTimer        int          // 距离开始自然回血的剩余帧数
+	DamageTicks       int          // 环境伤害和回血的计时
+	LastDamageSource  DamageSource // 最近一次受到伤害的来源
+	SpawnX, SpawnY    float64      // 重生点
+	Effects           []StatusEffect // 当前生效的状态效果
+	Reach             float64        // 可以放置和挖掘方块的最大距离（从玩家中心算起）
+	Perks             []string       // 选择的天赋名称，同一个天赋可以出现多次
+	lifeStealPool     float64        // 生命偷取累计的不足1点的回复量
 	World         World // 添加对世界的引用，用于碰撞检测
 	DashTrails    []DashTrail // 存储冲刺残影
 	Inventory     *Inventory  // 玩家物品栏
@@ -39,6 +71,7 @@ type Player struct {
 // World 接口定义，用于玩家与世界交互
 type World interface {
 	IsBlockAt(x, y int) bool
+	GetBlock(x, y int) (*Block, bool)
 }
 
 func NewPlayer(x, y float64) *Player { 
@@ -49,8 +82,15 @@ func NewPlayer(x, y float64) *Player {
 		Y: y,
 		OnGround: true,
 		DoubleJump: DoubleJumpMax,
+		Facing: 1,
+		Health: PlayerMaxHealth,
+		MaxHealth: PlayerMaxHealth,
+		SpawnX: x,
+		SpawnY: y,
 		DashTrails: make([]DashTrail, 0),
 		Inventory: inventory,
+		Effects: make([]StatusEffect, 0),
+		Reach: PlayerReach,
 	}
 }
 
@@ -66,6 +106,10 @@ func (p *Player) GetInventory() *Inventory {
 
 // Update 更新玩家状态
 func (p *Player) Update() {
+	if p.DashCooldownTimer > 0 {
+		p.DashCooldownTimer--
+	}
+	
 	// 处理冲刺状态
 	if p.Dashing {
 		p.updateDash()
@@ -74,9 +118,37 @@ func (p *Player) Update() {
 	// 更新残影
 	p.updateDashTrails()
 
-	// 应用重力
-	if !p.OnGround && !p.Dashing {
-		p.VY += Gravity
+	// 状态效果会修改本帧的能力数值
+	stats := p.Stats()
+
+	// 离开可攀爬方块时松手，向上爬出顶端时轻跳一下以便登上平台
+	p.OnClimbable = p.checkClimbable()
+	if !p.OnClimbable && p.Climbing {
+		p.Climbing = false
+		if p.ClimbInput < 0 {
+			p.VY = -stats.JumpPower / 2
+		}
+	}
+
+	// 攀爬时不受重力影响，没有输入时悬挂不动
+	if p.Climbing && !p.Dashing {
+		p.VY = float64(p.ClimbInput) * ClimbSpeed
+	} else if !p.OnGround && !p.Dashing {
+		// 应用重力
+		p.VY += stats.Gravity
+	}
+
+	// 贴墙滑落：在空中朝墙壁方向按键时限制下落速度
+	p.WallSliding = false
+	if !p.OnGround && !p.Dashing && !p.Climbing && p.WallDirection != 0 && p.MoveInput == p.WallDirection && p.VY > 0 {
+		p.WallSliding = true
+		p.VY = min(p.VY, WallSlideSpeed)
+	}
+	if p.WallJumpTimer > 0 {
+		p.WallJumpTimer--
+	}
+	if p.AttackTimer > 0 {
+		p.AttackTimer--
 	}
 
 	// 应用空气阻力
@@ -88,6 +160,14 @@ func (p *Player) Update() {
 
 	// 更新位置并处理碰撞
 	p.updatePosition()
+
+	// 更新状态效果和生命值（环境伤害、回血）
+	p.updateEffects()
+	p.updateHealth()
+
+	// 移动输入只在一帧内有效
+	p.MoveInput = 0
+	p.ClimbInput = 0
 }
 
 // updatePosition 更新位置并处理碰撞
@@ -98,26 +178,128 @@ func (p *Player) updatePosition() {
 	// 先处理水平移动
 	p.X += p.VX
 	
-	// 检查水平碰撞
+	// 检查水平碰撞，撞墙时贴紧墙面
 	if p.World != nil && p.checkHorizontalCollision() {
-		p.X = oldX
+		p.snapToWall(oldX)
 		p.VX = 0
 	}
 	
 	// 处理垂直移动
+	embedded := p.World != nil && p.checkOverlap()
 	p.Y += p.VY
 	
-	// 检查垂直碰撞
+	// 检查垂直碰撞，落地时根据撞击速度计算摔落伤害
 	onGround := false
-	if p.World != nil {
+	if embedded {
+		// 卡在方块内时不做落地修正（否则会被一格一格顶出方块），保持原位并承受窒息伤害
+		p.Y -= p.VY
+		onGround = true
+	} else if p.World != nil {
+		impactVY := p.VY
 		onGround = p.checkVerticalCollision()
 		if onGround {
+			if !p.OnGround {
+				p.applyFallDamage(impactVY)
+			}
 			p.VY = 0
 		}
 	}
 	
 	// 更新OnGround状态
 	p.SetOnGround(onGround)
+
+	// 记录接触的墙壁方向
+	p.WallDirection = p.checkWallContact()
+}
+
+// checkClimbable 检查玩家是否与可攀爬方块重叠
+func (p *Player) checkClimbable() bool {
+	if p.World == nil {
+		return false
+	}
+
+	left := int(math.Floor((p.X - PlayerSize/2) / BlockSize))
+	right := int(math.Floor((p.X + PlayerSize/2 - 1) / BlockSize))
+	top := int(math.Floor((p.Y - PlayerSize/2) / BlockSize))
+	bottom := int(math.Floor((p.Y + PlayerSize/2 - 1) / BlockSize))
+
+	for x := left; x <= right; x++ {
+		for y := top; y <= bottom; y++ {
+			if IsClimbableBlockAt(p.World, x, y) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// snapToWall 将玩家贴紧撞到的墙面，避免与墙之间留下空隙
+func (p *Player) snapToWall(oldX float64) {
+	if p.VX > 0 {
+		right := int(math.Floor((p.X + PlayerSize/2 - 1) / BlockSize))
+		p.X = float64(right*BlockSize - PlayerSize/2)
+	} else if p.VX < 0 {
+		left := int(math.Floor((p.X - PlayerSize/2) / BlockSize))
+		p.X = float64((left+1)*BlockSize + PlayerSize/2)
+	}
+	// 贴墙后仍然重叠（例如卡在方块内）则回退到原位置
+	if p.checkOverlap() {
+		p.X = oldX
+	}
+}
+
+// checkOverlap 检查玩家当前是否与任何方块重叠
+func (p *Player) checkOverlap() bool {
+	left := int(math.Floor((p.X - PlayerSize/2) / BlockSize))
+	right := int(math.Floor((p.X + PlayerSize/2 - 1) / BlockSize))
+	top := int(math.Floor((p.Y - PlayerSize/2) / BlockSize))
+	bottom := int(math.Floor((p.Y + PlayerSize/2 - 1) / BlockSize))
+
+	for x := left; x <= right; x++ {
+		for y := top; y <= bottom; y++ {
+			if IsSolidBlockAt(p.World, x, y) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// checkWallContact 检查玩家左右两侧是否紧贴墙壁
+// 返回-1表示左侧有墙，1表示右侧有墙，0表示没有接触墙壁
+// 两侧都有墙时优先返回玩家正在按键的方向
+func (p *Player) checkWallContact() int {
+	if p.World == nil {
+		return 0
+	}
+
+	leftColumn := int(math.Floor((p.X - PlayerSize/2 - 1) / BlockSize))
+	rightColumn := int(math.Floor((p.X + PlayerSize/2) / BlockSize))
+	top := int(math.Floor((p.Y - PlayerSize/2) / BlockSize))
+	bottom := int(math.Floor((p.Y + PlayerSize/2 - 1) / BlockSize))
+
+	leftWall, rightWall := false, false
+	for y := top; y <= bottom; y++ {
+		if IsSolidBlockAt(p.World, leftColumn, y) {
+			leftWall = true
+		}
+		if IsSolidBlockAt(p.World, rightColumn, y) {
+			rightWall = true
+		}
+	}
+
+	switch {
+	case leftWall && rightWall:
+		if p.MoveInput != 0 {
+			return p.MoveInput
+		}
+		return -1
+	case leftWall:
+		return -1
+	case rightWall:
+		return 1
+	}
+	return 0
 }
 
 // checkHorizontalCollision 检查水平碰撞
@@ -134,10 +316,10 @@ func (p *Player) checkHorizontalCollision() bool {
 	
 	// 检查左边和右边是否有方块
 	for y := top; y <= bottom; y++ {
-		if p.VX < 0 && p.World.IsBlockAt(left, y) {
+		if p.VX < 0 && IsSolidBlockAt(p.World, left, y) {
 			return true
 		}
-		if p.VX > 0 && p.World.IsBlockAt(right, y) {
+		if p.VX > 0 && IsSolidBlockAt(p.World, right, y) {
 			return true
 		}
 	}
@@ -160,7 +342,7 @@ func (p *Player) checkVerticalCollision() bool {
 	// 检查下方是否有方块（着陆）
 	if p.VY >= 0 {
 		for x := left; x <= right; x++ {
-			if p.World.IsBlockAt(x, bottom) {
+			if IsSolidBlockAt(p.World, x, bottom) {
 				// 调整玩家位置到方块上方
 				p.Y = float64(bottom*BlockSize - PlayerSize/2)
 				return true
@@ -171,7 +353,7 @@ func (p *Player) checkVerticalCollision() bool {
 	// 检查上方是否有方块（撞头）
 	if p.VY < 0 {
 		for x := left; x <= right; x++ {
-			if p.World.IsBlockAt(x, top) {
+			if IsSolidBlockAt(p.World, x, top) {
 				// 调整玩家位置到方块下方
 				p.Y = float64((top+1)*BlockSize + PlayerSize/2)
 				return false // 仍然不着地
@@ -197,7 +379,7 @@ func (p *Player) checkDashCollision(oldX, oldY float64) bool {
 	// 检查所有方向上是否有方块
 	for x := left; x <= right; x++ {
 		for y := top; y <= bottom; y++ {
-			if p.World.IsBlockAt(x, y) {
+			if IsSolidBlockAt(p.World, x, y) {
 				return true
 			}
 		}
@@ -209,26 +391,87 @@ func (p *Player) checkDashCollision(oldX, oldY float64) bool {
 // MoveHorizontal 控制水平移动
 func (p *Player) MoveHorizontal(direction int) {
 	// direction: -1=left, 1=right
-	p.VX = float64(direction) * PlayerSpeed
+	p.MoveInput = direction
+	if direction != 0 {
+		p.Facing = direction
+	}
+
+	// 蹬墙跳后的短时间内忽略朝向墙壁的输入，保证能被踢离墙面
+	if p.WallJumpTimer > 0 && direction == p.WallJumpSide {
+		return
+	}
+	p.VX = float64(direction) * p.Stats().Speed
+}
+
+// Climb 在可攀爬方块上上下移动
+// direction: -1=向上, 1=向下；不与可攀爬方块重叠时无效
+func (p *Player) Climb(direction int) {
+	if !p.OnClimbable {
+		return
+	}
+	p.ClimbInput = direction
+	if !p.Climbing {
+		p.Climbing = true
+		p.DoubleJump = p.Stats().AirJumps
+	}
+}
+
+// IsClimbing 检查玩家是否正在攀爬
+func (p *Player) IsClimbing() bool {
+	return p.Climbing
+}
+
+// IsOnClimbable 检查玩家是否与可攀爬方块重叠
+func (p *Player) IsOnClimbable() bool {
+	return p.OnClimbable
 }
 
 // Jump 跳跃
+// 在空中贴墙时执行蹬墙跳：向远离墙壁的方向弹出，并像从地面起跳一样恢复空中跳跃次数
+// 攀爬时跳跃会松开可攀爬方块
 func (p *Player) Jump() {
-	if p.OnGround {
-		p.VY = -JumpPower
+	stats := p.Stats()
+	if p.OnGround || p.Climbing {
+		p.VY = -stats.JumpPower
 		p.OnGround = false
-		p.DoubleJump = DoubleJumpMax - 1
+		p.Climbing = false
+		p.DoubleJump = stats.AirJumps - 1
+	} else if p.WallDirection != 0 {
+		p.WallJump()
 	} else if p.DoubleJump > 0 {
-		p.VY = -JumpPower
+		p.VY = -stats.JumpPower
 		p.DoubleJump--
 	}
 }
 
-// Dash 冲刺，根据玩家状态和鼠标位置决定方向
+// WallJump 蹬墙跳，向远离当前接触墙壁的方向弹出
+func (p *Player) WallJump() {
+	if p.WallDirection == 0 {
+		return
+	}
+	stats := p.Stats()
+	p.VY = -stats.JumpPower
+	p.VX = -float64(p.WallDirection) * WallJumpKick
+	p.DoubleJump = stats.AirJumps - 1
+	p.WallJumpTimer = WallJumpLock
+	p.WallJumpSide = p.WallDirection
+	p.WallSliding = false
+	p.WallDirection = 0
+}
+
+// IsWallSliding 检查玩家是否正在贴墙滑落
+func (p *Player) IsWallSliding() bool {
+	return p.WallSliding
+}
+
+// Dash 冲刺，根据玩家状态和鼠标位置决定方向；冷却结束前不能再次冲刺
 func (p *Player) Dash(mouseX, mouseY float64) {
-	if !p.Dashing {
+	if !p.Dashing && p.DashCooldownTimer <= 0 {
 		p.Dashing = true
-		p.DashTimer = DashDuration
+		p.DashTimer = p.Stats().DashDuration
+		p.DashCooldownTimer = DashCooldown
+		p.DashHit = false
+		p.dashStruck = p.dashStruck[:0]
 		
 		// 如果玩家正在移动，则朝移动方向冲刺
 		if math.Abs(p.VX) > 0.1 {
@@ -285,15 +528,16 @@ func (p *Player) updateDash() {
 	oldX, oldY := p.X, p.Y
 	
 	// 根据方向移动
+	dashDistance := p.Stats().DashDistance
 	switch p.DashDirection {
 	case 0: // Right
-		p.X += DashDistance
+		p.X += dashDistance
 	case 1: // Left
-		p.X -= DashDistance
+		p.X -= dashDistance
 	case 2: // Up
-		p.Y -= DashDistance
+		p.Y -= dashDistance
 	case 3: // Down
-		p.Y += DashDistance
+		p.Y += dashDistance
 	}
 	
 	// 检查碰撞，如果碰撞则停止冲刺
@@ -303,6 +547,11 @@ func (p *Player) updateDash() {
 		return
 	}
 	
+	// 冲刺攻击模式下伤害经过的目标
+	if p.DashStrike {
+		p.strikeAlongDash(oldX, oldY)
+	}
+	
 	if p.DashTimer <= 0 {
 		p.Dashing = false
 	}
@@ -316,6 +565,7 @@ func (p *Player) addDashTrail() {
 		Alpha: 1.0,
 		Timer: 0,
 		Duration: 20,
+		Strike: p.DashHit,
 	}
 	p.DashTrails = append(p.DashTrails, trail)
 }
@@ -362,7 +612,7 @@ func (p *Player) IsOnGround() bool {
 func (p *Player) SetOnGround(onGround bool) {
 	// 当从空中落到地面时，重置双跳次数
 	if !p.OnGround && onGround {
-		p.DoubleJump = DoubleJumpMax
+		p.DoubleJump = p.Stats().AirJumps
 	}
 	p.OnGround = onGround
 	if onGround {
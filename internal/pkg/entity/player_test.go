@@ -119,4 +119,194 @@ func TestPlayerUpdate(t *testing.T) {
 	if player.DoubleJump != DoubleJumpMax {
 		t.Errorf("Expected DoubleJump=%d when landing, got %d", DoubleJumpMax, player.DoubleJump)
 	}
-}
\ No newline at end of file
+}
+
+// newWallTestPlayer 创建一个悬空并紧挨右侧墙壁的玩家（墙壁位于网格第3列）
+func newWallTestPlayer() *Player {
+	mockWorld := NewMockWorld()
+	for y := 0; y < 6; y++ {
+		mockWorld.AddBlock(3, y)
+	}
+
+	player := NewPlayer(float64(3*BlockSize-PlayerSize/2-2), float64(2*BlockSize+PlayerSize/2))
+	player.SetWorld(mockWorld)
+	player.SetOnGround(false)
+	return player
+}
+
+func TestPlayerWallContact(t *testing.T) {
+	player := newWallTestPlayer()
+
+	// 朝墙壁移动，应贴紧墙面并记录右侧墙壁
+	player.MoveHorizontal(1)
+	player.Update()
+
+	expectedX := float64(3*BlockSize - PlayerSize/2)
+	if player.X != expectedX {
+		t.Errorf("Expected player to be snapped against wall at X=%f, got %f", expectedX, player.X)
+	}
+
+	if player.WallDirection != 1 {
+		t.Errorf("Expected WallDirection=1, got %d", player.WallDirection)
+	}
+
+	// 松开按键后依然保持接触
+	player.Update()
+	if player.WallDirection != 1 {
+		t.Errorf("Expected wall contact to persist without input, got %d", player.WallDirection)
+	}
+}
+
+func TestPlayerWallSlide(t *testing.T) {
+	player := newWallTestPlayer()
+	player.MoveHorizontal(1)
+	player.Update()
+
+	// 快速下落时朝墙按键，下落速度应被限制
+	player.VY = 8
+	player.MoveHorizontal(1)
+	player.Update()
+
+	if !player.IsWallSliding() {
+		t.Error("Expected player to be wall sliding")
+	}
+
+	if player.VY > WallSlideSpeed {
+		t.Errorf("Expected VY<=%f while wall sliding, got %f", WallSlideSpeed, player.VY)
+	}
+
+	// 不按向墙壁时不减速
+	player.VY = 8
+	player.Update()
+	if player.IsWallSliding() {
+		t.Error("Expected no wall slide without pressing into the wall")
+	}
+}
+
+func TestPlayerWallJump(t *testing.T) {
+	player := newWallTestPlayer()
+	player.MoveHorizontal(1)
+	player.Update()
+
+	// 用完空中跳跃次数后仍可蹬墙跳
+	player.DoubleJump = 0
+	player.Jump()
+
+	if player.VY != -JumpPower {
+		t.Errorf("Expected VY=%f after wall jump, got %f", -JumpPower, player.VY)
+	}
+
+	if player.VX != -WallJumpKick {
+		t.Errorf("Expected VX=%f after wall jump, got %f", -WallJumpKick, player.VX)
+	}
+
+	if player.DoubleJump != DoubleJumpMax-1 {
+		t.Errorf("Expected DoubleJump=%d after wall jump, got %d", DoubleJumpMax-1, player.DoubleJump)
+	}
+
+	// 锁定期间朝墙按键不会抵消弹出速度
+	player.MoveHorizontal(1)
+	if player.VX >= 0 {
+		t.Errorf("Expected wall jump kick to survive input toward the wall, got VX=%f", player.VX)
+	}
+
+	// 离开墙壁后空中跳跃次数照常消耗
+	player.Update()
+	player.Jump()
+	if player.DoubleJump != DoubleJumpMax-2 {
+		t.Errorf("Expected DoubleJump=%d after air jump, got %d", DoubleJumpMax-2, player.DoubleJump)
+	}
+}
+
+// newLadderTestPlayer 创建一个站在梯子旁的玩家（梯子位于网格第2列，第0-5行）
+func newLadderTestPlayer() *Player {
+	mockWorld := NewMockWorld()
+	for y := 0; y < 6; y++ {
+		mockWorld.AddBlockWithType(2, y, LadderBlock)
+	}
+	mockWorld.AddBlock(2, 6)
+
+	player := NewPlayer(float64(2*BlockSize+PlayerSize/2), float64(4*BlockSize+PlayerSize/2))
+	player.SetWorld(mockWorld)
+	player.SetOnGround(false)
+	return player
+}
+
+func TestPlayerClimb(t *testing.T) {
+	player := newLadderTestPlayer()
+	player.Update()
+
+	if !player.IsOnClimbable() {
+		t.Fatal("Expected player to overlap the ladder")
+	}
+
+	// 向上攀爬
+	initialY := player.Y
+	player.Climb(-1)
+	player.Update()
+
+	if !player.IsClimbing() {
+		t.Error("Expected player to be climbing")
+	}
+
+	if player.Y != initialY-ClimbSpeed {
+		t.Errorf("Expected Y=%f after climbing up, got %f", initialY-ClimbSpeed, player.Y)
+	}
+
+	// 没有输入时悬挂不动，不受重力影响
+	hangY := player.Y
+	for i := 0; i < 10; i++ {
+		player.Update()
+	}
+
+	if player.Y != hangY {
+		t.Errorf("Expected player to hang at Y=%f, got %f", hangY, player.Y)
+	}
+
+	// 向下攀爬
+	player.Climb(1)
+	player.Update()
+	if player.Y != hangY+ClimbSpeed {
+		t.Errorf("Expected Y=%f after climbing down, got %f", hangY+ClimbSpeed, player.Y)
+	}
+}
+
+func TestPlayerJumpOffClimbable(t *testing.T) {
+	player := newLadderTestPlayer()
+	player.Update()
+	player.Climb(-1)
+	player.Update()
+
+	player.Jump()
+
+	if player.IsClimbing() {
+		t.Error("Expected player to let go after jumping")
+	}
+
+	if player.VY != -JumpPower {
+		t.Errorf("Expected VY=%f after jumping off, got %f", -JumpPower, player.VY)
+	}
+
+	// 松手后重新受重力影响
+	player.Update()
+	if player.VY != -JumpPower+Gravity {
+		t.Errorf("Expected gravity to apply after jumping off, got VY=%f", player.VY)
+	}
+}
+
+func TestPlayerPassesThroughClimbable(t *testing.T) {
+	mockWorld := NewMockWorld()
+	mockWorld.AddBlockWithType(2, 3, VineBlock)
+
+	// 站在藤蔓上方，没有抓住时应该穿过藤蔓下落
+	player := NewPlayer(float64(2*BlockSize+PlayerSize/2), float64(2*BlockSize+PlayerSize/2))
+	player.SetWorld(mockWorld)
+	player.SetOnGround(false)
+	player.VY = 4
+	player.Update()
+	player.Update()
+
+	if player.IsOnGround() {
+		t.Error("Expected player not to land on a vine")
+	}
+}
@@ -0,0 +1,190 @@
+package entity
+
+import "math"
+
+const (
+	ProjectileMaxFallSpeed = 14.0 // 投射物的最大下落速度
+	ProjectileLifetime     = 1200 // 投射物的存活帧数，插在方块上后重新计时（20秒）
+	ProjectileStep         = 4.0  // 逐段检测碰撞时每段的最大移动距离，避免高速穿过方块和生物
+	ProjectilePickupRange  = 24.0 // 玩家可以拾取插在方块上的投射物的距离
+)
+
+// ProjectileKind 投射物种类
+type ProjectileKind int
+
+const (
+	ProjectileArrow ProjectileKind = iota // 箭
+	ProjectileKnife                       // 飞刀
+)
+
+// ProjectileProperties 投射物属性
+type ProjectileProperties struct {
+	Name    string
+	Item    string  // 回收后得到的物品（数据文件中的名称），也是远程武器消耗的弹药
+	Gravity float64 // 每帧增加的下落速度
+	Sprite  int
+}
+
+// projectileProperties 各种投射物的属性
+var projectileProperties = map[ProjectileKind]ProjectileProperties{
+	ProjectileArrow: {Name: "Arrow", Item: "arrow", Gravity: 0.15, Sprite: ArrowSprite},
+	ProjectileKnife: {Name: "Knife", Item: "throwing_knife", Gravity: 0.3, Sprite: ThrowingKnifeSprite},
+}
+
+// GetProjectileProperties 获取投射物种类的属性
+func GetProjectileProperties(kind ProjectileKind) ProjectileProperties {
+	return projectileProperties[kind]
+}
+
+// ItemType 获取投射物回收后得到的物品
+func (p ProjectileProperties) ItemType() (ItemType, bool) {
+	return ParseItemName(p.Item)
+}
+
+// Projectile 飞行中或插在方块上的投射物
+// 飞行时受重力影响，命中可被伤害的实体时造成伤害和击退然后消失，撞到实心方块时插在上面，可以被玩家回收；
+// 插着的方块被破坏后重新下落
+type Projectile struct {
+	Kind           ProjectileKind
+	X, Y           float64 // 投射物头部的位置
+	VX, VY         float64
+	Angle          float64 // 飞行方向（弧度），插在方块上后保持不变
+	Damage         int
+	Knockback      float64
+	Stuck          bool
+	StuckX, StuckY int // 插入的方块位置
+	Lifetime       int
+	Dead           bool
+	Landed         bool // 命中了目标
+	Dealt          int  // 命中时对目标实际造成的伤害
+	Hostile        bool // 首领等敌人发射的投射物，只会命中玩家，不能回收
+	World          World
+}
+
+// NewProjectile 创建从指定位置以指定速度飞出的投射物
+func NewProjectile(kind ProjectileKind, x, y, vx, vy float64, damage int, knockback float64) *Projectile {
+	return &Projectile{
+		Kind:      kind,
+		X:         x,
+		Y:         y,
+		VX:        vx,
+		VY:        vy,
+		Angle:     math.Atan2(vy, vx),
+		Damage:    damage,
+		Knockback: knockback,
+		Lifetime:  ProjectileLifetime,
+	}
+}
+
+// Props 获取投射物的属性
+func (p *Projectile) Props() ProjectileProperties {
+	return GetProjectileProperties(p.Kind)
+}
+
+// SetWorld 设置世界引用
+func (p *Projectile) SetWorld(world World) {
+	p.World = world
+}
+
+// GetPosition 获取投射物的位置
+func (p *Projectile) GetPosition() (float64, float64) {
+	return p.X, p.Y
+}
+
+// Update 更新一帧：飞行中的投射物检测与方块和目标的碰撞，插着的方块消失后重新下落
+func (p *Projectile) Update(targets []Damageable) {
+	if p.Dead {
+		return
+	}
+	p.Lifetime--
+	if p.Lifetime <= 0 {
+		p.Dead = true
+		return
+	}
+
+	if p.Stuck {
+		if p.World == nil || IsSolidBlockAt(p.World, p.StuckX, p.StuckY) {
+			return
+		}
+		p.Stuck = false
+		p.VX, p.VY = 0, 0
+	}
+
+	p.VY = math.Min(p.VY+p.Props().Gravity, ProjectileMaxFallSpeed)
+	if p.VX != 0 || p.VY != 0 {
+		p.Angle = math.Atan2(p.VY, p.VX)
+	}
+
+	steps := int(math.Ceil(math.Max(math.Abs(p.VX), math.Abs(p.VY)) / ProjectileStep))
+	for i := 0; i < steps; i++ {
+		p.X += p.VX / float64(steps)
+		p.Y += p.VY / float64(steps)
+
+		gridX, gridY := int(math.Floor(p.X/BlockSize)), int(math.Floor(p.Y/BlockSize))
+		if p.World != nil && IsSolidBlockAt(p.World, gridX, gridY) {
+			p.Stuck = true
+			p.StuckX, p.StuckY = gridX, gridY
+			p.VX, p.VY = 0, 0
+			p.Lifetime = ProjectileLifetime
+			return
+		}
+
+		for _, target := range targets {
+			if !target.Hitbox().Contains(p.X, p.Y) {
+				continue
+			}
+			if hit, dealt := hitTarget(target, p.Damage, sign(p.VX)*p.Knockback, -p.Knockback*KnockbackLift); hit {
+				p.Dead, p.Landed, p.Dealt = true, true, dealt
+				return
+			}
+		}
+	}
+}
+
+// Tick 更新一帧（实现Entity）：敌对投射物只检测玩家，己方投射物只检测本帧飞行范围内的目标，
+// 命中时玩家获得生命偷取
+func (p *Projectile) Tick(world EntityWorld) {
+	if p.Hostile {
+		p.Update([]Damageable{world.GetPlayer()})
+		return
+	}
+	p.Update(world.DamageablesIn(p.sweep()))
+	if p.Landed {
+		world.GetPlayer().StealLife(p.Dealt)
+	}
+}
+
+// sweep 获取投射物本帧可能飞过的范围，向外留出一格余量容纳重力的影响
+func (p *Projectile) sweep() Hitbox {
+	return Hitbox{
+		Left:   math.Min(p.X, p.X+p.VX) - BlockSize,
+		Top:    math.Min(p.Y, p.Y+p.VY) - BlockSize,
+		Right:  math.Max(p.X, p.X+p.VX) + BlockSize,
+		Bottom: math.Max(p.Y, p.Y+p.VY) + BlockSize,
+	}
+}
+
+// Bounds 投射物按头部所在的点参与空间查询（实现Entity）
+func (p *Projectile) Bounds() Hitbox {
+	return NewHitbox(p.X, p.Y, 0, 0)
+}
+
+// IsRemoved 命中目标或过期的投射物从世界中移除（实现Entity）
+func (p *Projectile) IsRemoved() bool {
+	return p.Dead
+}
+
+// DrawLayer 获取投射物的绘制层（实现Entity）
+func (p *Projectile) DrawLayer() DrawLayer {
+	return LayerProjectiles
+}
+
+// CanPickup 检查玩家是否可以回收投射物：只有插在方块上的己方投射物可以回收
+func (p *Projectile) CanPickup(player *Player) bool {
+	if !p.Stuck || p.Dead || p.Hostile || player == nil || player.Dead {
+		return false
+	}
+	playerX, playerY := player.GetPosition()
+	return math.Abs(p.X-playerX) <= PlayerSize/2+ProjectilePickupRange &&
+		math.Abs(p.Y-playerY) <= PlayerSize/2+ProjectilePickupRange
+}
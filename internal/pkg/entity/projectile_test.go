@@ -0,0 +1,102 @@
+package entity
+
+import "testing"
+
+func TestProjectileFallsUnderGravity(t *testing.T) {
+	projectile := NewProjectile(ProjectileArrow, 100, 100, 10, 0, 4, 3)
+	projectile.SetWorld(NewMockWorld())
+	for i := 0; i < 10; i++ {
+		projectile.Update(nil)
+	}
+	if projectile.VY <= 0 || projectile.Y <= 100 || projectile.Angle <= 0 {
+		t.Errorf("Expected the arrow to curve downwards, vy %v y %v angle %v", projectile.VY, projectile.Y, projectile.Angle)
+	}
+}
+
+func TestProjectileSticksAndFallsWhenBlockRemoved(t *testing.T) {
+	world := &behaviorWorld{MockWorld: NewMockWorld()}
+	world.AddBlockWithType(10, 3, StoneBlock)
+	world.AddBlockWithType(10, 8, StoneBlock)
+	projectile := NewProjectile(ProjectileArrow, 5*BlockSize, 3.5*BlockSize, 12, 0, 4, 3)
+	projectile.SetWorld(world)
+
+	for i := 0; i < 30 && !projectile.Stuck; i++ {
+		projectile.Update(nil)
+	}
+	if !projectile.Stuck || projectile.StuckX != 10 || projectile.StuckY != 3 {
+		t.Fatalf("Expected the arrow to stick into the wall, stuck %v at (%d, %d)", projectile.Stuck, projectile.StuckX, projectile.StuckY)
+	}
+	x, y := projectile.GetPosition()
+	projectile.Update(nil)
+	if px, py := projectile.GetPosition(); px != x || py != y {
+		t.Error("Expected a stuck arrow to stay in place")
+	}
+
+	world.RemoveBlock(10, 3)
+	for i := 0; i < 60 && projectile.StuckY != 8; i++ {
+		projectile.Update(nil)
+	}
+	if !projectile.Stuck || projectile.StuckY != 8 {
+		t.Error("Expected the arrow to fall and stick into the block below")
+	}
+}
+
+func TestProjectileHitsMob(t *testing.T) {
+	mob := NewMob(MobCaveCrawler, 8*BlockSize, 3*BlockSize)
+	projectile := NewProjectile(ProjectileKnife, 6*BlockSize, 3*BlockSize, 10, 0, 3, 2)
+	projectile.SetWorld(NewMockWorld())
+
+	for i := 0; i < 20 && !projectile.Dead; i++ {
+		projectile.Update([]Damageable{mob})
+	}
+	if !projectile.Dead {
+		t.Fatal("Expected the knife to be used up on hit")
+	}
+	if mob.Health != mob.MaxHealth-3 || mob.VX != 2 {
+		t.Errorf("Expected the mob to take damage and knockback, health %d vx %v", mob.Health, mob.VX)
+	}
+	if !projectile.Landed || projectile.Dealt != 3 {
+		t.Errorf("Expected the hit to record the damage dealt, got %d", projectile.Dealt)
+	}
+}
+
+func TestProjectilePickup(t *testing.T) {
+	player := NewPlayer(100, 100)
+	projectile := NewProjectile(ProjectileArrow, 110, 100, 0, 0, 4, 3)
+	if projectile.CanPickup(player) {
+		t.Error("Expected a flying arrow not to be picked up")
+	}
+	projectile.Stuck = true
+	if !projectile.CanPickup(player) {
+		t.Error("Expected a stuck arrow next to the player to be picked up")
+	}
+	projectile.X = 300
+	if projectile.CanPickup(player) {
+		t.Error("Expected a distant arrow not to be picked up")
+	}
+	if itemType, ok := projectile.Props().ItemType(); !ok || GetItemName(itemType) != "arrow" {
+		t.Error("Expected arrows to be recovered as arrow items")
+	}
+}
+
+func TestHostileProjectileHitsPlayer(t *testing.T) {
+	player := NewPlayer(8*BlockSize, 3*BlockSize)
+	projectile := NewProjectile(ProjectileArrow, 6*BlockSize, 3*BlockSize, 10, 0, 3, 4)
+	projectile.Hostile = true
+	projectile.SetWorld(NewMockWorld())
+
+	for i := 0; i < 10 && !projectile.Dead; i++ {
+		projectile.Update([]Damageable{player})
+	}
+	if !projectile.Dead || player.Health != player.MaxHealth-3 || player.LastDamageSource != DamageAttack {
+		t.Errorf("Expected the hostile arrow to hit the player, health %d", player.Health)
+	}
+	if player.VX <= 0 {
+		t.Error("Expected the player to be knocked back")
+	}
+
+	stuck := &Projectile{Stuck: true, Hostile: true, X: player.X, Y: player.Y}
+	if stuck.CanPickup(player) {
+		t.Error("Expected hostile projectiles not to be recoverable")
+	}
+}
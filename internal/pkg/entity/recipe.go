@@ -0,0 +1,207 @@
+package entity
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Recipe 合成配方
+// 有序配方（Shaped）要求材料按Pattern的形状摆放（允许水平镜像），
+// 无序配方只要求网格中恰好有Ingredients中的材料
+type Recipe struct {
+	Name        string
+	Shaped      bool
+	Pattern     [][]ItemType // 有序配方的形状，Air表示空格
+	Ingredients []ItemType   // 无序配方的材料
+	Result      ItemStack
+}
+
+// Width 返回配方占用的宽度
+func (r *Recipe) Width() int {
+	if !r.Shaped {
+		return 0
+	}
+	width := 0
+	for _, row := range r.Pattern {
+		if len(row) > width {
+			width = len(row)
+		}
+	}
+	return width
+}
+
+// Height 返回配方占用的高度
+func (r *Recipe) Height() int {
+	if !r.Shaped {
+		return 0
+	}
+	return len(r.Pattern)
+}
+
+// FitsGrid 检查配方能否在指定大小的合成网格中合成
+func (r *Recipe) FitsGrid(size int) bool {
+	if r.Shaped {
+		return r.Width() <= size && r.Height() <= size
+	}
+	return len(r.Ingredients) <= size*size
+}
+
+// IngredientCounts 统计配方需要的每种材料的数量
+func (r *Recipe) IngredientCounts() map[ItemType]int {
+	counts := make(map[ItemType]int)
+	if r.Shaped {
+		for _, row := range r.Pattern {
+			for _, itemType := range row {
+				if itemType != Air {
+					counts[itemType]++
+				}
+			}
+		}
+		return counts
+	}
+	for _, itemType := range r.Ingredients {
+		counts[itemType]++
+	}
+	return counts
+}
+
+// RecipeRegistry 配方注册表
+type RecipeRegistry struct {
+	recipes []*Recipe
+}
+
+// NewRecipeRegistry 创建空的配方注册表
+func NewRecipeRegistry() *RecipeRegistry {
+	return &RecipeRegistry{recipes: make([]*Recipe, 0)}
+}
+
+// Register 注册一个配方
+func (r *RecipeRegistry) Register(recipe *Recipe) {
+	r.recipes = append(r.recipes, recipe)
+}
+
+// All 返回所有配方
+func (r *RecipeRegistry) All() []*Recipe {
+	return r.recipes
+}
+
+// Match 查找与合成网格当前摆放相匹配的配方，没有时返回nil
+func (r *RecipeRegistry) Match(grid *CraftingGrid) *Recipe {
+	for _, recipe := range r.recipes {
+		if recipe.FitsGrid(grid.Size) && grid.matches(recipe) {
+			return recipe
+		}
+	}
+	return nil
+}
+
+// Search 按配方名称或产物名称搜索配方（不区分大小写），空字符串返回全部
+func (r *RecipeRegistry) Search(query string) []*Recipe {
+	query = strings.ToLower(strings.TrimSpace(query))
+	results := make([]*Recipe, 0)
+	for _, recipe := range r.recipes {
+		if query == "" ||
+			strings.Contains(strings.ToLower(recipe.Name), query) ||
+			strings.Contains(GetItemName(recipe.Result.Type), query) {
+			results = append(results, recipe)
+		}
+	}
+	return results
+}
+
+// recipeData 配方数据文件中的一条配方
+type recipeData struct {
+	Name        string            `json:"name"`
+	Type        string            `json:"type"` // "shaped" 或 "shapeless"
+	Pattern     []string          `json:"pattern"`
+	Key         map[string]string `json:"key"`
+	Ingredients []string          `json:"ingredients"`
+	Result      struct {
+		Item  string `json:"item"`
+		Count int    `json:"count"`
+	} `json:"result"`
+}
+
+// LoadRecipes 从JSON数据文件加载配方
+func LoadRecipes(path string) (*RecipeRegistry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRecipes(data)
+}
+
+// ParseRecipes 解析JSON格式的配方数据
+func ParseRecipes(data []byte) (*RecipeRegistry, error) {
+	var file struct {
+		Recipes []recipeData `json:"recipes"`
+	}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, err
+	}
+
+	registry := NewRecipeRegistry()
+	for _, entry := range file.Recipes {
+		recipe, err := entry.toRecipe()
+		if err != nil {
+			return nil, fmt.Errorf("recipe %q: %w", entry.Name, err)
+		}
+		registry.Register(recipe)
+	}
+	return registry, nil
+}
+
+// toRecipe 将数据文件中的配方转换为Recipe
+func (d *recipeData) toRecipe() (*Recipe, error) {
+	resultType, ok := ParseItemName(d.Result.Item)
+	if !ok || resultType == Air {
+		return nil, fmt.Errorf("unknown result item %q", d.Result.Item)
+	}
+	count := d.Result.Count
+	if count <= 0 {
+		count = 1
+	}
+	recipe := &Recipe{
+		Name:   d.Name,
+		Result: ItemStack{Type: resultType, Count: count},
+	}
+
+	switch d.Type {
+	case "shaped":
+		if len(d.Pattern) == 0 {
+			return nil, fmt.Errorf("empty pattern")
+		}
+		recipe.Shaped = true
+		for _, line := range d.Pattern {
+			row := make([]ItemType, 0, len(line))
+			for _, symbol := range line {
+				if symbol == ' ' {
+					row = append(row, Air)
+					continue
+				}
+				itemType, ok := ParseItemName(d.Key[string(symbol)])
+				if !ok {
+					return nil, fmt.Errorf("unknown key %q", string(symbol))
+				}
+				row = append(row, itemType)
+			}
+			recipe.Pattern = append(recipe.Pattern, row)
+		}
+	case "shapeless":
+		if len(d.Ingredients) == 0 {
+			return nil, fmt.Errorf("no ingredients")
+		}
+		for _, name := range d.Ingredients {
+			itemType, ok := ParseItemName(name)
+			if !ok || itemType == Air {
+				return nil, fmt.Errorf("unknown ingredient %q", name)
+			}
+			recipe.Ingredients = append(recipe.Ingredients, itemType)
+		}
+	default:
+		return nil, fmt.Errorf("unknown recipe type %q", d.Type)
+	}
+	return recipe, nil
+}
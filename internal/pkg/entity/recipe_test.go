@@ -0,0 +1,79 @@
+package entity
+
+import "testing"
+
+const testRecipes = `{
+  "recipes": [
+    {"name": "planks", "type": "shapeless", "ingredients": ["wood"], "result": {"item": "planks", "count": 4}},
+    {"name": "stick", "type": "shaped", "pattern": ["P", "P"], "key": {"P": "planks"}, "result": {"item": "stick", "count": 4}},
+    {"name": "wooden_axe", "type": "shaped", "pattern": ["PP", "PS", " S"], "key": {"P": "planks", "S": "stick"}, "result": {"item": "wooden_axe"}}
+  ]
+}`
+
+func TestParseRecipes(t *testing.T) {
+	registry, err := ParseRecipes([]byte(testRecipes))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(registry.All()) != 3 {
+		t.Fatalf("Expected 3 recipes, got %d", len(registry.All()))
+	}
+
+	axe := registry.All()[2]
+	if !axe.Shaped || axe.Width() != 2 || axe.Height() != 3 {
+		t.Errorf("Unexpected axe recipe shape %dx%d", axe.Width(), axe.Height())
+	}
+	if axe.Result.Count != 1 {
+		t.Errorf("Expected default result count 1, got %d", axe.Result.Count)
+	}
+	if axe.FitsGrid(InventoryCraftingSize) || !axe.FitsGrid(TableCraftingSize) {
+		t.Error("Expected axe recipe to need a crafting table")
+	}
+	counts := axe.IngredientCounts()
+	if counts[Planks] != 3 || counts[Stick] != 2 {
+		t.Errorf("Unexpected ingredient counts %v", counts)
+	}
+}
+
+func TestParseRecipesErrors(t *testing.T) {
+	bad := []string{
+		`{"recipes": [{"name": "x", "type": "shapeless", "ingredients": ["unobtainium"], "result": {"item": "stone"}}]}`,
+		`{"recipes": [{"name": "x", "type": "shaped", "pattern": ["AB"], "key": {"A": "stone"}, "result": {"item": "stone"}}]}`,
+		`{"recipes": [{"name": "x", "type": "spiral", "result": {"item": "stone"}}]}`,
+		`{"recipes": [{"name": "x", "type": "shapeless", "ingredients": ["stone"], "result": {"item": "nothing"}}]}`,
+		`not json`,
+	}
+	for _, data := range bad {
+		if _, err := ParseRecipes([]byte(data)); err == nil {
+			t.Errorf("Expected error for %s", data)
+		}
+	}
+}
+
+func TestLoadRecipesDataFile(t *testing.T) {
+	registry, err := LoadRecipes("../../../data/recipes.json")
+	if err != nil {
+		t.Fatalf("Failed to load recipe data file: %v", err)
+	}
+
+	ladders := registry.Search("ladder")
+	if len(ladders) == 0 || ladders[0].Result.Type != Ladder {
+		t.Error("Expected a ladder recipe in the data file")
+	}
+	if len(registry.Search("")) != len(registry.All()) {
+		t.Error("Expected empty search to return all recipes")
+	}
+	if len(registry.Search("PICKAXE")) < 2 {
+		t.Error("Expected case-insensitive search to find pickaxe recipes")
+	}
+}
+
+func TestItemNames(t *testing.T) {
+	for itemType := Air; itemType <= Bomb; itemType++ {
+		name := GetItemName(itemType)
+		parsed, ok := ParseItemName(name)
+		if !ok || parsed != itemType {
+			t.Errorf("Item %d name %q does not round-trip", itemType, name)
+		}
+	}
+}
@@ -13,13 +13,93 @@ const (
 	DirtBlockSprite // 合并了草方块和泥土方块
 	WoodBlockSprite
 	LeavesBlockSprite
+	LadderBlockSprite
+	VineBlockSprite
+	RopeBlockSprite
+	SpikeBlockSprite
+
+	// 物品专用精灵（没有对应的方块）
+	SwiftnessPotionSprite
+	FeatherfallPotionSprite
+	LeapingPotionSprite
+	DashPotionSprite
+	RegenerationPotionSprite
+
+	// 工具精灵
+	WoodenPickaxeSprite
+	StonePickaxeSprite
+	IronPickaxeSprite
+	WoodenAxeSprite
+	StoneAxeSprite
+	IronAxeSprite
+	WoodenShovelSprite
+	StoneShovelSprite
+	IronShovelSprite
+
+	// 合成相关的方块和物品精灵
+	PlanksBlockSprite
+	CraftingTableBlockSprite
+	StickSprite
+
+	// 存储容器精灵
+	ChestBlockSprite
+
+	// 矿石和熔炼相关的方块和物品精灵
+	IronOreBlockSprite
+	CoalOreBlockSprite
+	SmoothStoneBlockSprite
+	FurnaceBlockSprite
+	CoalSprite
+	IronIngotSprite
+
+	// 食物精灵
+	AppleSprite
+
+	// 带有使用效果的物品精灵
+	TorchBlockSprite
+	BombSprite
+
+	// 生物精灵
+	SlimeSprite
+	CaveCrawlerSprite
+	CritterSprite
+
+	// 武器和投射物精灵
+	WoodenSwordSprite
+	IronSwordSprite
+	BowSprite
+	ArrowSprite
+	ThrowingKnifeSprite
+
+	// 首领战精灵
+	ArenaBarrierBlockSprite
+	SlimeKingSprite
+	StoneGolemSprite
+	SlimeCrownSprite
+
+	// 战利品表掉落的物品精灵
+	SaplingSprite
+
+	// 天赋祭坛精灵
+	PerkShrineBlockSprite
 
 	// 物品精灵（与方块精灵相同）
-	StoneItemSprite  = StoneBlockSprite
-	DirtItemSprite   = DirtBlockSprite
-	GrassItemSprite  = DirtBlockSprite // 草物品使用泥土精灵
-	WoodItemSprite   = WoodBlockSprite
-	LeavesItemSprite = LeavesBlockSprite
+	StoneItemSprite         = StoneBlockSprite
+	DirtItemSprite          = DirtBlockSprite
+	GrassItemSprite         = DirtBlockSprite // 草物品使用泥土精灵
+	WoodItemSprite          = WoodBlockSprite
+	LeavesItemSprite        = LeavesBlockSprite
+	LadderItemSprite        = LadderBlockSprite
+	VineItemSprite          = VineBlockSprite
+	RopeItemSprite          = RopeBlockSprite
+	SpikeItemSprite         = SpikeBlockSprite
+	PlanksItemSprite        = PlanksBlockSprite
+	CraftingTableItemSprite = CraftingTableBlockSprite
+	ChestItemSprite         = ChestBlockSprite
+	IronOreItemSprite       = IronOreBlockSprite
+	SmoothStoneItemSprite   = SmoothStoneBlockSprite
+	FurnaceItemSprite       = FurnaceBlockSprite
+	TorchItemSprite         = TorchBlockSprite
 
 	// TODO: 添加更多精灵索引，如特效、UI元素等
 )
@@ -32,16 +112,66 @@ type SpriteInfo struct {
 
 // 所有精灵信息映射
 var SpriteMap = map[string]SpriteInfo{
-	"player":       {PlayerSprite, "Player"},
-	"stone_block":  {StoneBlockSprite, "Stone Block"},
-	"dirt_block":   {DirtBlockSprite, "Dirt/Grass Block"}, // 合并了草方块和泥土方块
-	"wood_block":   {WoodBlockSprite, "Wood Block"},
-	"leaves_block": {LeavesBlockSprite, "Leaves Block"},
-	"stone_item":   {StoneItemSprite, "Stone Item"},
-	"dirt_item":    {DirtItemSprite, "Dirt Item"},
-	"grass_item":   {GrassItemSprite, "Grass Item"},
-	"wood_item":    {WoodItemSprite, "Wood Item"},
-	"leaves_item":  {LeavesItemSprite, "Leaves Item"},
+	"player":              {PlayerSprite, "Player"},
+	"stone_block":         {StoneBlockSprite, "Stone Block"},
+	"dirt_block":          {DirtBlockSprite, "Dirt/Grass Block"}, // 合并了草方块和泥土方块
+	"wood_block":          {WoodBlockSprite, "Wood Block"},
+	"leaves_block":        {LeavesBlockSprite, "Leaves Block"},
+	"stone_item":          {StoneItemSprite, "Stone Item"},
+	"dirt_item":           {DirtItemSprite, "Dirt Item"},
+	"grass_item":          {GrassItemSprite, "Grass Item"},
+	"wood_item":           {WoodItemSprite, "Wood Item"},
+	"leaves_item":         {LeavesItemSprite, "Leaves Item"},
+	"ladder_block":        {LadderBlockSprite, "Ladder Block"},
+	"vine_block":          {VineBlockSprite, "Vine Block"},
+	"rope_block":          {RopeBlockSprite, "Rope Block"},
+	"ladder_item":         {LadderItemSprite, "Ladder Item"},
+	"vine_item":           {VineItemSprite, "Vine Item"},
+	"rope_item":           {RopeItemSprite, "Rope Item"},
+	"spike_block":         {SpikeBlockSprite, "Spike Block"},
+	"spike_item":          {SpikeItemSprite, "Spike Item"},
+	"swiftness_potion":    {SwiftnessPotionSprite, "Swiftness Potion"},
+	"featherfall_potion":  {FeatherfallPotionSprite, "Featherfall Potion"},
+	"leaping_potion":      {LeapingPotionSprite, "Leaping Potion"},
+	"dash_potion":         {DashPotionSprite, "Dash Potion"},
+	"regeneration_potion": {RegenerationPotionSprite, "Regeneration Potion"},
+	"wooden_pickaxe":      {WoodenPickaxeSprite, "Wooden Pickaxe"},
+	"stone_pickaxe":       {StonePickaxeSprite, "Stone Pickaxe"},
+	"iron_pickaxe":        {IronPickaxeSprite, "Iron Pickaxe"},
+	"wooden_axe":          {WoodenAxeSprite, "Wooden Axe"},
+	"stone_axe":           {StoneAxeSprite, "Stone Axe"},
+	"iron_axe":            {IronAxeSprite, "Iron Axe"},
+	"wooden_shovel":       {WoodenShovelSprite, "Wooden Shovel"},
+	"stone_shovel":        {StoneShovelSprite, "Stone Shovel"},
+	"iron_shovel":         {IronShovelSprite, "Iron Shovel"},
+	"planks_block":        {PlanksBlockSprite, "Planks Block"},
+	"crafting_table":      {CraftingTableBlockSprite, "Crafting Table"},
+	"planks_item":         {PlanksItemSprite, "Planks Item"},
+	"stick":               {StickSprite, "Stick"},
+	"chest":               {ChestBlockSprite, "Chest"},
+	"iron_ore":            {IronOreBlockSprite, "Iron Ore"},
+	"coal_ore":            {CoalOreBlockSprite, "Coal Ore"},
+	"smooth_stone":        {SmoothStoneBlockSprite, "Smooth Stone"},
+	"furnace":             {FurnaceBlockSprite, "Furnace"},
+	"coal":                {CoalSprite, "Coal"},
+	"iron_ingot":          {IronIngotSprite, "Iron Ingot"},
+	"apple":               {AppleSprite, "Apple"},
+	"torch":               {TorchBlockSprite, "Torch"},
+	"bomb":                {BombSprite, "Bomb"},
+	"slime":               {SlimeSprite, "Slime"},
+	"cave_crawler":        {CaveCrawlerSprite, "Cave Crawler"},
+	"critter":             {CritterSprite, "Critter"},
+	"wooden_sword":        {WoodenSwordSprite, "Wooden Sword"},
+	"iron_sword":          {IronSwordSprite, "Iron Sword"},
+	"bow":                 {BowSprite, "Bow"},
+	"arrow":               {ArrowSprite, "Arrow"},
+	"throwing_knife":      {ThrowingKnifeSprite, "Throwing Knife"},
+	"arena_barrier":       {ArenaBarrierBlockSprite, "Arena Barrier"},
+	"slime_king":          {SlimeKingSprite, "Slime King"},
+	"stone_golem":         {StoneGolemSprite, "Stone Golem"},
+	"slime_crown":         {SlimeCrownSprite, "Slime Crown"},
+	"sapling":             {SaplingSprite, "Sapling"},
+	"perk_shrine":         {PerkShrineBlockSprite, "Perk Shrine"},
 }
 
 // GetSpriteIndex 根据名称获取精灵索引
@@ -66,6 +196,96 @@ func GetSpriteName(index int) string {
 		return "Wood Block"
 	case LeavesBlockSprite:
 		return "Leaves Block"
+	case LadderBlockSprite:
+		return "Ladder Block"
+	case VineBlockSprite:
+		return "Vine Block"
+	case RopeBlockSprite:
+		return "Rope Block"
+	case SpikeBlockSprite:
+		return "Spike Block"
+	case SwiftnessPotionSprite:
+		return "Swiftness Potion"
+	case FeatherfallPotionSprite:
+		return "Featherfall Potion"
+	case LeapingPotionSprite:
+		return "Leaping Potion"
+	case DashPotionSprite:
+		return "Dash Potion"
+	case RegenerationPotionSprite:
+		return "Regeneration Potion"
+	case WoodenPickaxeSprite:
+		return "Wooden Pickaxe"
+	case StonePickaxeSprite:
+		return "Stone Pickaxe"
+	case IronPickaxeSprite:
+		return "Iron Pickaxe"
+	case WoodenAxeSprite:
+		return "Wooden Axe"
+	case StoneAxeSprite:
+		return "Stone Axe"
+	case IronAxeSprite:
+		return "Iron Axe"
+	case WoodenShovelSprite:
+		return "Wooden Shovel"
+	case StoneShovelSprite:
+		return "Stone Shovel"
+	case IronShovelSprite:
+		return "Iron Shovel"
+	case PlanksBlockSprite:
+		return "Planks Block"
+	case CraftingTableBlockSprite:
+		return "Crafting Table"
+	case StickSprite:
+		return "Stick"
+	case ChestBlockSprite:
+		return "Chest"
+	case IronOreBlockSprite:
+		return "Iron Ore"
+	case CoalOreBlockSprite:
+		return "Coal Ore"
+	case SmoothStoneBlockSprite:
+		return "Smooth Stone"
+	case FurnaceBlockSprite:
+		return "Furnace"
+	case CoalSprite:
+		return "Coal"
+	case IronIngotSprite:
+		return "Iron Ingot"
+	case AppleSprite:
+		return "Apple"
+	case TorchBlockSprite:
+		return "Torch"
+	case BombSprite:
+		return "Bomb"
+	case SlimeSprite:
+		return "Slime"
+	case CaveCrawlerSprite:
+		return "Cave Crawler"
+	case CritterSprite:
+		return "Critter"
+	case WoodenSwordSprite:
+		return "Wooden Sword"
+	case IronSwordSprite:
+		return "Iron Sword"
+	case BowSprite:
+		return "Bow"
+	case ArrowSprite:
+		return "Arrow"
+	case ThrowingKnifeSprite:
+		return "Throwing Knife"
+	case ArenaBarrierBlockSprite:
+		return "Arena Barrier"
+	case SlimeKingSprite:
+		return "Slime King"
+	case StoneGolemSprite:
+		return "Stone Golem"
+	case SlimeCrownSprite:
+		return "Slime Crown"
+	case SaplingSprite:
+		return "Sapling"
+	case PerkShrineBlockSprite:
+		return "Perk Shrine"
 	}
 	return "Unknown"
 }
@@ -81,21 +301,39 @@ func GetBlockSpriteIndex(blockType BlockType) int {
 		return WoodBlockSprite
 	} else if blockType == LeavesBlock {
 		return LeavesBlockSprite
+	} else if blockType == LadderBlock {
+		return LadderBlockSprite
+	} else if blockType == VineBlock {
+		return VineBlockSprite
+	} else if blockType == RopeBlock {
+		return RopeBlockSprite
+	} else if blockType == SpikeBlock {
+		return SpikeBlockSprite
+	} else if blockType == PlanksBlock {
+		return PlanksBlockSprite
+	} else if blockType == CraftingTableBlock {
+		return CraftingTableBlockSprite
+	} else if blockType == ChestBlock {
+		return ChestBlockSprite
+	} else if blockType == IronOreBlock {
+		return IronOreBlockSprite
+	} else if blockType == CoalOreBlock {
+		return CoalOreBlockSprite
+	} else if blockType == SmoothStoneBlock {
+		return SmoothStoneBlockSprite
+	} else if blockType == FurnaceBlock {
+		return FurnaceBlockSprite
+	} else if blockType == TorchBlock {
+		return TorchBlockSprite
+	} else if blockType == ArenaBarrierBlock {
+		return ArenaBarrierBlockSprite
+	} else if blockType == PerkShrineBlock {
+		return PerkShrineBlockSprite
 	}
 	return StoneBlockSprite
 }
 
-// GetItemSpriteIndex 根据物品类型获取精灵索引
+// GetItemSpriteIndex 根据物品类型获取精灵索引（来自物品注册表）
 func GetItemSpriteIndex(itemType ItemType) int {
-	// 由于Grass已合并到Dirt中，需要特殊处理
-	if itemType == Stone {
-		return StoneItemSprite
-	} else if itemType == Dirt || itemType == Grass {
-		return GrassItemSprite // 草物品使用泥土精灵
-	} else if itemType == Wood {
-		return WoodItemSprite
-	} else if itemType == Leaves {
-		return LeavesItemSprite
-	}
-	return StoneItemSprite
+	return Items.Get(itemType).Sprite
 }
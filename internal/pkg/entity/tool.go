@@ -0,0 +1,57 @@
+package entity
+
+const (
+	MaxStackSize = 64 // 物品数据没有指定时的默认最大堆叠数量
+)
+
+// ToolKind 工具种类，决定工具对哪些方块有效
+type ToolKind int
+
+const (
+	ToolNone    ToolKind = iota
+	ToolPickaxe          // 镐：石头、尖刺
+	ToolAxe              // 斧：木头、梯子
+	ToolShovel           // 铲：泥土
+)
+
+// ToolTier 工具材质等级
+type ToolTier int
+
+const (
+	TierWood ToolTier = iota
+	TierStone
+	TierIron
+)
+
+// ToolProperties 工具属性
+type ToolProperties struct {
+	Kind          ToolKind
+	Tier          ToolTier
+	Speed         float64 // 挖掘对应方块时的速度倍率
+	MaxDurability int     // 最大耐久度，每破坏一个方块消耗1点
+}
+
+// GetToolProperties 获取物品的工具属性（来自物品注册表）
+func GetToolProperties(itemType ItemType) (ToolProperties, bool) {
+	tool := Items.Get(itemType).Tool
+	if tool == nil {
+		return ToolProperties{}, false
+	}
+	return *tool, true
+}
+
+// IsTool 检查物品是否为工具
+func IsTool(itemType ItemType) bool {
+	return Items.Get(itemType).Tool != nil
+}
+
+// GetMaxStackSize 获取物品的最大堆叠数量（来自物品注册表），工具不能堆叠
+func GetMaxStackSize(itemType ItemType) int {
+	return Items.Get(itemType).MaxStack
+}
+
+// NewToolStack 创建一个满耐久的工具物品堆叠
+func NewToolStack(itemType ItemType) ItemStack {
+	props, _ := GetToolProperties(itemType)
+	return ItemStack{Type: itemType, Count: 1, Durability: props.MaxDurability}
+}
@@ -0,0 +1,71 @@
+package entity
+
+import "testing"
+
+func TestToolMiningSpeed(t *testing.T) {
+	if GetMiningSpeed(StonePickaxe, StoneBlock) <= GetMiningSpeed(WoodenPickaxe, StoneBlock) {
+		t.Error("Expected higher tier pickaxe to mine stone faster")
+	}
+	if GetMiningSpeed(WoodenPickaxe, StoneBlock) <= 1 {
+		t.Error("Expected pickaxe to mine stone faster than by hand")
+	}
+	if GetMiningSpeed(IronShovel, StoneBlock) != 1 {
+		t.Error("Expected shovel to give no bonus on stone")
+	}
+	if GetMiningSpeed(StoneAxe, WoodBlock) <= 1 || GetMiningSpeed(StoneShovel, DirtBlock) <= 1 {
+		t.Error("Expected axe and shovel to be effective on wood and dirt")
+	}
+	if GetMiningSpeed(Stone, StoneBlock) != 1 {
+		t.Error("Expected non-tool items to mine at hand speed")
+	}
+}
+
+func TestToolDurability(t *testing.T) {
+	inventory := NewInventory()
+	inventory.SetSlot(0, ItemStack{Type: StonePickaxe, Count: 1, Durability: 2})
+	inventory.SetSelectedSlot(0)
+
+	if inventory.DamageSelectedItem(1) {
+		t.Error("Expected tool to survive with durability left")
+	}
+	if inventory.GetSelectedItem().Durability != 1 {
+		t.Errorf("Expected durability 1, got %d", inventory.GetSelectedItem().Durability)
+	}
+	if !inventory.DamageSelectedItem(1) {
+		t.Error("Expected tool to break when durability runs out")
+	}
+	if inventory.GetSelectedItem().Type != Air {
+		t.Error("Expected broken tool to be removed from the slot")
+	}
+
+	// 非工具物品不受影响
+	inventory.SetSlot(0, ItemStack{Type: Stone, Count: 5})
+	if inventory.DamageSelectedItem(1) || inventory.GetSelectedItem().Count != 5 {
+		t.Error("Expected non-tool items to ignore durability damage")
+	}
+}
+
+func TestToolsDoNotStack(t *testing.T) {
+	inventory := NewInventory()
+	inventory.Clear()
+
+	inventory.AddItem(IronAxe, 2)
+	if inventory.GetSlot(0).Count != 1 || inventory.GetSlot(1).Type != IronAxe {
+		t.Error("Expected each tool to take its own slot")
+	}
+	if inventory.GetSlot(0).Durability != NewToolStack(IronAxe).Durability {
+		t.Error("Expected newly added tool to have full durability")
+	}
+
+	inventory.AddStack(ItemStack{Type: WoodenShovel, Count: 1, Durability: 7})
+	if inventory.GetSlot(2).Durability != 7 {
+		t.Errorf("Expected added tool to keep its durability, got %d", inventory.GetSlot(2).Durability)
+	}
+
+	if GetMaxStackSize(Stone) != MaxStackSize || GetMaxStackSize(IronAxe) != 1 {
+		t.Error("Unexpected max stack sizes")
+	}
+	if GetItemSpriteIndex(IronShovel) != IronShovelSprite {
+		t.Error("Expected tools to use their own sprites")
+	}
+}
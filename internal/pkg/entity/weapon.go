@@ -0,0 +1,148 @@
+package entity
+
+import "math"
+
+const (
+	KnockbackLift = 0.5 // 击退时向上的速度相对于击退速度的比例
+)
+
+// WeaponKind 武器种类
+type WeaponKind int
+
+const (
+	WeaponMelee  WeaponKind = iota // 近战：向目标方向挥砍，命中扇形范围内的生物
+	WeaponRanged                   // 远程：消耗弹药发射投射物（弓发射箭）
+	WeaponThrown                   // 投掷：把武器本身作为投射物扔出（飞刀）
+)
+
+// WeaponProperties 武器属性
+type WeaponProperties struct {
+	Kind       WeaponKind
+	Damage     int
+	Knockback  float64        // 命中时击退的水平速度
+	Range      float64        // 近战攻击距离（从玩家中心算起）
+	Arc        float64        // 近战挥砍的扇形角度（度），以玩家指向目标的方向为中心
+	Cooldown   int            // 两次攻击之间的帧数
+	Projectile ProjectileKind // 远程和投掷武器发射的投射物
+	Speed      float64        // 投射物的初速度
+}
+
+// GetWeaponProperties 获取物品的武器属性（来自物品注册表）
+func GetWeaponProperties(itemType ItemType) (WeaponProperties, bool) {
+	weapon := Items.Get(itemType).Weapon
+	if weapon == nil {
+		return WeaponProperties{}, false
+	}
+	return *weapon, true
+}
+
+// IsWeapon 检查物品是否为武器
+func IsWeapon(itemType ItemType) bool {
+	return Items.Get(itemType).Weapon != nil
+}
+
+// WeaponBehavior 武器：左键点击实体时攻击，远程和投掷武器也可以右键朝目标发射
+// 冷却中的攻击被忽略，但仍然算作已使用，避免点击生物时转为挖掘它身后的方块
+type WeaponBehavior struct{ NoBehavior }
+
+func (WeaponBehavior) Attack(ctx *ItemUseContext, target Target) bool {
+	weapon, ok := GetWeaponProperties(ctx.Stack.Type)
+	if !ok {
+		return false
+	}
+	if ctx.Player.AttackTimer > 0 {
+		return true
+	}
+	targetX, targetY := target.GetPosition()
+	if weapon.Kind == WeaponMelee {
+		MeleeAttack(ctx.World, ctx.Player, weapon, targetX, targetY)
+		ctx.Player.AttackTimer = weapon.Cooldown
+		return true
+	}
+	return fireAt(ctx, targetX, targetY)
+}
+
+func (WeaponBehavior) UseInAir(ctx *ItemUseContext) bool {
+	return fireAt(ctx, (float64(ctx.GridX)+0.5)*BlockSize, (float64(ctx.GridY)+0.5)*BlockSize)
+}
+
+func (b WeaponBehavior) UseOnBlock(ctx *ItemUseContext, _ *Block) bool {
+	return b.UseInAir(ctx)
+}
+
+func (WeaponBehavior) UseOnEntity(ctx *ItemUseContext, target Target) bool {
+	targetX, targetY := target.GetPosition()
+	return fireAt(ctx, targetX, targetY)
+}
+
+// fireAt 用远程或投掷武器朝目标点发射投射物，近战武器不会发射，冷却中的武器忽略本次使用
+func fireAt(ctx *ItemUseContext, targetX, targetY float64) bool {
+	weapon, ok := GetWeaponProperties(ctx.Stack.Type)
+	if !ok || weapon.Kind == WeaponMelee {
+		return false
+	}
+	if ctx.Player.AttackTimer > 0 {
+		return true
+	}
+
+	// 弓消耗物品栏中的弹药，飞刀消耗自身
+	if weapon.Kind == WeaponRanged {
+		ammo, ok := GetProjectileProperties(weapon.Projectile).ItemType()
+		if !ok || ctx.Player.Inventory.RemoveItem(ammo, 1) == 0 {
+			return false
+		}
+	} else {
+		ctx.Consume()
+	}
+
+	playerX, playerY := ctx.Player.GetPosition()
+	dx, dy := targetX-playerX, targetY-playerY
+	distance := math.Hypot(dx, dy)
+	if distance == 0 {
+		dx, distance = float64(ctx.Player.Facing), 1
+	}
+	vx, vy := dx/distance*weapon.Speed, dy/distance*weapon.Speed
+	ctx.World.AddProjectile(NewProjectile(weapon.Projectile, playerX, playerY, vx, vy, weapon.Damage, weapon.Knockback))
+	ctx.Player.AttackTimer = weapon.Cooldown
+	return true
+}
+
+// MeleeAttack 以玩家为中心朝目标点挥砍：距离不超过攻击距离、方向在扇形角度内的目标都会被命中
+// 返回命中的目标数量
+func MeleeAttack(world ItemWorld, player *Player, weapon WeaponProperties, aimX, aimY float64) int {
+	playerX, playerY := player.GetPosition()
+	aim := math.Atan2(aimY-playerY, aimX-playerX)
+	halfArc := weapon.Arc / 2 * math.Pi / 180
+
+	hits := 0
+	for _, target := range world.Damageables() {
+		targetX, targetY := target.GetPosition()
+		dx, dy := targetX-playerX, targetY-playerY
+		// 攻击距离算到目标碰撞箱的边缘
+		reach := weapon.Range + math.Max(target.Hitbox().Size())/2
+		if dx*dx+dy*dy > reach*reach {
+			continue
+		}
+		if angleBetween(aim, math.Atan2(dy, dx)) > halfArc {
+			continue
+		}
+		direction := sign(dx)
+		if direction == 0 {
+			direction = float64(player.Facing)
+		}
+		if hit, dealt := hitTarget(target, weapon.Damage, direction*weapon.Knockback, -weapon.Knockback*KnockbackLift); hit {
+			player.StealLife(dealt)
+			hits++
+		}
+	}
+	return hits
+}
+
+// angleBetween 两个角度（弧度）之间的夹角，范围0到π
+func angleBetween(a, b float64) float64 {
+	diff := math.Mod(math.Abs(a-b), 2*math.Pi)
+	if diff > math.Pi {
+		diff = 2*math.Pi - diff
+	}
+	return diff
+}
@@ -0,0 +1,162 @@
+package entity
+
+import (
+	"reflect"
+	"testing"
+)
+
+// lookupItem 按名称查找数据文件中定义的物品
+func lookupItem(t *testing.T, name string) ItemType {
+	t.Helper()
+	itemType, ok := ParseItemName(name)
+	if !ok {
+		t.Fatalf("Expected item %q to be defined", name)
+	}
+	return itemType
+}
+
+func TestWeaponProperties(t *testing.T) {
+	sword := lookupItem(t, "wooden_sword")
+	weapon, ok := GetWeaponProperties(sword)
+	if !ok || weapon.Kind != WeaponMelee || weapon.Damage <= 0 || weapon.Range <= 0 || weapon.Arc <= 0 {
+		t.Errorf("Unexpected sword properties: %+v", weapon)
+	}
+	if GetMaxStackSize(sword) != 1 {
+		t.Error("Expected swords not to stack")
+	}
+
+	bow, _ := GetWeaponProperties(lookupItem(t, "bow"))
+	if bow.Kind != WeaponRanged || bow.Projectile != ProjectileArrow {
+		t.Errorf("Expected the bow to fire arrows, got %+v", bow)
+	}
+	knife := lookupItem(t, "throwing_knife")
+	if weapon, _ := GetWeaponProperties(knife); weapon.Kind != WeaponThrown || weapon.Projectile != ProjectileKnife {
+		t.Errorf("Expected throwing knives to be thrown, got %+v", weapon)
+	}
+	if GetMaxStackSize(knife) <= 1 {
+		t.Error("Expected throwing knives to stack")
+	}
+
+	if IsWeapon(Stick) {
+		t.Error("Expected a stick not to be a weapon")
+	}
+	for _, name := range []string{"wooden_sword", "iron_sword", "bow", "throwing_knife"} {
+		if behavior := GetItemBehavior(lookupItem(t, name)); reflect.TypeOf(behavior) != reflect.TypeOf(WeaponBehavior{}) {
+			t.Errorf("Expected %s to use WeaponBehavior, got %T", name, behavior)
+		}
+	}
+}
+
+func TestMeleeAttackArc(t *testing.T) {
+	player := NewPlayer(10*BlockSize, 5*BlockSize)
+	weapon := WeaponProperties{Kind: WeaponMelee, Damage: 2, Knockback: 5, Range: 48, Arc: 90}
+	front := NewMob(MobCaveCrawler, player.X+40, player.Y)
+	behind := NewMob(MobCaveCrawler, player.X-40, player.Y)
+	far := NewMob(MobCaveCrawler, player.X+120, player.Y)
+	world := &behaviorWorld{MockWorld: NewMockWorld(), targets: []Damageable{front, behind, far}}
+
+	if hits := MeleeAttack(world, player, weapon, front.X, front.Y); hits != 1 {
+		t.Fatalf("Expected exactly one mob to be hit, got %d", hits)
+	}
+	if front.Health != front.MaxHealth-2 || behind.Health != behind.MaxHealth || far.Health != far.MaxHealth {
+		t.Error("Expected only the mob in front and in range to take damage")
+	}
+	if front.VX != 5 || front.VY >= 0 || front.KnockbackTimer != MobKnockbackDuration {
+		t.Errorf("Expected the mob to be knocked away and up, velocity (%v, %v)", front.VX, front.VY)
+	}
+
+	// 受伤闪烁期间无敌
+	if hits := MeleeAttack(world, player, weapon, front.X, front.Y); hits != 0 {
+		t.Error("Expected invulnerability frames to block a second hit")
+	}
+}
+
+func TestMeleeLifeStealUsesDealtDamage(t *testing.T) {
+	player := NewPlayer(10*BlockSize, 5*BlockSize)
+	player.AddPerk("vampirism")
+	player.Health = 10
+	weapon := WeaponProperties{Kind: WeaponMelee, Damage: 50, Range: 48, Arc: 90}
+
+	// 训练假人不会失去生命值，不能用来回复生命
+	dummy := NewTrainingDummy(player.X+40, player.Y)
+	world := &behaviorWorld{MockWorld: NewMockWorld(), targets: []Damageable{dummy}}
+	if hits := MeleeAttack(world, player, weapon, dummy.X, dummy.Y); hits != 1 || player.Health != 10 {
+		t.Fatalf("Expected hitting the dummy not to heal, got %d hits and %d health", hits, player.Health)
+	}
+
+	// 按生物实际失去的生命值回复，而不是武器的伤害
+	mob := NewMob(MobCaveCrawler, player.X+40, player.Y)
+	mob.Health = 10
+	world.targets = []Damageable{mob}
+	if hits := MeleeAttack(world, player, weapon, mob.X, mob.Y); hits != 1 || !mob.Dead {
+		t.Fatal("Expected the mob to be killed")
+	}
+	if player.Health != 12 {
+		t.Errorf("Expected to heal 20%% of the 10 damage dealt, got %d health", player.Health)
+	}
+}
+
+func TestMeleeWeaponCooldown(t *testing.T) {
+	sword := lookupItem(t, "wooden_sword")
+	ctx, world := newBehaviorContext(ItemStack{Type: sword, Count: 1}, 0, 0)
+	mob := NewMob(MobCaveCrawler, ctx.Player.X+32, ctx.Player.Y)
+	world.targets = []Damageable{mob}
+	behavior := GetItemBehavior(sword)
+
+	if !behavior.Attack(ctx, mob) || mob.Health == mob.MaxHealth {
+		t.Fatal("Expected the sword to hit the mob")
+	}
+	weapon, _ := GetWeaponProperties(sword)
+	if ctx.Player.AttackTimer != weapon.Cooldown {
+		t.Errorf("Expected attack cooldown %d, got %d", weapon.Cooldown, ctx.Player.AttackTimer)
+	}
+
+	// 冷却中的攻击被忽略
+	mob.HurtTimer = 0
+	health := mob.Health
+	if !behavior.Attack(ctx, mob) || mob.Health != health {
+		t.Error("Expected the click to be swallowed without damage during cooldown")
+	}
+	// 近战武器不能发射
+	ctx.Player.AttackTimer = 0
+	if behavior.UseInAir(ctx) {
+		t.Error("Expected a melee weapon not to fire")
+	}
+}
+
+func TestBowConsumesArrows(t *testing.T) {
+	bow, arrow := lookupItem(t, "bow"), lookupItem(t, "arrow")
+	ctx, world := newBehaviorContext(ItemStack{Type: bow, Count: 1}, 20, 0)
+	behavior := GetItemBehavior(bow)
+
+	if behavior.UseInAir(ctx) || len(world.projectiles) != 0 {
+		t.Fatal("Expected the bow not to fire without arrows")
+	}
+
+	ctx.Player.Inventory.AddItem(arrow, 2)
+	if !behavior.UseInAir(ctx) || len(world.projectiles) != 1 {
+		t.Fatal("Expected the bow to fire an arrow")
+	}
+	if ctx.Player.Inventory.CountItem(arrow) != 1 || ctx.Stack.Count != 1 {
+		t.Error("Expected one arrow to be consumed and the bow to remain")
+	}
+	projectile := world.projectiles[0]
+	if projectile.Kind != ProjectileArrow || projectile.VX <= 0 {
+		t.Errorf("Expected an arrow flying towards the target, got %+v", projectile)
+	}
+}
+
+func TestThrowingKnifeConsumesItself(t *testing.T) {
+	knife := lookupItem(t, "throwing_knife")
+	ctx, world := newBehaviorContext(ItemStack{Type: knife, Count: 1}, -20, 0)
+
+	if !GetItemBehavior(knife).UseInAir(ctx) || len(world.projectiles) != 1 {
+		t.Fatal("Expected the knife to be thrown")
+	}
+	if ctx.Stack.Type != Air {
+		t.Error("Expected the thrown knife to leave the slot")
+	}
+	if world.projectiles[0].Kind != ProjectileKnife || world.projectiles[0].VX >= 0 {
+		t.Error("Expected a knife flying towards the target")
+	}
+}
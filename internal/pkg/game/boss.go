@@ -0,0 +1,124 @@
+package game
+
+import (
+	"fmt"
+	"image/color"
+
+	"mygo/internal/pkg/entity"
+	"mygo/internal/pkg/world"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+)
+
+const (
+	dungeonBoss   = "stone_golem" // 地牢竞技场中的首领
+	dungeonWidth  = 20            // 地牢竞技场内部的宽度（格）
+	dungeonHeight = 8             // 地牢竞技场内部的高度（格）
+	dungeonFloor  = 42            // 地牢竞技场最下面一行内部格子的深度
+	dungeonTunnel = 6             // 地牢两侧门外挖出的通道长度（格）
+	bossBarWidth  = 400           // 屏幕上方首领血条的宽度（像素）
+)
+
+// generateDungeon 在深处生成一个平滑石头围成的地牢竞技场，两侧各有一扇门和一段通道，
+// 位置由种子决定并远离出生点；玩家进入后与地牢首领战斗
+func (g *Game) generateDungeon(noise *PerlinNoise) {
+	if _, exists := entity.Bosses.Get(dungeonBoss); !exists {
+		return
+	}
+	left := 60 + int((noise.Noise(0.37, 11.3)+1)*50)
+	if noise.Noise(5.1, 2.7) < 0 {
+		left = -left - dungeonWidth
+	}
+	arena := &world.Arena{
+		Left:   left,
+		Top:    dungeonFloor - dungeonHeight + 1,
+		Right:  left + dungeonWidth - 1,
+		Bottom: dungeonFloor,
+		Boss:   dungeonBoss,
+	}
+
+	for y := arena.Top - 1; y <= arena.Bottom+1; y++ {
+		for x := arena.Left - 1; x <= arena.Right+1; x++ {
+			g.world.ClearBlock(x, y)
+			if !arena.Contains(x, y) {
+				g.world.AddBlockWithType(x, y, entity.SmoothStoneBlock)
+			}
+		}
+	}
+
+	// 两侧的门和通道有两格高，战斗开始时门会被屏障封住
+	for i := 0; i <= dungeonTunnel; i++ {
+		for _, x := range []int{arena.Left - 1 - i, arena.Right + 1 + i} {
+			g.world.ClearBlock(x, arena.Bottom)
+			g.world.ClearBlock(x, arena.Bottom-1)
+		}
+	}
+	g.world.AddArena(arena)
+}
+
+// handleWorldEvents 取出世界事件，计入正在进行的一局，并显示首领战的提示
+func (g *Game) handleWorldEvents() {
+	for _, event := range g.world.PollEvents() {
+		if g.run != nil {
+			g.run.Record(event)
+		}
+		name := event.Boss
+		if props, exists := entity.Bosses.Get(event.Boss); exists {
+			name = props.DisplayName
+		}
+		switch event.Kind {
+		case world.EventBossStarted:
+			g.showNotice(fmt.Sprintf("%s has awoken! The exits are sealed.", name))
+		case world.EventBossDefeated:
+			g.showNotice(fmt.Sprintf("%s has been defeated!", name))
+		case world.EventBossReset:
+			g.showNotice(fmt.Sprintf("%s waits for your return...", name))
+		}
+	}
+}
+
+// drawBoss 绘制正在战斗的首领，受伤时闪红，阶段转换时闪烁
+func (g *Game) drawBoss(screen *ebiten.Image) {
+	boss := g.world.Boss
+	if boss == nil {
+		return
+	}
+	props := boss.Props()
+	hitbox := boss.Hitbox()
+	left, top := g.camera.WorldToScreen(hitbox.Left, hitbox.Top)
+
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Scale(props.Width/32, props.Height/32)
+	if boss.Facing < 0 {
+		op.GeoM.Scale(-1, 1)
+		op.GeoM.Translate(props.Width, 0)
+	}
+	op.GeoM.Translate(left, top)
+	if boss.HurtTimer > 0 {
+		op.ColorM.Scale(1, 0.4, 0.4, 1)
+	} else if boss.TransitionTimer > 0 && boss.TransitionTimer%8 < 4 {
+		op.ColorM.Scale(1, 1, 1, 0.5)
+	}
+	g.drawSpriteWithOp(screen, op, props.Sprite)
+}
+
+// drawBossBar 在屏幕上方绘制首领的名称和血条，血条上标出进入后续阶段的生命值位置
+func (g *Game) drawBossBar(screen *ebiten.Image) {
+	boss := g.world.Boss
+	if boss == nil {
+		return
+	}
+	props := boss.Props()
+	x, y := float64(800-bossBarWidth)/2, 64.0
+
+	ebitenutil.DrawRect(screen, x-2, y-2, bossBarWidth+4, 20, color.RGBA{20, 20, 20, 220})
+	ebitenutil.DrawRect(screen, x, y, bossBarWidth, 16, color.RGBA{60, 0, 20, 255})
+	ebitenutil.DrawRect(screen, x, y, bossBarWidth*boss.HealthRatio(), 16, color.RGBA{200, 30, 60, 255})
+	for _, phase := range props.Phases[1:] {
+		ebitenutil.DrawRect(screen, x+bossBarWidth*phase.Threshold-1, y, 2, 16, color.RGBA{255, 230, 120, 255})
+	}
+
+	label := fmt.Sprintf("%s  %d/%d", props.DisplayName, boss.Health, boss.MaxHealth)
+	ebitenutil.DebugPrintAt(screen, label, int(x)+6, int(y)+1)
+}
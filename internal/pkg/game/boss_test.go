@@ -0,0 +1,58 @@
+package game
+
+import (
+	"strings"
+	"testing"
+
+	"mygo/internal/pkg/entity"
+)
+
+func TestGenerateDungeon(t *testing.T) {
+	g := newTestGame()
+	g.generateDungeon(NewPerlinNoise(7))
+
+	if len(g.world.Arenas) != 1 {
+		t.Fatalf("Expected one dungeon arena, got %d", len(g.world.Arenas))
+	}
+	arena := g.world.Arenas[0]
+	if arena.Boss != dungeonBoss || arena.Right-arena.Left+1 != dungeonWidth || arena.Bottom != dungeonFloor {
+		t.Errorf("Unexpected dungeon arena %+v", arena)
+	}
+	if arena.Left < 60 && arena.Right > -60 {
+		t.Error("Expected the dungeon to be away from the spawn point")
+	}
+	for y := arena.Top; y <= arena.Bottom; y++ {
+		for x := arena.Left; x <= arena.Right; x++ {
+			if g.world.IsBlockAt(x, y) {
+				t.Fatalf("Expected the dungeon to be hollow, found a block at (%d, %d)", x, y)
+			}
+		}
+	}
+	if block, _ := g.world.GetBlock(arena.Left-1, arena.Top); block == nil || block.GetType() != entity.SmoothStoneBlock {
+		t.Error("Expected smooth stone walls")
+	}
+	if g.world.IsBlockAt(arena.Left-1, arena.Bottom) || g.world.IsBlockAt(arena.Right+1+dungeonTunnel, arena.Bottom-1) {
+		t.Error("Expected doors and tunnels on both sides")
+	}
+}
+
+func TestBossEventsShowNotices(t *testing.T) {
+	g := newTestGame()
+	if !g.world.SummonBoss("slime_king", 0, 4) {
+		t.Fatal("Expected the slime king to be summoned")
+	}
+	g.handleWorldEvents()
+	if !strings.Contains(g.notice, "Slime King") || g.noticeTimer == 0 {
+		t.Errorf("Expected a notice about the boss, got %q", g.notice)
+	}
+
+	g.world.Boss.Hit(g.world.Boss.MaxHealth, 0, 0)
+	g.world.Update()
+	g.handleWorldEvents()
+	if !strings.Contains(g.notice, "defeated") {
+		t.Errorf("Expected a defeat notice, got %q", g.notice)
+	}
+	if len(g.world.PollEvents()) != 0 {
+		t.Error("Expected events to be consumed")
+	}
+}
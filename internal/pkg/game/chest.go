@@ -0,0 +1,89 @@
+package game
+
+import (
+	"image/color"
+	"math/rand"
+
+	"mygo/internal/pkg/entity"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+)
+
+// 箱子面板在屏幕上的位置（位于物品栏上方）
+const (
+	chestGridX = inventoryGridX
+	chestGridY = inventoryGridY - 3*40 - 30
+	// chestSlotBase 箱子槽位的编号起点，排在物品栏槽位和合成网格格子之后
+	chestSlotBase = entity.TotalSlotCount + entity.TableCraftingSize*entity.TableCraftingSize
+	// chestLootTable 洞穴箱子使用的战利品表
+	chestLootTable = "chests/cave"
+)
+
+// openChest 展开物品栏并打开箱子面板
+func (g *Game) openChest(container *entity.Container) {
+	g.player.GetInventory().OpenInventory()
+	g.crafting = nil
+	g.chest = container
+	g.furnace = nil
+	g.searchFocused = false
+}
+
+// chestSlotAt 获取屏幕坐标下的箱子槽位索引
+func (g *Game) chestSlotAt(mx, my int) (int, bool) {
+	if g.chest == nil {
+		return -1, false
+	}
+	rows := (g.chest.Size() + 8) / 9
+	if mx < chestGridX || mx >= chestGridX+9*40 || my < chestGridY || my >= chestGridY+rows*40 {
+		return -1, false
+	}
+	slot := (my-chestGridY)/40*9 + (mx-chestGridX)/40
+	if slot >= g.chest.Size() {
+		return -1, false
+	}
+	return slot, true
+}
+
+// drawChest 绘制打开的箱子面板
+func (g *Game) drawChest(screen *ebiten.Image) {
+	if g.chest == nil {
+		return
+	}
+
+	ebitenutil.DebugPrintAt(screen, "Chest (Shift+Click to transfer)", chestGridX, chestGridY-18)
+	for i, stack := range g.chest.Slots {
+		x := chestGridX + i%9*40 + 2
+		y := chestGridY + i/9*40 + 2
+		ebitenutil.DrawRect(screen, float64(x), float64(y), 36, 36, color.RGBA{70, 55, 35, 200})
+		g.drawSlotItem(screen, stack, x, y)
+	}
+}
+
+// generateChests 在较深的洞穴地面上零星生成装有战利品的箱子
+func (g *Game) generateChests(noise *PerlinNoise) {
+	chests := make([][2]int, 0)
+	for _, block := range g.generationBlocks() {
+		x, y := block.GetGridPosition()
+		if block.GetType() != entity.StoneBlock || y < 25 {
+			continue
+		}
+		// 石头上方有两格空位，即足够高的洞穴地面
+		if !g.world.IsBlockAt(x, y-1) && !g.world.IsBlockAt(x, y-2) &&
+			noise.Noise(float64(x)*0.9+0.3, float64(y)*0.9+0.3) > 0.55 {
+			chests = append(chests, [2]int{x, y - 1})
+		}
+	}
+
+	for _, pos := range chests {
+		// 每个箱子使用由种子和位置决定的随机数，保证同一种子生成相同的战利品
+		rng := rand.New(rand.NewSource(noise.seed + int64(pos[0])*73856093 + int64(pos[1])*19349663))
+		g.world.PlaceChest(pos[0], pos[1], rollChestLoot(pos[1], rng), rng)
+	}
+}
+
+// rollChestLoot 抽取箱子战利品表，较深的箱子可能装有更好的物品
+func rollChestLoot(depth int, rng *rand.Rand) []entity.ItemStack {
+	loot, _ := entity.LootTables.Roll(chestLootTable, entity.LootContext{Depth: depth, Rng: rng})
+	return loot
+}
@@ -0,0 +1,103 @@
+package game
+
+import (
+	"math/rand"
+	"testing"
+
+	"mygo/internal/pkg/entity"
+)
+
+func TestUseBlockOpensChest(t *testing.T) {
+	g := newTestGame()
+	g.player.SetPosition(3*32+16, 4*32+16)
+	g.world.PlaceChest(2, 4, []entity.ItemStack{{Type: entity.Rope, Count: 6}}, rand.New(rand.NewSource(1)))
+
+	if !g.useBlockAt(2, 4) {
+		t.Fatal("Expected chest to be opened")
+	}
+	if !g.player.GetInventory().IsOpen() || g.chest == nil || g.crafting != nil {
+		t.Fatal("Expected inventory to open with the chest panel instead of a crafting grid")
+	}
+	if g.useBlockAt(4, 4) {
+		t.Error("Expected empty cell not to be usable")
+	}
+
+	g.closeInventory()
+	if g.chest != nil {
+		t.Error("Expected chest panel to be closed with the inventory")
+	}
+}
+
+func TestDeathClosesContainerPanels(t *testing.T) {
+	g := newTestGame()
+	g.openChest(entity.NewContainer(entity.ChestSlotCount))
+	g.player.TakeDamage(g.player.MaxHealth, entity.DamageHazard)
+	g.closeOnDeath()
+	g.world.Update()
+	if g.chest != nil || g.player.GetInventory().IsOpen() {
+		t.Error("Expected death to close the chest panel")
+	}
+
+	g.openFurnace(entity.NewFurnaceEntity())
+	g.player.InvulnerableTimer = 0
+	g.player.TakeDamage(g.player.MaxHealth, entity.DamageHazard)
+	g.closeOnDeath()
+	g.world.Update()
+	if g.furnace != nil || g.player.GetInventory().IsOpen() {
+		t.Error("Expected death to close the furnace panel")
+	}
+}
+
+func TestShiftClickTransfersBetweenChestAndInventory(t *testing.T) {
+	g := newTestGame()
+	inventory := g.player.GetInventory()
+	inventory.Clear()
+	inventory.AddItem(entity.Stone, 10)
+
+	container := entity.NewContainer(entity.ChestSlotCount)
+	container.AddStack(entity.ItemStack{Type: entity.Rope, Count: 4})
+	g.openChest(container)
+
+	// 物品栏 -> 箱子
+	g.quickMove(0)
+	if inventory.CountItem(entity.Stone) != 0 || container.CountItem(entity.Stone) != 10 {
+		t.Error("Expected stone to move into the chest")
+	}
+
+	// 箱子 -> 物品栏
+	g.quickMove(chestSlotBase)
+	if container.CountItem(entity.Rope) != 0 || inventory.CountItem(entity.Rope) != 4 {
+		t.Error("Expected rope to move into the inventory")
+	}
+
+	// 屏幕坐标对应到箱子槽位
+	stack, id, ok := g.stackAt(chestGridX+45, chestGridY+5)
+	if !ok || id != chestSlotBase+1 || stack != &container.Slots[1] {
+		t.Error("Expected screen position to map to the second chest slot")
+	}
+}
+
+func TestRollChestLoot(t *testing.T) {
+	loot := rollChestLoot(30, rand.New(rand.NewSource(42)))
+	if len(loot) < 3 || len(loot) > 5 {
+		t.Fatalf("Expected 3 to 5 loot stacks, got %d", len(loot))
+	}
+	for _, stack := range loot {
+		if stack.Count <= 0 || stack.Type == entity.Air {
+			t.Errorf("Unexpected loot stack %v", stack)
+		}
+	}
+}
+
+func TestRollChestLootIsSeeded(t *testing.T) {
+	a := rollChestLoot(50, rand.New(rand.NewSource(7)))
+	b := rollChestLoot(50, rand.New(rand.NewSource(7)))
+	if len(a) != len(b) {
+		t.Fatalf("Expected the same seed to roll the same loot, got %v and %v", a, b)
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			t.Fatalf("Expected the same seed to roll the same loot, got %v and %v", a, b)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package game
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+
+	"mygo/internal/pkg/entity"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+)
+
+// trainingDummyOffset 训练假人放在出生点右侧的格数
+const trainingDummyOffset = 3
+
+// placeTrainingDummy 在出生点附近的地面上放置一个训练假人
+func (g *Game) placeTrainingDummy() {
+	x := (trainingDummyOffset + 0.5) * entity.BlockSize
+	groundTop := g.findGroundY(x) + entity.PlayerSize/2
+	g.world.AddEntity(entity.NewTrainingDummy(x, groundTop-entity.DummyHeight/2))
+}
+
+// toggleDashStrike 切换冲刺攻击模式
+func (g *Game) toggleDashStrike() {
+	g.player.DashStrike = !g.player.DashStrike
+	if g.player.DashStrike {
+		g.showNotice("Dash strike: on")
+	} else {
+		g.showNotice("Dash strike: off")
+	}
+}
+
+// drawEntities 按绘制层依次绘制世界中的实体
+func (g *Game) drawEntities(screen *ebiten.Image) {
+	for _, e := range g.world.Entities.ByLayer() {
+		switch e := e.(type) {
+		case *entity.ItemEntity:
+			// 使用掉落物自己的绘制方法（带相机支持）
+			e.DrawWithCamera(screen, g.spriteSheet, g.camera)
+		case *entity.TrainingDummy:
+			g.drawTrainingDummy(screen, e)
+		case *entity.Mob:
+			g.drawMob(screen, e)
+		case *entity.Projectile:
+			g.drawProjectile(screen, e)
+		}
+	}
+}
+
+// drawTrainingDummy 绘制训练假人（木桩和会摇晃的靶身），头顶显示累计受到的伤害
+func (g *Game) drawTrainingDummy(screen *ebiten.Image, dummy *entity.TrainingDummy) {
+	hitbox := dummy.Hitbox()
+	left, top := g.camera.WorldToScreen(hitbox.Left, hitbox.Top)
+	width, height := hitbox.Size()
+
+	ebitenutil.DrawRect(screen, left+width/2-3, top+height/2, 6, height/2, color.RGBA{110, 80, 50, 255})
+	body := color.RGBA{210, 180, 120, 255}
+	if dummy.HurtTimer > 0 {
+		body = color.RGBA{230, 90, 80, 255}
+	}
+	ebitenutil.DrawRect(screen, left+dummy.Sway, top, width, height*0.6, body)
+
+	if dummy.Hits > 0 {
+		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("%d", dummy.TotalDamage), int(left), int(top)-16)
+	}
+}
+
+// drawProjectile 绘制投射物，精灵按飞行方向旋转（精灵朝右上方画出）
+func (g *Game) drawProjectile(screen *ebiten.Image, projectile *entity.Projectile) {
+	screenX, screenY := g.camera.WorldToScreen(projectile.X, projectile.Y)
+
+	op := &ebiten.DrawImageOptions{}
+	// 以精灵中心为原点旋转，再让精灵的尖端对准投射物的位置
+	op.GeoM.Translate(-16, -16)
+	op.GeoM.Rotate(projectile.Angle + math.Pi/4)
+	op.GeoM.Scale(0.75, 0.75)
+	op.GeoM.Translate(screenX-math.Cos(projectile.Angle)*8, screenY-math.Sin(projectile.Angle)*8)
+	g.drawSpriteWithOp(screen, op, projectile.Props().Sprite)
+}
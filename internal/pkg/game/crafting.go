@@ -0,0 +1,281 @@
+package game
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"sort"
+
+	"mygo/internal/pkg/entity"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// 合成网格和配方列表在屏幕上的位置
+const (
+	craftingGridX    = inventoryGridX
+	craftingGridY    = 84
+	recipePanelX     = 590
+	recipePanelY     = 60
+	recipePanelWidth = 200
+	recipeRowHeight  = 20
+	maxRecipeRows    = 20
+)
+
+// openInventory 展开物品栏，并准备指定边长的合成网格
+func (g *Game) openInventory(craftingSize int) {
+	g.player.GetInventory().OpenInventory()
+	g.crafting = entity.NewCraftingGrid(craftingSize)
+	g.chest = nil
+	g.furnace = nil
+	g.searchFocused = false
+}
+
+// closeInventory 关闭物品栏，把合成网格和鼠标上的物品放回物品栏，放不下的丢出
+func (g *Game) closeInventory() {
+	inventory := g.player.GetInventory()
+	inventory.CloseInventory()
+	g.dragging = false
+	g.searchFocused = false
+
+	leftovers := make([]entity.ItemStack, 0)
+	if g.crafting != nil {
+		for _, stack := range g.crafting.Clear() {
+			if stack.Count = inventory.AddStack(stack); stack.Count > 0 {
+				leftovers = append(leftovers, stack)
+			}
+		}
+		g.crafting = nil
+	}
+	g.chest = nil
+	g.furnace = nil
+	if leftover := inventory.ReturnCursor(); leftover.Count > 0 {
+		leftovers = append(leftovers, leftover)
+	}
+
+	for _, stack := range leftovers {
+		targetX, targetY := g.throwTarget()
+		g.world.ThrowStack(stack, targetX, targetY)
+	}
+}
+
+// closeOnDeath 玩家死亡时关闭物品栏，合成网格和鼠标上的物品先回到物品栏，再随物品栏一起按死亡模式处理；
+// 打开的箱子和熔炉界面也一起关闭，重生后不能再远程存取
+func (g *Game) closeOnDeath() {
+	if !g.player.IsDead() {
+		return
+	}
+	g.closeInventory()
+	g.mining.Reset()
+}
+
+// interactWithBlock 右键鼠标指向的方块：工作台打开3x3合成网格，箱子和熔炉打开各自的界面，天赋祭坛提供天赋
+func (g *Game) interactWithBlock() bool {
+	mx, my := ebiten.CursorPosition()
+	worldX, worldY := g.camera.ScreenToWorld(float64(mx), float64(my))
+	return g.useBlockAt(int(math.Floor(worldX/32)), int(math.Floor(worldY/32)))
+}
+
+// useBlockAt 使用触及范围内指定网格位置的方块，返回该方块是否可以交互
+func (g *Game) useBlockAt(gridX, gridY int) bool {
+	block, exists := g.world.GetBlock(gridX, gridY)
+	if !exists || !g.world.CanReach(gridX, gridY) {
+		return false
+	}
+
+	switch {
+	case block.Type == entity.CraftingTableBlock:
+		g.openInventory(entity.TableCraftingSize)
+	case block.Container != nil:
+		g.openChest(block.Container)
+	case block.Type == entity.PerkShrineBlock:
+		g.useShrine(gridX, gridY)
+	case block.Type == entity.FurnaceBlock:
+		blockEntity, _ := g.world.GetBlockEntity(gridX, gridY)
+		furnace, ok := blockEntity.(*entity.FurnaceEntity)
+		if !ok {
+			return false
+		}
+		g.openFurnace(furnace)
+	default:
+		return false
+	}
+	return true
+}
+
+// craftingCellAt 获取屏幕坐标下的合成网格格子索引
+func (g *Game) craftingCellAt(mx, my int) (int, bool) {
+	if g.crafting == nil {
+		return -1, false
+	}
+	size := g.crafting.Size
+	if mx < craftingGridX || mx >= craftingGridX+size*40 || my < craftingGridY || my >= craftingGridY+size*40 {
+		return -1, false
+	}
+	return (my-craftingGridY)/40*size + (mx-craftingGridX)/40, true
+}
+
+// craftResultPos 获取合成产物格的左上角位置
+func (g *Game) craftResultPos() (int, int) {
+	size := g.crafting.Size
+	return craftingGridX + size*40 + 40, craftingGridY + (size-1)*20
+}
+
+// isOverCraftResult 检查屏幕坐标是否在合成产物格上
+func (g *Game) isOverCraftResult(mx, my int) bool {
+	if g.crafting == nil {
+		return false
+	}
+	x, y := g.craftResultPos()
+	return mx >= x && mx < x+40 && my >= y && my < y+40
+}
+
+// isOverSearchBox 检查屏幕坐标是否在配方搜索框上
+func isOverSearchBox(mx, my int) bool {
+	return mx >= recipePanelX && mx < recipePanelX+recipePanelWidth && my >= recipePanelY && my < recipePanelY+recipeRowHeight
+}
+
+// handleSearchInput 处理配方搜索框的文字输入，回车或ESC结束输入
+func (g *Game) handleSearchInput() {
+	g.recipeSearch += string(ebiten.AppendInputChars(nil))
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyBackspace) && len(g.recipeSearch) > 0 {
+		runes := []rune(g.recipeSearch)
+		g.recipeSearch = string(runes[:len(runes)-1])
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyEnter) || inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		g.searchFocused = false
+	}
+}
+
+// canCraftRecipe 检查配方能否用当前的合成网格大小和物品栏中的材料合成
+func (g *Game) canCraftRecipe(recipe *entity.Recipe) bool {
+	if g.crafting == nil || !recipe.FitsGrid(g.crafting.Size) {
+		return false
+	}
+	return entity.CanCraftFromInventory(g.player.GetInventory(), recipe)
+}
+
+// visibleRecipes 获取配方列表中显示的配方：匹配搜索关键字，可合成的排在前面
+func (g *Game) visibleRecipes() []*entity.Recipe {
+	recipes := g.recipes.Search(g.recipeSearch)
+	sort.SliceStable(recipes, func(i, j int) bool {
+		return g.canCraftRecipe(recipes[i]) && !g.canCraftRecipe(recipes[j])
+	})
+	if len(recipes) > maxRecipeRows {
+		recipes = recipes[:maxRecipeRows]
+	}
+	return recipes
+}
+
+// handleRecipeClick 点击配方列表中可合成的配方时直接用物品栏中的材料合成
+// 返回点击是否落在配方列表上
+func (g *Game) handleRecipeClick(mx, my int) bool {
+	top := recipePanelY + recipeRowHeight + 4
+	if g.crafting == nil || mx < recipePanelX || mx >= recipePanelX+recipePanelWidth || my < top {
+		return false
+	}
+	row := (my - top) / recipeRowHeight
+	recipes := g.visibleRecipes()
+	if row >= len(recipes) {
+		return false
+	}
+	g.craftRecipe(recipes[row])
+	return true
+}
+
+// craftRecipe 消耗物品栏中的材料合成配方，产物放入物品栏，放不下的丢出
+func (g *Game) craftRecipe(recipe *entity.Recipe) bool {
+	if !g.canCraftRecipe(recipe) {
+		return false
+	}
+
+	inventory := g.player.GetInventory()
+	result, _ := entity.CraftFromInventory(inventory, recipe)
+	if result.Count = inventory.AddStack(result); result.Count > 0 {
+		targetX, targetY := g.throwTarget()
+		g.world.ThrowStack(result, targetX, targetY)
+	}
+	return true
+}
+
+// drawSlotItem 在槽位中绘制物品的精灵、数量和耐久度
+func (g *Game) drawSlotItem(screen *ebiten.Image, item entity.ItemStack, x, y int) {
+	if item.Type == entity.Air || item.Count <= 0 {
+		return
+	}
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Scale(28.0/32.0, 28.0/32.0)
+	op.GeoM.Translate(float64(x+4), float64(y+4))
+	g.drawSpriteWithOp(screen, op, getItemToSpriteIndex(item.Type))
+
+	if item.Count > 1 {
+		countText := fmt.Sprintf("%d", item.Count)
+		ebitenutil.DebugPrintAt(screen, countText, x+25-len(countText)*3, y+20)
+	}
+	drawDurabilityBar(screen, item, x, y)
+}
+
+// drawCrafting 绘制合成网格和产物格
+func (g *Game) drawCrafting(screen *ebiten.Image) {
+	if g.crafting == nil {
+		return
+	}
+
+	title := "Crafting"
+	if g.crafting.Size == entity.TableCraftingSize {
+		title = "Crafting Table"
+	}
+	ebitenutil.DebugPrintAt(screen, title, craftingGridX, craftingGridY-18)
+
+	for i, stack := range g.crafting.Slots {
+		x := craftingGridX + i%g.crafting.Size*40 + 2
+		y := craftingGridY + i/g.crafting.Size*40 + 2
+		ebitenutil.DrawRect(screen, float64(x), float64(y), 36, 36, color.RGBA{50, 50, 50, 200})
+		g.drawSlotItem(screen, stack, x, y)
+	}
+
+	resultX, resultY := g.craftResultPos()
+	ebitenutil.DebugPrintAt(screen, "->", resultX-28, resultY+12)
+	ebitenutil.DrawRect(screen, float64(resultX+2), float64(resultY+2), 36, 36, color.RGBA{80, 70, 40, 220})
+	g.drawSlotItem(screen, g.crafting.Preview(g.recipes), resultX+2, resultY+2)
+}
+
+// drawRecipeList 绘制右侧可搜索的配方列表，可以合成的配方高亮显示
+func (g *Game) drawRecipeList(screen *ebiten.Image) {
+	if g.crafting == nil {
+		return
+	}
+
+	// 搜索框
+	boxColor := color.RGBA{30, 30, 30, 220}
+	if g.searchFocused {
+		boxColor = color.RGBA{60, 60, 90, 220}
+	}
+	ebitenutil.DrawRect(screen, recipePanelX, recipePanelY, recipePanelWidth, recipeRowHeight, boxColor)
+	searchText := "Search: " + g.recipeSearch
+	if g.searchFocused {
+		searchText += "_"
+	}
+	ebitenutil.DebugPrintAt(screen, searchText, recipePanelX+4, recipePanelY+2)
+
+	top := recipePanelY + recipeRowHeight + 4
+	for i, recipe := range g.visibleRecipes() {
+		y := top + i*recipeRowHeight
+		rowColor := color.RGBA{40, 40, 40, 180}
+		if g.canCraftRecipe(recipe) {
+			rowColor = color.RGBA{40, 100, 40, 220}
+		}
+		ebitenutil.DrawRect(screen, recipePanelX, float64(y), recipePanelWidth, recipeRowHeight-2, rowColor)
+
+		op := &ebiten.DrawImageOptions{}
+		op.GeoM.Scale(16.0/32.0, 16.0/32.0)
+		op.GeoM.Translate(recipePanelX+2, float64(y+1))
+		g.drawSpriteWithOp(screen, op, getItemToSpriteIndex(recipe.Result.Type))
+
+		label := fmt.Sprintf("%s x%d", entity.GetItemName(recipe.Result.Type), recipe.Result.Count)
+		ebitenutil.DebugPrintAt(screen, label, recipePanelX+22, y+1)
+	}
+}
@@ -0,0 +1,85 @@
+package game
+
+import (
+	"testing"
+
+	"mygo/internal/pkg/entity"
+)
+
+func TestCloseInventoryReturnsCraftingItems(t *testing.T) {
+	g := newTestGame()
+	inventory := g.player.GetInventory()
+	inventory.Clear()
+
+	g.openInventory(entity.InventoryCraftingSize)
+	g.crafting.Set(0, 0, entity.ItemStack{Type: entity.Wood, Count: 3})
+	inventory.Cursor = entity.ItemStack{Type: entity.Stone, Count: 2}
+
+	g.closeInventory()
+	if inventory.IsOpen() || g.crafting != nil {
+		t.Error("Expected inventory and crafting grid to be closed")
+	}
+	if inventory.CountItem(entity.Wood) != 3 || inventory.CountItem(entity.Stone) != 2 {
+		t.Error("Expected crafting grid and cursor items back in the inventory")
+	}
+}
+
+func TestDeathDropsCraftingGridItems(t *testing.T) {
+	g := newTestGame()
+	inventory := g.player.GetInventory()
+	inventory.Clear()
+
+	g.openInventory(entity.InventoryCraftingSize)
+	g.crafting.Set(0, 0, entity.ItemStack{Type: entity.Wood, Count: 3})
+	g.player.TakeDamage(g.player.MaxHealth, entity.DamageHazard)
+	g.closeOnDeath()
+	g.world.Update()
+
+	if inventory.IsOpen() || g.crafting != nil {
+		t.Error("Expected death to close the inventory and crafting grid")
+	}
+	dropped := 0
+	for _, item := range g.world.GetAllItems() {
+		if item.GetItemType() == entity.Wood {
+			dropped += item.Count
+		}
+	}
+	if dropped != 3 || inventory.CountItem(entity.Wood) != 0 {
+		t.Errorf("Expected crafting grid items to be dropped with the inventory, got %d dropped", dropped)
+	}
+}
+
+func TestRecipeListCraftsFromInventory(t *testing.T) {
+	g := newTestGame()
+	inventory := g.player.GetInventory()
+	inventory.Clear()
+	inventory.AddItem(entity.Planks, 4)
+
+	g.openInventory(entity.InventoryCraftingSize)
+	recipes := g.visibleRecipes()
+	if len(recipes) == 0 || !g.canCraftRecipe(recipes[0]) {
+		t.Fatal("Expected craftable recipes to be listed first")
+	}
+
+	// 2x2网格中不能合成需要3x3的配方
+	pickaxe := g.recipes.Search("wooden_pickaxe")[0]
+	inventory.AddItem(entity.Stick, 2)
+	if g.canCraftRecipe(pickaxe) {
+		t.Error("Expected pickaxe to need a crafting table")
+	}
+
+	g.recipeSearch = "crafting"
+	table := g.visibleRecipes()[0]
+	if !g.craftRecipe(table) {
+		t.Fatal("Expected to craft a crafting table")
+	}
+	if inventory.CountItem(entity.CraftingTable) != 1 || inventory.CountItem(entity.Planks) != 0 {
+		t.Error("Expected planks to be turned into a crafting table")
+	}
+
+	g.openInventory(entity.TableCraftingSize)
+	inventory.AddItem(entity.Planks, 3)
+	if !g.craftRecipe(pickaxe) || inventory.CountItem(entity.WoodenPickaxe) != 1 {
+		t.Error("Expected pickaxe to be craftable at a crafting table")
+	}
+}
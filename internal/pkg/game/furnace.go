@@ -0,0 +1,85 @@
+package game
+
+import (
+	"image/color"
+
+	"mygo/internal/pkg/entity"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+)
+
+// 熔炉面板在屏幕上的位置（与箱子面板位于同一区域）
+const (
+	furnaceX = inventoryGridX + 80
+	furnaceY = chestGridY
+	// furnaceSlotBase 熔炉槽位的编号起点，排在箱子槽位之后
+	furnaceSlotBase = chestSlotBase + entity.ChestSlotCount
+)
+
+// furnaceSlotPos 获取熔炉槽位的左上角位置：输入槽在上，燃料槽在下，产物槽在右侧
+func furnaceSlotPos(slot int) (int, int) {
+	switch slot {
+	case entity.FurnaceInput:
+		return furnaceX, furnaceY
+	case entity.FurnaceFuel:
+		return furnaceX, furnaceY + 80
+	default:
+		return furnaceX + 120, furnaceY + 40
+	}
+}
+
+// openFurnace 展开物品栏并打开熔炉面板
+func (g *Game) openFurnace(furnace *entity.FurnaceEntity) {
+	g.player.GetInventory().OpenInventory()
+	g.crafting = nil
+	g.chest = nil
+	g.furnace = furnace
+	g.searchFocused = false
+}
+
+// furnaceSlotAt 获取屏幕坐标下的熔炉槽位索引
+func (g *Game) furnaceSlotAt(mx, my int) (int, bool) {
+	if g.furnace == nil {
+		return -1, false
+	}
+	for slot := 0; slot < entity.FurnaceSlotCount; slot++ {
+		x, y := furnaceSlotPos(slot)
+		if mx >= x && mx < x+40 && my >= y && my < y+40 {
+			return slot, true
+		}
+	}
+	return -1, false
+}
+
+// isFurnaceOutput 检查槽位编号是否为熔炉的产物槽（只能取出）
+func isFurnaceOutput(id int) bool {
+	return id == furnaceSlotBase+entity.FurnaceOutput
+}
+
+// drawFurnace 绘制打开的熔炉面板：输入槽、燃料槽、火焰、烧制进度和产物槽
+func (g *Game) drawFurnace(screen *ebiten.Image) {
+	if g.furnace == nil {
+		return
+	}
+
+	ebitenutil.DebugPrintAt(screen, "Furnace", furnaceX, furnaceY-18)
+	for slot := 0; slot < entity.FurnaceSlotCount; slot++ {
+		x, y := furnaceSlotPos(slot)
+		ebitenutil.DrawRect(screen, float64(x+2), float64(y+2), 36, 36, color.RGBA{50, 50, 50, 200})
+		g.drawSlotItem(screen, g.furnace.Slots[slot], x+2, y+2)
+	}
+
+	// 火焰：剩余燃料越多火焰越高
+	flameX, flameY := furnaceX+12, furnaceY+44
+	ebitenutil.DrawRect(screen, float64(flameX), float64(flameY), 16, 32, color.RGBA{40, 40, 40, 200})
+	if g.furnace.IsBurning() {
+		height := 32 * g.furnace.BurnProgress()
+		ebitenutil.DrawRect(screen, float64(flameX), float64(flameY)+32-height, 16, height, color.RGBA{255, 140, 0, 255})
+	}
+
+	// 烧制进度箭头
+	arrowX, arrowY := furnaceX+56, furnaceY+54
+	ebitenutil.DrawRect(screen, float64(arrowX), float64(arrowY), 48, 12, color.RGBA{40, 40, 40, 200})
+	ebitenutil.DrawRect(screen, float64(arrowX), float64(arrowY), 48*g.furnace.CookProgress(), 12, color.RGBA{230, 230, 230, 255})
+}
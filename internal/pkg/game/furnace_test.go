@@ -0,0 +1,68 @@
+package game
+
+import (
+	"testing"
+
+	"mygo/internal/pkg/entity"
+)
+
+func TestUseBlockOpensFurnace(t *testing.T) {
+	g := newTestGame()
+	g.player.SetPosition(3*32+16, 4*32+16)
+	g.world.AddBlockWithType(2, 4, entity.FurnaceBlock)
+
+	if !g.useBlockAt(2, 4) || g.furnace == nil || g.crafting != nil || g.chest != nil {
+		t.Fatal("Expected furnace panel to be opened")
+	}
+
+	inventory := g.player.GetInventory()
+	inventory.Clear()
+	inventory.SetSlot(0, entity.ItemStack{Type: entity.IronOre, Count: 3})
+	inventory.SetSlot(1, entity.ItemStack{Type: entity.Coal, Count: 2})
+	g.quickMove(0)
+	g.quickMove(1)
+	if g.furnace.Slots[entity.FurnaceInput].Count != 3 || g.furnace.Slots[entity.FurnaceFuel].Count != 2 {
+		t.Fatal("Expected shift-click to fill the input and fuel slots")
+	}
+
+	// 界面关闭后熔炉继续工作
+	furnace := g.furnace
+	g.closeInventory()
+	for i := 0; i < entity.SmeltTime; i++ {
+		g.world.Update()
+	}
+	if furnace.Slots[entity.FurnaceOutput].Type != entity.IronIngot {
+		t.Error("Expected furnace to keep smelting with the UI closed")
+	}
+
+	g.openFurnace(furnace)
+	g.quickMove(furnaceSlotBase + entity.FurnaceOutput)
+	if inventory.CountItem(entity.IronIngot) != 1 {
+		t.Error("Expected shift-click to move the ingot into the inventory")
+	}
+	if !isFurnaceOutput(furnaceSlotBase+entity.FurnaceOutput) || isFurnaceOutput(furnaceSlotBase) {
+		t.Error("Expected only the output slot to be take-only")
+	}
+}
+
+func TestNonBlockItemsAreNotPlaceable(t *testing.T) {
+	g := newTestGame()
+	g.player.SetPosition(16, 4*32+16)
+	inventory := g.player.GetInventory()
+	inventory.SetSelectedSlot(0)
+
+	for _, itemType := range []entity.ItemType{entity.Stick, entity.Coal, entity.IronIngot} {
+		inventory.SetSlot(0, entity.ItemStack{Type: itemType, Count: 1})
+		if g.useItemAt(1, 4) {
+			t.Errorf("Expected %s not to be placeable", entity.GetItemName(itemType))
+		}
+	}
+
+	inventory.SetSlot(0, entity.ItemStack{Type: entity.Furnace, Count: 1})
+	if !g.useItemAt(1, 3) {
+		t.Fatal("Expected furnace to be placeable")
+	}
+	if _, ok := g.world.GetBlockEntity(1, 3); !ok {
+		t.Error("Expected placed furnace to get a block entity")
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"image"
 	"math"
 	"math/rand"
+	"sort"
 	_ "image/png"
 
 	"mygo/internal/pkg/entity"
@@ -142,6 +143,14 @@ func (g *Game) GenerateWorldTerrainWithNoise(noise *PerlinNoise) {
 		// 根据位置生成不同的生物群落
 		// 使用低频噪声确定生物群落类型
 		biomeNoise := noise.FBM(float64(x)*0.005, 300, 1.0, 1.0, 3)
+		// 记录每一列的生物群落，生物按生物群落生成
+		if biomeNoise > 0.5 {
+			g.world.SetBiome(x, world.BiomeDesert)
+		} else if biomeNoise < -0.5 {
+			g.world.SetBiome(x, world.BiomeSnow)
+		} else {
+			g.world.SetBiome(x, world.BiomePlains)
+		}
 		
 		// 生成地面层（地表和地下几层）
 		for y := groundHeight; y < groundHeight+8; y++ {
@@ -174,8 +183,11 @@ func (g *Game) GenerateWorldTerrainWithNoise(noise *PerlinNoise) {
 			if caveNoise > -0.1 {
 				// 根据深度和噪声决定方块类型
 				if y > groundHeight+25 && noise.Noise(float64(x)*0.1, float64(y)*0.1) > 0.7 {
-					// 在较深的地方生成矿石（这里简化为特殊石头）
-					g.world.AddBlockWithType(x, y, entity.StoneBlock)
+					// 在较深的地方生成铁矿石
+					g.world.AddBlockWithType(x, y, entity.IronOreBlock)
+				} else if y > groundHeight+12 && noise.Noise(float64(x)*0.13+0.5, float64(y)*0.13+0.5) > 0.6 {
+					// 较浅的地方生成煤矿石
+					g.world.AddBlockWithType(x, y, entity.CoalOreBlock)
 				} else {
 					// 生成普通石头
 					g.world.AddBlockWithType(x, y, entity.StoneBlock)
@@ -328,6 +340,19 @@ func (g *Game) GenerateWorldTerrainWithNoise(noise *PerlinNoise) {
 		}
 	}
 	
+	// 在深处生成地牢竞技场（在藤蔓等装饰之前，避免装饰依附的方块被挖空）
+	g.generateDungeon(noise)
+	
+	// 生成藤蔓
+	g.generateVines(noise)
+	
+	// 在洞穴地面生成尖刺
+	g.generateSpikes(noise)
+	
+	// 在深处的洞穴中生成装有战利品的箱子和天赋祭坛
+	g.generateChests(noise)
+	g.generateShrines(noise)
+	
 	// 确保玩家出生点附近是安全的，移除周围的方块
 	for x := -5; x <= 5; x++ {
 		for y := -12; y <= 6; y++ {
@@ -339,63 +364,275 @@ func (g *Game) GenerateWorldTerrainWithNoise(noise *PerlinNoise) {
 		}
 	}
 	
-	// 将玩家放置在地面上方
-	g.player.SetPosition(0, float64(-10 * entity.BlockSize))
+	// 将玩家放置在地面上，并设置为重生点（避免出生时摔落受伤）
+	spawnY := g.findGroundY(0)
+	g.player.SetPosition(0, spawnY)
+	g.player.SetSpawnPoint(0, spawnY)
+}
+
+// generationBlocks 获取按位置（先行后列）排序的所有方块
+// 世界生成中遍历方块时使用，保证同一个种子生成相同的世界
+func (g *Game) generationBlocks() []*entity.Block {
+	blocks := g.world.GetAllBlocks()
+	sort.Slice(blocks, func(i, j int) bool {
+		xi, yi := blocks[i].GetGridPosition()
+		xj, yj := blocks[j].GetGridPosition()
+		if yi != yj {
+			return yi < yj
+		}
+		return xi < xj
+	})
+	return blocks
+}
+
+// findGroundY 从出生点高度向下查找第一个实心方块，返回站在其上方时玩家的Y坐标
+func (g *Game) findGroundY(x float64) float64 {
+	left := int(math.Floor((x - entity.PlayerSize/2) / entity.BlockSize))
+	right := int(math.Floor((x + entity.PlayerSize/2 - 1) / entity.BlockSize))
+	for y := -10; y < 60; y++ {
+		for gx := left; gx <= right; gx++ {
+			if g.world.IsSolidAt(gx, y) {
+				return float64(y*entity.BlockSize - entity.PlayerSize/2)
+			}
+		}
+	}
+	return float64(-10 * entity.BlockSize)
+}
+
+// generateVines 生成藤蔓
+// 藤蔓悬挂在树冠下方，或者沿洞穴墙壁从靠近洞顶的位置向下垂落
+func (g *Game) generateVines(noise *PerlinNoise) {
+	type vineStart struct {
+		x, y, length int
+	}
+	starts := make([]vineStart, 0)
+
+	for _, block := range g.generationBlocks() {
+		x, y := block.GetGridPosition()
+		switch block.GetType() {
+		case entity.LeavesBlock:
+			// 树冠下方的空位
+			if !g.world.IsBlockAt(x, y+1) && noise.Noise(float64(x)*0.7+0.5, float64(y)*0.7+0.5) > 0.35 {
+				length := 1 + int(math.Abs(noise.Noise(float64(x)*0.3+0.5, 60))*4)
+				starts = append(starts, vineStart{x, y + 1, length})
+			}
+		case entity.StoneBlock:
+			// 洞穴墙壁：石头侧面是空位且空位上方有方块
+			if y < 12 {
+				continue
+			}
+			for _, dx := range []int{-1, 1} {
+				if !g.world.IsBlockAt(x+dx, y) && g.world.IsBlockAt(x+dx, y-1) &&
+					noise.Noise(float64(x+dx)*0.3+0.5, float64(y)*0.3+0.5) > 0.2 {
+					length := 2 + int(math.Abs(noise.Noise(float64(x)*0.3+0.5, 80))*6)
+					starts = append(starts, vineStart{x + dx, y, length})
+				}
+			}
+		}
+	}
+
+	// 从起点向下延伸，直到遇到方块或达到长度
+	for _, start := range starts {
+		for i := 0; i < start.length; i++ {
+			if g.world.IsBlockAt(start.x, start.y+i) {
+				break
+			}
+			g.world.AddBlockWithType(start.x, start.y+i, entity.VineBlock)
+		}
+	}
+}
+
+// generateSpikes 在较深的洞穴地面上生成尖刺
+func (g *Game) generateSpikes(noise *PerlinNoise) {
+	spikes := make([][2]int, 0)
+	for _, block := range g.generationBlocks() {
+		x, y := block.GetGridPosition()
+		if block.GetType() != entity.StoneBlock || y < 20 {
+			continue
+		}
+		// 石头上方是空位，即洞穴地面
+		if !g.world.IsBlockAt(x, y-1) && noise.Noise(float64(x)*0.2+0.5, float64(y)*0.2+0.5) > 0.45 {
+			spikes = append(spikes, [2]int{x, y - 1})
+		}
+	}
+
+	for _, pos := range spikes {
+		g.world.AddBlockWithType(pos[0], pos[1], entity.SpikeBlock)
+	}
+}
+
+// Options 创建游戏时的选项
+type Options struct {
+	Sandbox   bool            // 沙盒模式：没有一局游戏和永久死亡，可以保存和加载世界
+	Seed      int64           // 世界的种子，0表示随机选择
+	DeathMode world.DeathMode // 沙盒模式中玩家死亡时物品栏的处理方式（一局游戏中死亡即结束）
 }
 
+// NewGame 创建游戏并用随机种子开始一局
 func NewGame() *Game {
+	return NewGameWithOptions(Options{})
+}
+
+// NewGameWithOptions 按选项创建游戏：默认开始一局游戏，沙盒模式下直接生成世界
+func NewGameWithOptions(options Options) *Game {
 	// 加载精灵表
 	spriteSheet, _, err := ebitenutil.NewImageFromFile("image/test.png")
 	if err != nil {
 		panic(err)
 	}
 	
-	// 创建世界
-	w := world.NewWorld()
+	// 加载物品属性（合成配方会引用其中的物品名称，需要先加载）
+	if err := entity.LoadItems("data/items.json"); err != nil {
+		panic(err)
+	}
+	
+	// 加载合成配方
+	recipes, err := entity.LoadRecipes("data/recipes.json")
+	if err != nil {
+		panic(err)
+	}
+	
+	// 加载首领（召唤物品和掉落物引用物品名称）
+	if err := entity.LoadBosses("data/bosses.json"); err != nil {
+		panic(err)
+	}
+	
+	// 加载战利品表（方块、箱子和生物的掉落物引用物品名称）
+	if err := entity.LoadLootTables("data/loot_tables.json"); err != nil {
+		panic(err)
+	}
+	
+	// 加载天赋
+	if err := entity.LoadPerks("data/perks.json"); err != nil {
+		panic(err)
+	}
+	
+	// 加载解锁内容（起始物品引用物品名称）和跨局进度
+	unlocks, err := LoadUnlocks(unlocksPath)
+	if err != nil {
+		panic(err)
+	}
+	meta, metaErr := LoadMeta(metaPath)
 	
 	// 创建游戏实例
 	g := &Game{
-		player: w.Player,
-		camera: entity.NewCamera(0, 0),
-		world:  w,
 		lastPlacePos:    [2]int{-1, -1}, // 初始化为无效位置
-		lastDestroyPos:  [2]int{-1, -1}, // 初始化为无效位置
 		spriteSheet: spriteSheet,
+		recipes:     recipes,
+		meta:        meta,
+		metaPath:    metaPath,
+		unlocks:     unlocks,
+		deathMode:   options.DeathMode,
 	}
 	
-	// 使用当前时间作为种子，生成随机世界
-	seed := int64(rand.Intn(1000000))
-	noise := NewPerlinNoise(seed)
+	// 使用指定的种子或随机种子
+	seed := options.Seed
+	if seed == 0 {
+		seed = newRunSeed()
+	}
 	
-	// 生成世界地形
-	g.GenerateWorldTerrainWithNoise(noise)
+	if options.Sandbox {
+		// 生成世界，在出生点旁边放置训练假人，用于试验武器和冲刺攻击
+		g.perkRng = newPerkRng(seed)
+		g.generateWorld(seed)
+		g.placeTrainingDummy()
+	} else {
+		g.startRun(seed)
+	}
 	
-	// 设置相机
-	g.camera.SetScreenSize(800, 600)
+	// 进度文件损坏时使用空的进度，并且不覆盖原来的文件
+	if metaErr != nil {
+		g.meta, g.metaPath = &MetaProgress{}, ""
+		g.showNotice("Progress load failed: " + metaErr.Error())
+	}
 	
 	return g
 }
 
+// generateWorld 创建新的世界并用种子生成地形，相机对准出生点
+func (g *Game) generateWorld(seed int64) {
+	g.world = world.NewWorld()
+	g.world.SetSeed(seed)
+	g.world.DeathMode = g.deathMode
+	g.player = g.world.Player
+	g.GenerateWorldTerrainWithNoise(NewPerlinNoise(seed))
+	
+	g.camera = entity.NewCamera(g.player.GetPosition())
+	g.camera.SetScreenSize(800, 600)
+}
+
 type Game struct {
 	player *entity.Player
 	camera *entity.Camera
 	world  *world.World
 	// 连续放置/破坏方块相关变量
 	lastPlacePos    [2]int // 记录上次放置方块的网格位置
-	lastDestroyPos  [2]int // 记录上次破坏方块的网格位置
+	mining          entity.MiningState // 按住左键挖掘方块的进度
+	dragging        bool // 是否按住左键从槽位中拖起了物品
+	dragSlot        int  // 拖起物品的槽位（合成网格的格子从TotalSlotCount开始编号）
+	recipes         *entity.RecipeRegistry // 合成配方
+	crafting        *entity.CraftingGrid   // 物品栏展开时使用的合成网格
+	chest           *entity.Container      // 当前打开的箱子（打开箱子时没有合成网格）
+	furnace         *entity.FurnaceEntity  // 当前打开的熔炉
+	run             *Run            // 正在进行的一局游戏，沙盒模式下为nil
+	meta            *MetaProgress   // 跨局保存的进度
+	metaPath        string          // 跨局进度的保存路径，为空时不保存
+	unlocks         []Unlock        // 所有可以解锁的内容
+	runUnlocks      []Unlock        // 上一局结束时新解锁的内容
+	notice          string // 屏幕上显示的提示信息（如保存成功）
+	noticeTimer     int    // 提示信息剩余的显示帧数
+	recipeSearch    string // 配方列表的搜索关键字
+	searchFocused   bool   // 是否正在输入搜索关键字
+	perkOffer       []entity.Perk // 正在提供给玩家选择的天赋，为nil时没有在选择
+	pendingPerks    int           // 排队等待的天赋选择次数
+	perkRng         *rand.Rand    // 抽取天赋使用的随机数
+	showStats       bool          // 是否显示能力面板
+	deathMode       world.DeathMode // 生成的世界使用的死亡模式
 	spriteSheet     *ebiten.Image // 精灵表
+	fallbackSprites map[int]*ebiten.Image // 精灵表中还没有图案的精灵的代替图像
 }
 
 func (g *Game) Update() error {
+	// 一局结束后只等待玩家开始新的一局
+	if g.run != nil && g.run.Over {
+		if inpututil.IsKeyJustPressed(ebiten.KeyEnter) {
+			g.startRun(newRunSeed())
+		}
+		return nil
+	}
+	
+	// 选择天赋时游戏暂停，只处理选择
+	if g.updatePerkOffer() {
+		return nil
+	}
+	
 	// 更新玩家输入
 	g.handleInput()
 	
 	// 更新玩家状态
 	g.player.Update()
 	
-	// 更新世界状态（包括掉落物）
+	// 玩家在一局游戏中死亡时这一局结束，不会重生
+	if g.checkRunOver() {
+		return nil
+	}
+	
+	// 玩家死亡时先关闭物品栏，世界随后处理死亡
+	g.closeOnDeath()
+	
+	// 更新世界状态（包括掉落物和熔炉等方块实体）
 	g.world.Update()
 	
+	// 记录这一局的击杀和拾取，首领战开始、结束时显示提示
+	g.handleWorldEvents()
+	
+	// 推进这一局的计时，进入更深的层时显示提示
+	g.updateRun()
+	
+	if g.noticeTimer > 0 {
+		g.noticeTimer--
+	}
+	
 	// 更新摄像机跟随
 	g.camera.SetTarget(g.player.GetPosition())
 	g.camera.Update()
@@ -406,10 +643,16 @@ func (g *Game) Update() error {
 			g.placeBlock()
 		}
 		
-		// 处理连续破坏方块（仅当物品栏未展开时）
-		if ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
-			g.destroyBlock()
+		// 左键点击实体时攻击实体，否则按住左键持续挖掘方块（仅当物品栏未展开时），松开后进度清零
+		if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) && g.attackWithSelectedItem() {
+			g.mining.Reset()
+		} else if ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
+			g.mineBlock()
+		} else {
+			g.mining.Reset()
 		}
+	} else {
+		g.mining.Reset()
 	}
 	
 	return nil
@@ -429,12 +672,13 @@ func (g *Game) Draw(screen *ebiten.Image) {
 		g.drawSprite(screen, screenX, screenY, spriteIndex)
 	}
 	
-	// 绘制所有掉落物
-	items := g.world.GetAllItems()
-	for _, item := range items {
-		// 使用掉落物自己的绘制方法（带相机支持）
-		item.DrawWithCamera(screen, g.spriteSheet, g.camera)
-	}
+	// 绘制正在挖掘的方块上的裂纹和鼠标指向的目标格
+	g.drawMiningCracks(screen)
+	g.drawTargetHighlight(screen)
+	
+	// 按绘制层绘制掉落物、训练假人、生物和投射物，然后绘制首领
+	g.drawEntities(screen)
+	g.drawBoss(screen)
 	
 	// 绘制冲刺残影
 	dashTrails := g.player.GetDashTrails()
@@ -443,7 +687,12 @@ func (g *Game) Draw(screen *ebiten.Image) {
 		// 使用半透明红色方块表示残影
 		op := &ebiten.DrawImageOptions{}
 		op.GeoM.Translate(screenX-16, screenY-16)
-		op.ColorM.Scale(1, 1, 1, trail.Alpha*0.5) // 设置透明度
+		if trail.Strike {
+			// 冲刺攻击命中后的残影呈橙色
+			op.ColorM.Scale(1, 0.55, 0.15, trail.Alpha*0.7)
+		} else {
+			op.ColorM.Scale(1, 1, 1, trail.Alpha*0.5) // 设置透明度
+		}
 		// 绘制玩家精灵作为残影
 		g.drawSpriteWithOp(screen, op, entity.PlayerSprite)
 	}
@@ -451,16 +700,49 @@ func (g *Game) Draw(screen *ebiten.Image) {
 	// 绘制玩家
 	playerX, playerY := g.player.GetPosition()
 	screenX, screenY := g.camera.WorldToScreen(playerX, playerY)
-	// 绘制玩家精灵
-	g.drawSprite(screen, screenX-16, screenY-16, entity.PlayerSprite)
+	// 绘制玩家精灵，受伤后的无敌时间内闪烁
+	playerOp := &ebiten.DrawImageOptions{}
+	playerOp.GeoM.Translate(screenX-16, screenY-16)
+	if g.player.InvulnerableTimer > 0 && g.player.InvulnerableTimer%4 < 2 {
+		playerOp.ColorM.Scale(1, 0.4, 0.4, 0.6)
+	}
+	g.drawSpriteWithOp(screen, playerOp, entity.PlayerSprite)
+	
+	// 夜晚使画面变暗
+	g.drawNightShade(screen)
+	
+	// 绘制生命值和状态效果
+	g.drawHealth(screen)
+	g.drawEffects(screen)
+	
+	// 首领战期间在屏幕上方绘制首领血条
+	g.drawBossBar(screen)
 	
 	// 绘制底部快捷栏
 	g.drawHotbar(screen)
 	
+	// 物品栏已满时在快捷栏上方提示
+	if g.world.IsInventoryFull() {
+		g.drawInventoryFull(screen)
+	}
+	
 	// 如果物品栏展开，绘制完整物品栏
 	if g.player.GetInventory().IsOpen() {
 		g.drawInventory(screen)
 	}
+	
+	// 一局游戏的层数、击杀和时间，以及能力面板
+	g.drawRunStatus(screen)
+	g.drawStatsPanel(screen)
+	
+	// 绘制保存、加载等提示信息
+	g.drawNotice(screen)
+	
+	// 天赋选择界面
+	g.drawPerkOffer(screen)
+	
+	// 一局结束后显示结算
+	g.drawRunSummary(screen)
 }
 
 func (g *Game) Layout(outsideWidth, outsideHeight int) (int, int) { 
@@ -484,8 +766,19 @@ func (g *Game) handleInput() {
 			g.player.MoveHorizontal(1)
 		}
 		
-		// 跳跃
-		if inpututil.IsKeyJustPressed(ebiten.KeyW) {
+		// 攀爬：与梯子、藤蔓、绳索重叠时W/S上下移动，空格跳离
+		if g.player.IsOnClimbable() {
+			if ebiten.IsKeyPressed(ebiten.KeyW) {
+				g.player.Climb(-1)
+			}
+			if ebiten.IsKeyPressed(ebiten.KeyS) {
+				g.player.Climb(1)
+			}
+			if inpututil.IsKeyJustPressed(ebiten.KeySpace) {
+				g.player.Jump()
+			}
+		} else if inpututil.IsKeyJustPressed(ebiten.KeyW) {
+			// 跳跃
 			g.player.Jump()
 		}
 		
@@ -499,21 +792,42 @@ func (g *Game) handleInput() {
 			g.player.Dash(worldX, worldY)
 		}
 		
-		// 单次放置方块（向后兼容）
-		if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonRight) {
-			g.placeBlock()
+		// R键切换冲刺攻击模式
+		if inpututil.IsKeyJustPressed(ebiten.KeyR) {
+			g.toggleDashStrike()
 		}
 		
-		// 单次破坏方块（向后兼容）
-		if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
-			g.destroyBlock()
+		// 单次放置方块（向后兼容），每次新的点击都允许在同一格再次操作（例如延长绳索）
+		if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonRight) {
+			g.lastPlacePos = [2]int{-1, -1}
+			// 右键工作台打开3x3合成网格，右键箱子打开箱子；其他情况由选中物品的使用行为决定（放置方块、食用、爆炸等）
+			if g.interactWithBlock() {
+				return
+			}
+			g.useSelectedItem()
 		}
 	}
 	
+	// 正在输入配方搜索关键字时，键盘输入只用于搜索
+	if g.searchFocused && g.player.GetInventory().IsOpen() {
+		g.handleSearchInput()
+		g.handleInventoryMouse()
+		return
+	}
+	
 	// 处理物品栏相关输入（任何时候都可以）
 	// E键切换物品栏展开状态
 	if inpututil.IsKeyJustPressed(ebiten.KeyE) {
-		g.player.GetInventory().ToggleOpen()
+		if g.player.GetInventory().IsOpen() {
+			g.closeInventory()
+		} else {
+			g.openInventory(entity.InventoryCraftingSize)
+		}
+	}
+	
+	// Tab键显示/隐藏能力面板
+	if inpututil.IsKeyJustPressed(ebiten.KeyTab) {
+		g.showStats = !g.showStats
 	}
 	
 	// 数字键1-9选择快捷栏槽位
@@ -540,10 +854,320 @@ func (g *Game) handleInput() {
 	
 	// ESC键关闭物品栏
 	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
-		g.player.GetInventory().CloseInventory()
+		g.closeInventory()
+	}
+	
+	// F5保存世界，F9加载存档
+	if inpututil.IsKeyJustPressed(ebiten.KeyF5) {
+		g.saveWorld()
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyF9) {
+		g.loadWorld()
+	}
+	
+	// Q键丢出选中槽位（物品栏展开时为鼠标悬停的槽位）的一个物品，按住Ctrl丢出整组
+	if inpututil.IsKeyJustPressed(ebiten.KeyQ) {
+		slot := g.player.GetInventory().GetSelectedSlot()
+		if g.player.GetInventory().IsOpen() {
+			if hovered, ok := slotAt(ebiten.CursorPosition()); ok {
+				slot = hovered
+			}
+		}
+		targetX, targetY := g.throwTarget()
+		g.dropFromSlot(slot, ebiten.IsKeyPressed(ebiten.KeyControl), targetX, targetY)
+	}
+	
+	// 物品栏展开时用鼠标整理物品
+	g.handleInventoryMouse()
+}
+
+// 快捷栏和物品栏面板在屏幕上的位置（与drawHotbar、drawInventory一致）
+const (
+	hotbarX        = (800 - 9*40) / 2
+	hotbarY        = 600 - 40 - 10
+	inventoryGridX = (800 - 9*40) / 2
+	inventoryGridY = (600 - 3*40) / 2
+)
+
+// slotAt 获取屏幕坐标下的物品栏槽位索引
+func slotAt(mx, my int) (int, bool) {
+	if mx >= hotbarX && mx < hotbarX+9*40 && my >= hotbarY && my < hotbarY+40 {
+		return (mx - hotbarX) / 40, true
+	}
+	if mx >= inventoryGridX && mx < inventoryGridX+9*40 && my >= inventoryGridY && my < inventoryGridY+3*40 {
+		return entity.HotbarSlotCount + (my-inventoryGridY)/40*9 + (mx-inventoryGridX)/40, true
+	}
+	return -1, false
+}
+
+// isInsideInventoryPanel 检查屏幕坐标是否在物品栏面板内（从合成网格到快捷栏，包括右侧的配方列表）
+func isInsideInventoryPanel(mx, my int) bool {
+	return mx >= inventoryGridX && mx < recipePanelX+recipePanelWidth && my >= craftingGridY-20 && my < hotbarY+40
+}
+
+// handleInventoryMouse 处理展开的物品栏中的鼠标操作
+// 左键拿起/放下/交换/合并，右键拆分或放入一个，Shift+左键在快捷栏和主物品栏之间快速移动；
+// 拿着物品在面板外点击（或拖到面板外松开）会丢出物品
+func (g *Game) handleInventoryMouse() {
+	inventory := g.player.GetInventory()
+	if !inventory.IsOpen() {
+		g.dragging = false
+		return
+	}
+	
+	mx, my := ebiten.CursorPosition()
+	stack, id, overSlot := g.stackAt(mx, my)
+	
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		g.searchFocused = isOverSearchBox(mx, my)
+		switch {
+		case overSlot && ebiten.IsKeyPressed(ebiten.KeyShift):
+			g.quickMove(id)
+		case overSlot && isFurnaceOutput(id):
+			inventory.TakeStack(stack)
+		case overSlot:
+			hadCursor := inventory.HasCursor()
+			inventory.ClickStack(stack)
+			g.dragging = !hadCursor && inventory.HasCursor()
+			g.dragSlot = id
+		case g.isOverCraftResult(mx, my):
+			inventory.CraftToCursor(g.crafting, g.recipes)
+		case g.handleRecipeClick(mx, my):
+			// 点击配方列表时直接合成
+		case inventory.HasCursor() && !isInsideInventoryPanel(mx, my):
+			g.throwCursor(true)
+		}
+	}
+	
+	// 拖起物品后在另一个槽位松开即放下，在面板外松开即丢出
+	if g.dragging && inpututil.IsMouseButtonJustReleased(ebiten.MouseButtonLeft) {
+		g.dragging = false
+		if !isInsideInventoryPanel(mx, my) {
+			g.throwCursor(true)
+		} else if overSlot && id != g.dragSlot && !isFurnaceOutput(id) {
+			inventory.ClickStack(stack)
+		}
+	}
+	
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonRight) {
+		if overSlot && isFurnaceOutput(id) {
+			inventory.TakeStack(stack)
+		} else if overSlot {
+			inventory.RightClickStack(stack)
+		} else if inventory.HasCursor() && !isInsideInventoryPanel(mx, my) {
+			g.throwCursor(false)
+		}
+	}
+}
+
+// stackAt 获取屏幕坐标下的物品栏槽位、合成网格格子或箱子槽位
+// 返回物品堆叠的指针和编号，合成网格的格子从TotalSlotCount开始编号，
+// 箱子槽位从chestSlotBase开始编号，熔炉槽位从furnaceSlotBase开始编号
+func (g *Game) stackAt(mx, my int) (*entity.ItemStack, int, bool) {
+	if slot, ok := slotAt(mx, my); ok {
+		return &g.player.GetInventory().Slots[slot], slot, true
+	}
+	if cell, ok := g.craftingCellAt(mx, my); ok {
+		return &g.crafting.Slots[cell], entity.TotalSlotCount + cell, true
+	}
+	if slot, ok := g.chestSlotAt(mx, my); ok {
+		return &g.chest.Slots[slot], chestSlotBase + slot, true
+	}
+	if slot, ok := g.furnaceSlotAt(mx, my); ok {
+		return &g.furnace.Slots[slot], furnaceSlotBase + slot, true
+	}
+	return nil, -1, false
+}
+
+// quickMove Shift+左键：打开箱子或熔炉时在物品栏和箱子（熔炉）之间转移物品；
+// 否则物品栏槽位在快捷栏和主物品栏之间移动，合成网格中的物品放回物品栏
+func (g *Game) quickMove(id int) {
+	inventory := g.player.GetInventory()
+	switch {
+	case id >= furnaceSlotBase:
+		moveStack(&g.furnace.Slots[id-furnaceSlotBase], inventory.AddStack)
+	case id >= chestSlotBase:
+		moveStack(&g.chest.Slots[id-chestSlotBase], inventory.AddStack)
+	case id >= entity.TotalSlotCount:
+		moveStack(&g.crafting.Slots[id-entity.TotalSlotCount], inventory.AddStack)
+	case g.chest != nil:
+		moveStack(&inventory.Slots[id], g.chest.AddStack)
+	case g.furnace != nil:
+		moveStack(&inventory.Slots[id], g.furnace.InsertStack)
+	default:
+		inventory.QuickMove(id)
+	}
+}
+
+// moveStack 把槽位中的物品交给add放入目标位置，槽位中只留下放不下的部分
+func moveStack(slot *entity.ItemStack, add func(entity.ItemStack) int) {
+	if slot.Type == entity.Air || slot.Count <= 0 {
+		return
+	}
+	slot.Count = add(*slot)
+	if slot.Count == 0 {
+		*slot = entity.ItemStack{Type: entity.Air, Count: 0}
+	}
+}
+
+// throwCursor 丢出鼠标上拿着的物品（整组或一个）
+func (g *Game) throwCursor(all bool) {
+	inventory := g.player.GetInventory()
+	var stack entity.ItemStack
+	if all {
+		stack = inventory.TakeCursor()
+	} else {
+		stack = inventory.TakeCursorOne()
+	}
+	if stack.Count == 0 {
+		return
+	}
+	targetX, targetY := g.throwTarget()
+	g.world.ThrowStack(stack, targetX, targetY)
+}
+
+// throwTarget 获取丢出物品的目标点：物品栏关闭时朝向鼠标，展开时朝向玩家面对的方向
+func (g *Game) throwTarget() (float64, float64) {
+	if !g.player.GetInventory().IsOpen() {
+		mx, my := ebiten.CursorPosition()
+		return g.camera.ScreenToWorld(float64(mx), float64(my))
+	}
+	playerX, playerY := g.player.GetPosition()
+	return playerX + float64(g.player.Facing*entity.BlockSize), playerY
+}
+
+// dropFromSlot 从槽位中取出一个（或整组）物品，朝目标点丢出
+func (g *Game) dropFromSlot(slot int, all bool, targetX, targetY float64) bool {
+	count := 1
+	if all {
+		count = g.player.GetInventory().GetSlot(slot).Count
+	}
+	
+	stack := g.player.GetInventory().RemoveFromSlot(slot, count)
+	if stack.Count == 0 {
+		return false
+	}
+	g.world.ThrowStack(stack, targetX, targetY)
+	return true
+}
+
+// drawTargetHighlight 高亮鼠标指向的网格，可以操作时为白色，超出范围或被遮挡时为红色
+func (g *Game) drawTargetHighlight(screen *ebiten.Image) {
+	if g.player.GetInventory().IsOpen() {
+		return
+	}
+	
+	mx, my := ebiten.CursorPosition()
+	worldX, worldY := g.camera.ScreenToWorld(float64(mx), float64(my))
+	gridX, gridY := int(math.Floor(worldX/32)), int(math.Floor(worldY/32))
+	
+	highlight := color.RGBA{255, 255, 255, 180}
+	if !g.world.CanReach(gridX, gridY) {
+		highlight = color.RGBA{220, 40, 40, 180}
+	}
+	
+	screenX, screenY := g.camera.WorldToScreen(float64(gridX*entity.BlockSize), float64(gridY*entity.BlockSize))
+	size := float64(entity.BlockSize)
+	ebitenutil.DrawRect(screen, screenX, screenY, size, 2, highlight)
+	ebitenutil.DrawRect(screen, screenX, screenY+size-2, size, 2, highlight)
+	ebitenutil.DrawRect(screen, screenX, screenY, 2, size, highlight)
+	ebitenutil.DrawRect(screen, screenX+size-2, screenY, 2, size, highlight)
+}
+
+// crackLines 裂纹线段（相对方块左上角的坐标），每个阶段多绘制两段
+var crackLines = [][4]float64{
+	{16, 16, 9, 8}, {16, 16, 24, 21},
+	{9, 8, 4, 2}, {24, 21, 30, 27},
+	{16, 16, 22, 6}, {16, 16, 7, 25},
+	{22, 6, 27, 1}, {7, 25, 2, 31},
+	{9, 8, 2, 12}, {24, 21, 29, 14},
+}
+
+// drawMiningCracks 根据挖掘进度在目标方块上绘制裂纹
+func (g *Game) drawMiningCracks(screen *ebiten.Image) {
+	if !g.mining.Active || g.mining.Progress <= 0 {
+		return
+	}
+	
+	screenX, screenY := g.camera.WorldToScreen(float64(g.mining.TargetX*entity.BlockSize), float64(g.mining.TargetY*entity.BlockSize))
+	stage := g.mining.Stage()
+	
+	// 进度越高方块越暗
+	shade := uint8(30 + stage*20)
+	ebitenutil.DrawRect(screen, screenX, screenY, entity.BlockSize, entity.BlockSize, color.RGBA{0, 0, 0, shade})
+	
+	lineCount := (stage + 1) * 2
+	if lineCount > len(crackLines) {
+		lineCount = len(crackLines)
+	}
+	for _, line := range crackLines[:lineCount] {
+		ebitenutil.DrawLine(screen, screenX+line[0], screenY+line[1], screenX+line[2], screenY+line[3], color.RGBA{20, 20, 20, 230})
 	}
 }
 
+// drawHealth 在左上角绘制生命值
+func (g *Game) drawHealth(screen *ebiten.Image) {
+	health, maxHealth := g.player.GetHealth()
+	
+	// 每颗心代表2点生命值
+	heartCount := (maxHealth + 1) / 2
+	for i := 0; i < heartCount; i++ {
+		x := 10 + i*14
+		y := 10
+		ebitenutil.DrawRect(screen, float64(x), float64(y), 12, 12, color.RGBA{40, 0, 0, 200})
+		
+		remaining := health - i*2
+		if remaining >= 2 {
+			ebitenutil.DrawRect(screen, float64(x+1), float64(y+1), 10, 10, color.RGBA{220, 30, 30, 255})
+		} else if remaining == 1 {
+			// 半颗心
+			ebitenutil.DrawRect(screen, float64(x+1), float64(y+1), 5, 10, color.RGBA{220, 30, 30, 255})
+		}
+	}
+	
+	healthText := fmt.Sprintf("HP %d/%d", health, maxHealth)
+	ebitenutil.DebugPrintAt(screen, healthText, 10+heartCount*14+4, 9)
+}
+
+// drawEffects 在生命值下方绘制状态效果图标及剩余时间
+func (g *Game) drawEffects(screen *ebiten.Image) {
+	for i, effect := range g.player.GetEffects() {
+		x := 10 + i*56
+		y := 28
+		
+		ebitenutil.DrawRect(screen, float64(x), float64(y), 24, 24, color.RGBA{0, 0, 0, 150})
+		ebitenutil.DrawRect(screen, float64(x+2), float64(y+2), 20, 20, getEffectColor(effect.Type))
+		
+		// 图标上显示效果名称首字母和等级
+		label := fmt.Sprintf("%c%d", effect.Type.String()[0], effect.Level)
+		ebitenutil.DebugPrintAt(screen, label, x+4, y+4)
+		
+		// 剩余秒数
+		seconds := (effect.Duration + 59) / 60
+		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("%ds", seconds), x+26, y+4)
+	}
+}
+
+// getEffectColor 根据状态效果类型获取图标颜色
+func getEffectColor(effectType entity.EffectType) color.RGBA {
+	switch effectType {
+	case entity.EffectSwiftness:
+		return color.RGBA{80, 200, 240, 255} // 浅蓝色
+	case entity.EffectFeatherfall:
+		return color.RGBA{230, 230, 250, 255} // 淡紫色
+	case entity.EffectLeaping:
+		return color.RGBA{120, 220, 80, 255} // 黄绿色
+	case entity.EffectLongDash:
+		return color.RGBA{240, 160, 40, 255} // 橙色
+	case entity.EffectPoison:
+		return color.RGBA{90, 140, 30, 255} // 暗绿色
+	case entity.EffectRegeneration:
+		return color.RGBA{230, 90, 160, 255} // 粉红色
+	}
+	return color.RGBA{255, 0, 255, 255} // 品红色（默认）
+}
+
+
 // drawHotbar 绘制底部快捷栏
 func (g *Game) drawHotbar(screen *ebiten.Image) {
 	inventory := g.player.GetInventory()
@@ -585,6 +1209,9 @@ func (g *Game) drawHotbar(screen *ebiten.Image) {
 				countText := fmt.Sprintf("%d", item.Count)
 				ebitenutil.DebugPrintAt(screen, countText, x+25-len(countText)*3, y+20)
 			}
+			
+			// 绘制工具耐久度
+			drawDurabilityBar(screen, item, x, y)
 		}
 		
 		// 绘制槽位编号
@@ -593,6 +1220,30 @@ func (g *Game) drawHotbar(screen *ebiten.Image) {
 	}
 }
 
+// drawInventoryFull 在快捷栏上方绘制"物品栏已满"提示
+func (g *Game) drawInventoryFull(screen *ebiten.Image) {
+	text := "Inventory full"
+	width := len(text)*6 + 12
+	x := (800 - width) / 2
+	y := 600 - 40 - 10 - 24
+	ebitenutil.DrawRect(screen, float64(x), float64(y), float64(width), 18, color.RGBA{160, 20, 20, 200})
+	ebitenutil.DebugPrintAt(screen, text, x+6, y+1)
+}
+
+// drawDurabilityBar 在槽位底部绘制工具的耐久度条，满耐久时不绘制
+func drawDurabilityBar(screen *ebiten.Image, item entity.ItemStack, x, y int) {
+	props, isTool := entity.GetToolProperties(item.Type)
+	if !isTool || item.Durability >= props.MaxDurability {
+		return
+	}
+	
+	ratio := float64(item.Durability) / float64(props.MaxDurability)
+	// 耐久度从绿色渐变到红色
+	barColor := color.RGBA{uint8(255 * (1 - ratio)), uint8(255 * ratio), 0, 255}
+	ebitenutil.DrawRect(screen, float64(x+3), float64(y+31), 30, 3, color.RGBA{0, 0, 0, 255})
+	ebitenutil.DrawRect(screen, float64(x+3), float64(y+31), 30*ratio, 2, barColor)
+}
+
 // drawInventory 绘制完整物品栏
 func (g *Game) drawInventory(screen *ebiten.Image) {
 	// 绘制半透明背景覆盖整个屏幕
@@ -633,12 +1284,70 @@ func (g *Game) drawInventory(screen *ebiten.Image) {
 					countText := fmt.Sprintf("%d", item.Count)
 					ebitenutil.DebugPrintAt(screen, countText, x+25-len(countText)*3, y+20)
 				}
+				drawDurabilityBar(screen, item, x, y)
 			}
 		}
 	}
 	
 	// 绘制说明文字
 	ebitenutil.DebugPrintAt(screen, "Press ESC or E to close inventory", 280, 580)
+	
+	// 绘制合成网格和配方列表（打开箱子时绘制箱子）
+	g.drawCrafting(screen)
+	g.drawRecipeList(screen)
+	g.drawChest(screen)
+	g.drawFurnace(screen)
+	
+	// 鼠标悬停在物品上时显示名称
+	g.drawItemTooltip(screen)
+	
+	// 绘制鼠标上拿着的物品
+	if inventory.HasCursor() {
+		item := inventory.GetCursor()
+		mx, my := ebiten.CursorPosition()
+		op := &ebiten.DrawImageOptions{}
+		op.GeoM.Scale(28.0/32.0, 28.0/32.0)
+		op.GeoM.Translate(float64(mx-14), float64(my-14))
+		g.drawSpriteWithOp(screen, op, getItemToSpriteIndex(item.Type))
+		if item.Count > 1 {
+			countText := fmt.Sprintf("%d", item.Count)
+			ebitenutil.DebugPrintAt(screen, countText, mx+9-len(countText)*3, my+4)
+		}
+	}
+}
+
+// drawItemTooltip 在鼠标悬停的物品旁显示物品名称，颜色表示稀有度
+func (g *Game) drawItemTooltip(screen *ebiten.Image) {
+	if g.player.GetInventory().HasCursor() {
+		return
+	}
+	mx, my := ebiten.CursorPosition()
+	stack, _, ok := g.stackAt(mx, my)
+	if !ok || stack.Type == entity.Air || stack.Count <= 0 {
+		return
+	}
+	
+	props := entity.GetItemProperties(stack.Type)
+	text := props.DisplayName
+	if props.Food > 0 {
+		text += fmt.Sprintf(" (+%d HP)", props.Food)
+	}
+	width := len(text)*6 + 12
+	ebitenutil.DrawRect(screen, float64(mx+12), float64(my-20), float64(width), 18, getRarityColor(props.Rarity))
+	ebitenutil.DebugPrintAt(screen, text, mx+18, my-19)
+}
+
+// getRarityColor 根据物品稀有度获取名称背景颜色
+func getRarityColor(rarity entity.Rarity) color.RGBA {
+	switch rarity {
+	case entity.RarityUncommon:
+		return color.RGBA{30, 110, 30, 230} // 绿色
+	case entity.RarityRare:
+		return color.RGBA{30, 70, 160, 230} // 蓝色
+	case entity.RarityEpic:
+		return color.RGBA{120, 40, 150, 230} // 紫色
+	}
+	return color.RGBA{40, 40, 40, 230} // 灰色
 }
 
 // drawSprite 绘制精灵
@@ -648,8 +1357,16 @@ func (g *Game) drawSprite(screen *ebiten.Image, x, y float64, index int) {
 	g.drawSpriteWithOp(screen, op, index)
 }
 
-// drawSpriteWithOp 使用指定选项绘制精灵
+// spriteSheetCount 精灵表中画好的精灵数量（玩家和4种基础方块），之后的索引还没有图案
+const spriteSheetCount = entity.LadderBlockSprite
+
+// drawSpriteWithOp 使用指定选项绘制精灵，精灵表中还没有图案的精灵用对应颜色的方块代替
 func (g *Game) drawSpriteWithOp(screen *ebiten.Image, op *ebiten.DrawImageOptions, index int) {
+	if index >= spriteSheetCount {
+		screen.DrawImage(g.fallbackSprite(index), op)
+		return
+	}
+	
 	// 精灵表是640x640，每个精灵是32x32
 	// 每行可以放20个精灵 (640/32 = 20)
 	spriteRow := index / 20
@@ -666,6 +1383,47 @@ func (g *Game) drawSpriteWithOp(screen *ebiten.Image, op *ebiten.DrawImageOption
 	screen.DrawImage(g.spriteSheet.SubImage(srcRect).(*ebiten.Image), op)
 }
 
+// fallbackSprite 获取代替精灵的纯色图像，按索引缓存
+func (g *Game) fallbackSprite(index int) *ebiten.Image {
+	if g.fallbackSprites == nil {
+		g.fallbackSprites = make(map[int]*ebiten.Image)
+	}
+	img, exists := g.fallbackSprites[index]
+	if !exists {
+		img = ebiten.NewImage(32, 32)
+		img.Fill(getSpriteColor(index))
+		g.fallbackSprites[index] = img
+	}
+	return img
+}
+
+// getSpriteColor 根据精灵索引获取代替精灵的颜色：方块和物品使用各自的颜色，生物和首领单独指定
+func getSpriteColor(index int) color.RGBA {
+	for blockType := entity.StoneBlock; blockType <= entity.PerkShrineBlock; blockType++ {
+		if entity.GetBlockSpriteIndex(blockType) == index {
+			return getBlockColor(blockType)
+		}
+	}
+	for _, props := range entity.Items.All() {
+		if props.Sprite == index {
+			return getItemColor(props.Type)
+		}
+	}
+	switch index {
+	case entity.SlimeSprite:
+		return color.RGBA{90, 200, 90, 255}   // 史莱姆绿
+	case entity.CaveCrawlerSprite:
+		return color.RGBA{90, 60, 110, 255}   // 暗紫色
+	case entity.CritterSprite:
+		return color.RGBA{220, 190, 140, 255} // 浅黄色
+	case entity.SlimeKingSprite:
+		return color.RGBA{40, 160, 60, 255}   // 深绿色
+	case entity.StoneGolemSprite:
+		return color.RGBA{100, 100, 110, 255} // 岩石灰
+	}
+	return color.RGBA{255, 0, 255, 255}   // 品红色（默认）
+}
+
 // getItemColor 根据物品类型获取颜色（备用方案）
 func getItemColor(itemType entity.ItemType) color.RGBA {
 	// 由于Grass已合并到Dirt中，需要特殊处理
@@ -677,6 +1435,51 @@ func getItemColor(itemType entity.ItemType) color.RGBA {
 		return color.RGBA{150, 100, 50, 255}  // 棕色
 	} else if itemType == entity.Leaves {
 		return color.RGBA{30, 120, 30, 255}   // 深绿色
+	} else if itemType == entity.Ladder || itemType == entity.Rope {
+		return color.RGBA{190, 150, 90, 255}  // 浅棕色
+	} else if itemType == entity.Vine {
+		return color.RGBA{60, 160, 60, 255}   // 绿色
+	} else if itemType == entity.Spike {
+		return color.RGBA{200, 200, 210, 255} // 银色
+	} else if itemType == entity.Planks || itemType == entity.CraftingTable || itemType == entity.Chest {
+		return color.RGBA{200, 160, 100, 255} // 木板色
+	} else if itemType == entity.IronOre || itemType == entity.IronIngot {
+		return color.RGBA{210, 170, 140, 255} // 铁锈色
+	} else if itemType == entity.Coal {
+		return color.RGBA{30, 30, 30, 255}    // 黑色
+	} else if itemType == entity.SmoothStone || itemType == entity.Furnace {
+		return color.RGBA{160, 160, 160, 255} // 浅灰色
+	} else if itemType == entity.Stick {
+		return color.RGBA{120, 80, 40, 255}   // 深棕色
+	} else if itemType == entity.Torch {
+		return color.RGBA{255, 200, 80, 255}  // 火焰色
+	} else if itemType == entity.Bomb {
+		return color.RGBA{50, 50, 60, 255}    // 深灰色
+	} else if itemType >= entity.SwiftnessPotion && itemType <= entity.RegenerationPotion {
+		return color.RGBA{180, 80, 200, 255}  // 药水紫色
+	} else if props, isTool := entity.GetToolProperties(itemType); isTool {
+		// 工具按材质着色
+		switch props.Tier {
+		case entity.TierWood:
+			return color.RGBA{160, 120, 60, 255}
+		case entity.TierStone:
+			return color.RGBA{110, 110, 110, 255}
+		case entity.TierIron:
+			return color.RGBA{220, 220, 220, 255}
+		}
+	}
+	// 数据文件中定义的物品按名称着色
+	switch entity.GetItemName(itemType) {
+	case "apple":
+		return color.RGBA{210, 40, 40, 255}   // 红色
+	case "sapling":
+		return color.RGBA{80, 180, 60, 255}   // 嫩绿色
+	case "wooden_sword", "bow", "arrow":
+		return color.RGBA{160, 120, 60, 255}  // 木头色
+	case "iron_sword", "throwing_knife":
+		return color.RGBA{220, 220, 220, 255} // 铁色
+	case "slime_crown":
+		return color.RGBA{240, 200, 40, 255}  // 金色
 	}
 	return color.RGBA{255, 0, 255, 255}   // 品红色（默认）
 }
@@ -697,27 +1500,42 @@ func getBlockColor(blockType entity.BlockType) color.RGBA {
 		return color.RGBA{150, 100, 50, 255}  // 棕色
 	} else if blockType == entity.LeavesBlock {
 		return color.RGBA{30, 120, 30, 255}   // 深绿色
+	} else if blockType == entity.LadderBlock || blockType == entity.RopeBlock {
+		return color.RGBA{190, 150, 90, 255}  // 浅棕色
+	} else if blockType == entity.VineBlock {
+		return color.RGBA{60, 160, 60, 255}   // 绿色
+	} else if blockType == entity.SpikeBlock {
+		return color.RGBA{200, 200, 210, 255} // 银色
+	} else if blockType == entity.PlanksBlock || blockType == entity.CraftingTableBlock || blockType == entity.ChestBlock {
+		return color.RGBA{200, 160, 100, 255} // 木板色
+	} else if blockType == entity.IronOreBlock {
+		return color.RGBA{210, 170, 140, 255} // 铁锈色
+	} else if blockType == entity.CoalOreBlock {
+		return color.RGBA{60, 60, 60, 255}    // 深灰色
+	} else if blockType == entity.SmoothStoneBlock || blockType == entity.FurnaceBlock {
+		return color.RGBA{160, 160, 160, 255} // 浅灰色
+	} else if blockType == entity.TorchBlock {
+		return color.RGBA{255, 200, 80, 255}  // 火焰色
+	} else if blockType == entity.ArenaBarrierBlock {
+		return color.RGBA{150, 60, 200, 255}  // 紫色
+	} else if blockType == entity.PerkShrineBlock {
+		return color.RGBA{80, 200, 220, 255}  // 青色
 	}
 	return color.RGBA{139, 69, 19, 255}   // 棕色（默认）
 }
 
-// getItemToBlockType 将物品类型转换为方块类型
-func getItemToBlockType(itemType entity.ItemType) entity.BlockType {
-	// 由于Grass已合并到Dirt中，需要特殊处理
-	if itemType == entity.Stone {
-		return entity.StoneBlock
-	} else if itemType == entity.Dirt || itemType == entity.Grass {
-		return entity.DirtBlock
-	} else if itemType == entity.Wood {
-		return entity.WoodBlock
-	} else if itemType == entity.Leaves {
-		return entity.LeavesBlock
-	}
-	return entity.StoneBlock // 默认为石头
+// getItemToBlockType 将物品类型转换为可以放置的方块类型（来自物品注册表），木棍、煤炭等不是方块的物品返回false
+func getItemToBlockType(itemType entity.ItemType) (entity.BlockType, bool) {
+	props := entity.GetItemProperties(itemType)
+	return props.Block, props.Placeable
 }
 
-// placeBlock 放置方块
+// placeBlock 按住右键时连续放置方块，只有可放置的物品会被连续使用
 func (g *Game) placeBlock() {
+	if _, placeable := getItemToBlockType(g.player.GetInventory().GetSelectedItem().Type); !placeable {
+		return
+	}
+	
 	// 获取鼠标位置
 	mx, my := ebiten.CursorPosition()
 	
@@ -734,27 +1552,69 @@ func (g *Game) placeBlock() {
 	// 更新上次放置位置
 	g.lastPlacePos[0], g.lastPlacePos[1] = gridX, gridY
 	
-	// 检查该位置是否已经有方块
-	if g.world.IsBlockAt(gridX, gridY) {
-		return // 如果已经有方块，则不放置也不消耗物品
-	}
-	
-	// 获取当前选中的物品
-	selectedItem := g.player.GetInventory().GetSelectedItem()
-	if selectedItem.Type == entity.Air {
-		return // 空气不能放置
-	}
+	g.useItemAt(gridX, gridY)
+}
+
+// useSelectedItem 点击右键时对鼠标所在的位置使用选中的物品
+func (g *Game) useSelectedItem() {
+	// 获取鼠标位置
+	mx, my := ebiten.CursorPosition()
 	
-	// 添加对应类型的方块
-	blockType := getItemToBlockType(selectedItem.Type)
-	g.world.AddBlockWithType(gridX, gridY, blockType)
+	// 转换为世界坐标
+	worldX, worldY := g.camera.ScreenToWorld(float64(mx), float64(my))
+	// 转换为网格坐标（使用math.Floor确保负数也能正确处理）
+	gridX, gridY := int(math.Floor(worldX/32)), int(math.Floor(worldY/32))
 	
-	// 消耗选中的物品
-	g.player.GetInventory().ConsumeSelectedItem()
+	// 记录位置，避免按住右键时在同一格重复放置
+	g.lastPlacePos[0], g.lastPlacePos[1] = gridX, gridY
+	g.useItemAt(gridX, gridY)
 }
 
-// destroyBlock 破坏方块
-func (g *Game) destroyBlock() {
+// attackWithSelectedItem 点击左键时攻击鼠标所在位置的实体，返回是否发生了攻击
+func (g *Game) attackWithSelectedItem() bool {
+	mx, my := ebiten.CursorPosition()
+	worldX, worldY := g.camera.ScreenToWorld(float64(mx), float64(my))
+	return g.attackAt(int(math.Floor(worldX/32)), int(math.Floor(worldY/32)))
+}
+
+// useItemAt 对指定网格位置使用选中的物品，返回物品是否被使用
+// 目标处有实体时先作用于实体，然后根据目标处是否有方块作用于方块或在空中使用
+func (g *Game) useItemAt(gridX, gridY int) bool {
+	ctx := g.itemUseContext(gridX, gridY)
+	behavior := entity.GetItemBehavior(ctx.Stack.Type)
+	if target, exists := g.world.EntityAt(gridX, gridY); exists && behavior.UseOnEntity(ctx, target) {
+		return true
+	}
+	if block, exists := g.world.GetBlock(gridX, gridY); exists {
+		return behavior.UseOnBlock(ctx, block)
+	}
+	return behavior.UseInAir(ctx)
+}
+
+// attackAt 用选中的物品攻击指定网格位置的实体，返回是否发生了攻击
+func (g *Game) attackAt(gridX, gridY int) bool {
+	target, exists := g.world.EntityAt(gridX, gridY)
+	if !exists {
+		return false
+	}
+	ctx := g.itemUseContext(gridX, gridY)
+	return entity.GetItemBehavior(ctx.Stack.Type).Attack(ctx, target)
+}
+
+// itemUseContext 创建使用选中物品的上下文
+func (g *Game) itemUseContext(gridX, gridY int) *entity.ItemUseContext {
+	inventory := g.player.GetInventory()
+	return &entity.ItemUseContext{
+		Player: g.player,
+		World:  g.world,
+		Stack:  &inventory.Slots[inventory.GetSelectedSlot()],
+		GridX:  gridX,
+		GridY:  gridY,
+	}
+}
+
+// mineBlock 挖掘鼠标指向的方块
+func (g *Game) mineBlock() {
 	// 获取鼠标位置
 	mx, my := ebiten.CursorPosition()
 	
@@ -763,14 +1623,27 @@ func (g *Game) destroyBlock() {
 	// 转换为网格坐标（使用math.Floor确保负数也能正确处理）
 	gridX, gridY := int(math.Floor(worldX/32)), int(math.Floor(worldY/32))
 	
-	// 如果网格位置没有变化，则不重复操作
-	if gridX == g.lastDestroyPos[0] && gridY == g.lastDestroyPos[1] {
-		return
+	g.mineAt(gridX, gridY)
+}
+
+// mineAt 对指定网格位置的方块挖掘一帧，进度完成时破坏方块并产生掉落物
+// 只能挖掘触及距离内第一个可见的方块，无法破坏的方块（竞技场屏障）不能挖掘；挖掘速度由方块硬度、手持物品和天赋决定，目标改变时进度重新计算
+func (g *Game) mineAt(gridX, gridY int) bool {
+	block, exists := g.world.GetBlock(gridX, gridY)
+	if !exists || !g.world.CanReach(gridX, gridY) || entity.GetBlockProperties(block.Type).Unbreakable {
+		g.mining.Reset()
+		return false
 	}
 	
-	// 更新上次破坏位置
-	g.lastDestroyPos[0], g.lastDestroyPos[1] = gridX, gridY
+	tool := g.player.GetInventory().GetSelectedItem().Type
+	speed := entity.GetMiningSpeed(tool, block.Type) * g.player.Stats().MiningSpeed
+	if !g.mining.Mine(gridX, gridY, block.Type, speed) {
+		return false
+	}
 	
-	// 移除方块
-	g.world.RemoveBlock(gridX, gridY)
+	// 移除方块，掉落物取决于使用的工具，使用工具挖掘会消耗耐久
+	toolProps, _ := entity.GetToolProperties(tool)
+	g.world.BreakBlock(gridX, gridY, toolProps.Kind)
+	g.player.GetInventory().DamageSelectedItem(1)
+	return true
 }
\ No newline at end of file
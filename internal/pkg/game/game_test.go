@@ -1,11 +1,30 @@
 package game
 
 import (
+	"image/color"
+	"os"
 	"testing"
 	"mygo/internal/pkg/entity"
 	"mygo/internal/pkg/world"
 )
 
+// TestMain 测试前加载物品、首领、战利品表和天赋数据文件，与游戏启动时一致
+func TestMain(m *testing.M) {
+	if err := entity.LoadItems("../../../data/items.json"); err != nil {
+		panic(err)
+	}
+	if err := entity.LoadBosses("../../../data/bosses.json"); err != nil {
+		panic(err)
+	}
+	if err := entity.LoadLootTables("../../../data/loot_tables.json"); err != nil {
+		panic(err)
+	}
+	if err := entity.LoadPerks("../../../data/perks.json"); err != nil {
+		panic(err)
+	}
+	os.Exit(m.Run())
+}
+
 // 创建一个简化版的Game用于测试，避免加载图像文件
 func newTestGame() *Game {
 	// 创建世界
@@ -29,12 +48,19 @@ func newTestGame() *Game {
 	camera := entity.NewCamera(0, 0)
 	camera.SetScreenSize(800, 600)
 	
+	// 加载合成配方
+	recipes, err := entity.LoadRecipes("../../../data/recipes.json")
+	if err != nil {
+		panic(err)
+	}
+	
 	return &Game{
 		player: w.Player,
 		camera: camera,
 		world:  w,
+		recipes: recipes,
+		perkRng: newPerkRng(1),
 		lastPlacePos:    [2]int{-1, -1}, // 初始化为无效位置
-		lastDestroyPos:  [2]int{-1, -1}, // 初始化为无效位置
 		spriteSheet: nil, // 测试时不需要图像
 	}
 }
@@ -185,6 +211,27 @@ func TestWorldGeneration(t *testing.T) {
 	t.Logf("Generated %d blocks with %d different types", len(blocks), len(blockTypes))
 }
 
+func TestGenerateWorldUsesDeathMode(t *testing.T) {
+	g := newTestGame()
+	g.deathMode = world.DeathClearInventory
+	g.generateWorld(7)
+	if g.world.DeathMode != world.DeathClearInventory {
+		t.Error("Expected the generated world to use the selected death mode")
+	}
+}
+
+func TestSpritesWithoutArtHaveFallbackColors(t *testing.T) {
+	magenta := color.RGBA{255, 0, 255, 255}
+	for name, sprite := range entity.SpriteMap {
+		if sprite.Index >= spriteSheetCount && getSpriteColor(sprite.Index) == magenta {
+			t.Errorf("Expected a fallback color for sprite %q", name)
+		}
+	}
+	if getSpriteColor(entity.LadderBlockSprite) != getBlockColor(entity.LadderBlock) {
+		t.Error("Expected block sprites to use the block color")
+	}
+}
+
 func TestPerlinNoise(t *testing.T) {
 	// 测试噪声生成器
 	noise := NewPerlinNoise(12345)
@@ -202,4 +249,239 @@ func TestPerlinNoise(t *testing.T) {
 	}
 	
 	t.Logf("Noise value: %f, FBM value: %f", value, fbmValue)
-}
\ No newline at end of file
+}
+func TestWorldGenerationVines(t *testing.T) {
+	g := &Game{
+		world:  world.NewWorld(),
+		player: entity.NewPlayer(0, 0),
+	}
+
+	g.GenerateWorldTerrainWithNoise(NewPerlinNoise(12345))
+
+	vines := 0
+	for _, block := range g.world.GetAllBlocks() {
+		if block.GetType() != entity.VineBlock {
+			continue
+		}
+		vines++
+
+		// 出生点附近会被清空，跳过该区域
+		x, y := block.GetGridPosition()
+		if x >= -6 && x <= 6 {
+			continue
+		}
+
+		// 藤蔓必须悬挂在方块下方
+		if !g.world.IsBlockAt(x, y-1) {
+			t.Errorf("Expected vine at (%d, %d) to hang from a block", x, y)
+		}
+	}
+
+	if vines == 0 {
+		t.Error("Expected world generation to create vines")
+	}
+}
+
+func TestMiningRequiresHoldingUntilBroken(t *testing.T) {
+	g := newTestGame()
+	g.player.SetPosition(3*32+16, 1*32+16)
+	g.world.AddBlockWithType(3, 3, entity.StoneBlock)
+	g.world.AddBlockWithType(4, 3, entity.DirtBlock)
+	itemCount := len(g.world.GetAllItems())
+
+	frames := entity.GetBreakTime(entity.StoneBlock, 1.0)
+	for i := 0; i < frames-1; i++ {
+		if g.mineAt(3, 3) {
+			t.Fatalf("Stone broke after %d frames, expected %d", i+1, frames)
+		}
+	}
+	if !g.world.IsBlockAt(3, 3) || len(g.world.GetAllItems()) != itemCount {
+		t.Error("Expected block to remain without drops before mining finishes")
+	}
+
+	// 切换目标会重新计算进度
+	g.mineAt(4, 3)
+	if g.mining.TargetX != 4 || g.mining.Progress >= 0.5 {
+		t.Errorf("Expected progress to reset on target change, got %v", g.mining.Progress)
+	}
+	for i := 0; i < frames-1; i++ {
+		g.mineAt(3, 3)
+	}
+	if !g.mineAt(3, 3) {
+		t.Fatal("Expected stone to break after its full break time")
+	}
+	if g.world.IsBlockAt(3, 3) {
+		t.Error("Expected mined block to be removed")
+	}
+	if len(g.world.GetAllItems()) != itemCount+1 {
+		t.Error("Expected mined block to drop an item")
+	}
+}
+
+func TestReachAndPlacementChecks(t *testing.T) {
+	g := newTestGame()
+	// 玩家站在(0, 4)格内，脚下是y=5的地面
+	g.player.SetPosition(16, 4*32+16)
+	g.player.GetInventory().SetSelectedSlot(0) // 石头
+
+	if g.useItemAt(0, 4) {
+		t.Error("Expected placement inside the player to be rejected")
+	}
+	if g.useItemAt(0, -3) {
+		t.Error("Expected placement beyond reach to be rejected")
+	}
+	if !g.useItemAt(1, 4) {
+		t.Error("Expected placement next to the player to succeed")
+	}
+
+	// (-6, 4)在左侧墙壁(-5, 4)后面，被遮挡
+	g.world.AddBlockWithType(-6, 4, entity.DirtBlock)
+	g.mineAt(-6, 4)
+	if g.mining.Active {
+		t.Error("Expected no mining progress on a block behind a wall")
+	}
+	g.mineAt(0, 5)
+	if !g.mining.Active {
+		t.Error("Expected the block under the player to be minable")
+	}
+}
+
+// probeTarget 测试用的实体
+type probeTarget struct{ x, y float64 }
+
+func (p *probeTarget) GetPosition() (float64, float64) { return p.x, p.y }
+func (p *probeTarget) Tick(entity.EntityWorld)         {}
+func (p *probeTarget) Bounds() entity.Hitbox           { return entity.NewHitbox(p.x, p.y, 0, 0) }
+func (p *probeTarget) IsRemoved() bool                 { return false }
+func (p *probeTarget) DrawLayer() entity.DrawLayer     { return entity.LayerProps }
+
+// probeBehavior 记录被调用的使用行为钩子
+type probeBehavior struct {
+	entity.NoBehavior
+	hooks *[]string
+}
+
+func (b probeBehavior) UseOnEntity(*entity.ItemUseContext, entity.Target) bool {
+	*b.hooks = append(*b.hooks, "entity")
+	return true
+}
+
+func (b probeBehavior) Attack(*entity.ItemUseContext, entity.Target) bool {
+	*b.hooks = append(*b.hooks, "attack")
+	return true
+}
+
+func TestUseItemDispatchesToBehavior(t *testing.T) {
+	g := newTestGame()
+	g.player.SetPosition(16, 4*32+16)
+	inventory := g.player.GetInventory()
+	inventory.SetSelectedSlot(0)
+
+	// 药水对着方块或在空中使用都会被喝掉，而不是放置
+	inventory.SetSlot(0, entity.ItemStack{Type: entity.SwiftnessPotion, Count: 2})
+	if !g.useItemAt(0, 5) || !g.useItemAt(1, 4) || inventory.GetSelectedItem().Type != entity.Air {
+		t.Error("Expected potions to be drunk on blocks and in the air")
+	}
+	if g.world.IsBlockAt(1, 4) {
+		t.Error("Expected potions not to be placed")
+	}
+
+	// 可放置的物品不能放在已有的方块上
+	inventory.SetSlot(0, entity.ItemStack{Type: entity.Stone, Count: 1})
+	if g.useItemAt(0, 5) {
+		t.Error("Expected blocks not to be placed on an existing block")
+	}
+
+	hooks := make([]string, 0)
+	entity.RegisterItemBehavior("probe", probeBehavior{hooks: &hooks})
+	probe := entity.Items.Register(entity.ItemProperties{Name: "probe", MaxStack: 1, Behavior: "probe"})
+	inventory.SetSlot(0, entity.ItemStack{Type: probe, Count: 1})
+
+	if g.attackAt(1, 4) {
+		t.Error("Expected no attack without an entity")
+	}
+	g.world.AddEntity(&probeTarget{x: 1*32 + 16, y: 4*32 + 16})
+	if !g.useItemAt(1, 4) || !g.attackAt(1, 4) {
+		t.Fatal("Expected entity hooks to be used")
+	}
+	if len(hooks) != 2 || hooks[0] != "entity" || hooks[1] != "attack" {
+		t.Errorf("Unexpected hooks %v", hooks)
+	}
+}
+
+func TestToolsMineFasterAndAreNotPlaceable(t *testing.T) {
+	g := newTestGame()
+	g.player.SetPosition(16, 4*32+16)
+	inventory := g.player.GetInventory()
+	inventory.SetSlot(0, entity.NewToolStack(entity.StonePickaxe))
+	inventory.SetSelectedSlot(0)
+
+	if g.useItemAt(1, 4) {
+		t.Error("Expected tools not to be placeable")
+	}
+
+	frames := entity.GetBreakTime(entity.StoneBlock, entity.GetMiningSpeed(entity.StonePickaxe, entity.StoneBlock))
+	if frames >= entity.GetBreakTime(entity.StoneBlock, 1) {
+		t.Fatal("Expected pickaxe to shorten break time")
+	}
+	broken := false
+	for i := 0; i < frames; i++ {
+		broken = g.mineAt(0, 5)
+	}
+	if !broken {
+		t.Fatal("Expected stone to break with the pickaxe's break time")
+	}
+
+	full := entity.NewToolStack(entity.StonePickaxe).Durability
+	if inventory.GetSelectedItem().Durability != full-1 {
+		t.Errorf("Expected pickaxe to lose 1 durability, got %d", inventory.GetSelectedItem().Durability)
+	}
+}
+
+func TestDropFromSlot(t *testing.T) {
+	g := newTestGame()
+	inventory := g.player.GetInventory()
+	itemCount := len(g.world.GetAllItems())
+
+	if !g.dropFromSlot(0, false, 100, -64) {
+		t.Fatal("Expected to drop one item")
+	}
+	if inventory.GetSlot(0).Count != 63 {
+		t.Errorf("Expected 63 stone left, got %d", inventory.GetSlot(0).Count)
+	}
+
+	g.dropFromSlot(1, true, -100, -64)
+	if inventory.GetSlot(1).Type != entity.Air {
+		t.Error("Expected the whole stack to be dropped")
+	}
+
+	items := g.world.GetAllItems()
+	if len(items) != itemCount+2 {
+		t.Fatalf("Expected 2 thrown items, got %d", len(items)-itemCount)
+	}
+	if items[itemCount].VX <= 0 || items[itemCount+1].VX >= 0 {
+		t.Error("Expected items to be thrown toward the target")
+	}
+	if items[itemCount+1].Count != 64 {
+		t.Errorf("Expected thrown stack of 64, got %d", items[itemCount+1].Count)
+	}
+
+	if g.dropFromSlot(entity.HotbarSlotCount, false, 0, 0) {
+		t.Error("Expected dropping from an empty slot to fail")
+	}
+}
+
+func TestSlotAt(t *testing.T) {
+	if slot, ok := slotAt(hotbarX+2*40+5, hotbarY+5); !ok || slot != 2 {
+		t.Errorf("Expected hotbar slot 2, got %d", slot)
+	}
+	if slot, ok := slotAt(inventoryGridX+40+5, inventoryGridY+40+5); !ok || slot != entity.HotbarSlotCount+10 {
+		t.Errorf("Expected inventory slot %d, got %d", entity.HotbarSlotCount+10, slot)
+	}
+	if _, ok := slotAt(10, 10); ok {
+		t.Error("Expected no slot at the screen corner")
+	}
+	if isInsideInventoryPanel(10, 10) || !isInsideInventoryPanel(inventoryGridX+5, hotbarY-5) {
+		t.Error("Unexpected inventory panel bounds")
+	}
+}
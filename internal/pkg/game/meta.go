@@ -0,0 +1,212 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"mygo/internal/pkg/entity"
+)
+
+const (
+	// metaPath 跨局进度的文件路径
+	metaPath = "saves/meta.json"
+	// unlocksPath 解锁内容的数据文件路径
+	unlocksPath = "data/unlocks.json"
+)
+
+// UnlockRequirement 解锁条件，所有大于0的条件都满足时解锁
+type UnlockRequirement struct {
+	Runs       int // 完成的局数
+	BestFloor  int // 到达过的最深的层
+	TotalKills int // 累计击杀的生物数量
+	BossKills  int // 累计击败的首领数量
+}
+
+// Unlock 跨局解锁的内容：解锁后每一局开始时获得起始物品和额外生命值
+type Unlock struct {
+	Name        string
+	DisplayName string
+	Description string
+	Requires    UnlockRequirement
+	Items       []entity.ItemStack
+	MaxHealth   int
+}
+
+// MetaProgress 跨局保存的进度：所有局的统计和已经解锁的内容
+type MetaProgress struct {
+	Runs       int      `json:"runs"`
+	BestFloor  int      `json:"best_floor"`
+	BestDepth  int      `json:"best_depth"`
+	TotalKills int      `json:"total_kills"`
+	BossKills  int      `json:"boss_kills"`
+	Unlocked   []string `json:"unlocked"`
+}
+
+// IsUnlocked 检查内容是否已经解锁
+func (m *MetaProgress) IsUnlocked(name string) bool {
+	for _, unlocked := range m.Unlocked {
+		if unlocked == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Meets 检查当前的进度是否满足解锁条件
+func (m *MetaProgress) Meets(req UnlockRequirement) bool {
+	return m.Runs >= req.Runs && m.BestFloor >= req.BestFloor &&
+		m.TotalKills >= req.TotalKills && m.BossKills >= req.BossKills
+}
+
+// RecordRun 把结束的一局计入进度，返回因此新解锁的内容
+func (m *MetaProgress) RecordRun(run *Run, unlocks []Unlock) []Unlock {
+	m.Runs++
+	m.BestFloor = maxInt(m.BestFloor, run.Floor)
+	m.BestDepth = maxInt(m.BestDepth, run.MaxDepth)
+	m.TotalKills += run.Kills
+	m.BossKills += run.BossKills
+
+	var unlocked []Unlock
+	for _, unlock := range unlocks {
+		if !m.IsUnlocked(unlock.Name) && m.Meets(unlock.Requires) {
+			m.Unlocked = append(m.Unlocked, unlock.Name)
+			unlocked = append(unlocked, unlock)
+		}
+	}
+	return unlocked
+}
+
+// Save 把进度保存到文件
+func (m *MetaProgress) Save(path string) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// LoadMeta 从文件加载进度，文件不存在时返回空的进度
+func LoadMeta(path string) (*MetaProgress, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &MetaProgress{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	meta := &MetaProgress{}
+	if err := json.Unmarshal(data, meta); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return meta, nil
+}
+
+// unlockFile 解锁数据文件的格式
+type unlockFile struct {
+	Unlocks []unlockData `json:"unlocks"`
+}
+
+// unlockData 数据文件中的一项解锁内容
+type unlockData struct {
+	Name        string `json:"name"`
+	DisplayName string `json:"display_name"`
+	Description string `json:"description"`
+	Requires    struct {
+		Runs       int `json:"runs"`
+		BestFloor  int `json:"best_floor"`
+		TotalKills int `json:"total_kills"`
+		BossKills  int `json:"boss_kills"`
+	} `json:"requires"`
+	Items []struct {
+		Item  string `json:"item"`
+		Count int    `json:"count"`
+	} `json:"items"`
+	MaxHealth int `json:"max_health"`
+}
+
+// LoadUnlocks 从JSON文件加载解锁内容，起始物品引用物品名称，需要先加载物品
+func LoadUnlocks(path string) ([]Unlock, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	unlocks, err := ParseUnlocks(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return unlocks, nil
+}
+
+// ParseUnlocks 解析JSON格式的解锁内容，保持数据文件中的顺序
+func ParseUnlocks(data []byte) ([]Unlock, error) {
+	var file unlockFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, err
+	}
+
+	unlocks := make([]Unlock, 0, len(file.Unlocks))
+	names := make(map[string]bool)
+	for _, entry := range file.Unlocks {
+		unlock, err := entry.toUnlock()
+		if err != nil {
+			return nil, fmt.Errorf("unlock %q: %w", entry.Name, err)
+		}
+		if names[unlock.Name] {
+			return nil, fmt.Errorf("unlock %q: duplicate name", unlock.Name)
+		}
+		names[unlock.Name] = true
+		unlocks = append(unlocks, unlock)
+	}
+	return unlocks, nil
+}
+
+// toUnlock 把数据文件中的一项转换为解锁内容
+func (d unlockData) toUnlock() (Unlock, error) {
+	if d.Name == "" {
+		return Unlock{}, fmt.Errorf("missing name")
+	}
+	unlock := Unlock{
+		Name:        d.Name,
+		DisplayName: d.DisplayName,
+		Description: d.Description,
+		Requires: UnlockRequirement{
+			Runs:       d.Requires.Runs,
+			BestFloor:  d.Requires.BestFloor,
+			TotalKills: d.Requires.TotalKills,
+			BossKills:  d.Requires.BossKills,
+		},
+		MaxHealth: d.MaxHealth,
+	}
+	if unlock.DisplayName == "" {
+		unlock.DisplayName = d.Name
+	}
+	if unlock.MaxHealth < 0 {
+		return unlock, fmt.Errorf("max health must not be negative")
+	}
+	for _, entry := range d.Items {
+		item, ok := entity.ParseItemName(entry.Item)
+		if !ok {
+			return unlock, fmt.Errorf("unknown item %q", entry.Item)
+		}
+		count := entry.Count
+		if count <= 0 {
+			count = 1
+		}
+		unlock.Items = append(unlock.Items, entity.ItemStack{Type: item, Count: count})
+	}
+	return unlock, nil
+}
+
+// maxInt 返回两个整数中较大的一个
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,108 @@
+package game
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseUnlocks(t *testing.T) {
+	unlocks, err := ParseUnlocks([]byte(`{"unlocks": [
+		{"name": "kit", "display_name": "Kit", "requires": {"best_floor": 2, "total_kills": 5}, "items": [{"item": "torch", "count": 4}, {"item": "bow"}]},
+		{"name": "tough", "requires": {"runs": 1}, "max_health": 2}
+	]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(unlocks) != 2 || unlocks[0].Name != "kit" || unlocks[1].DisplayName != "tough" {
+		t.Fatalf("Unexpected unlocks %+v", unlocks)
+	}
+	kit := unlocks[0]
+	if kit.Requires.BestFloor != 2 || kit.Requires.TotalKills != 5 {
+		t.Errorf("Unexpected requirement %+v", kit.Requires)
+	}
+	if len(kit.Items) != 2 || kit.Items[0].Count != 4 || kit.Items[1].Count != 1 {
+		t.Errorf("Expected item counts to default to 1, got %+v", kit.Items)
+	}
+
+	for _, data := range []string{
+		`{"unlocks": [{"items": []}]}`,
+		`{"unlocks": [{"name": "a", "items": [{"item": "nothing"}]}]}`,
+		`{"unlocks": [{"name": "a", "max_health": -1}]}`,
+		`{"unlocks": [{"name": "a"}, {"name": "a"}]}`,
+	} {
+		if _, err := ParseUnlocks([]byte(data)); err == nil {
+			t.Errorf("Expected error for %s", data)
+		}
+	}
+}
+
+func TestUnlockDataFile(t *testing.T) {
+	unlocks, err := LoadUnlocks("../../../data/unlocks.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(unlocks) == 0 {
+		t.Fatal("Expected unlocks in the data file")
+	}
+	for _, unlock := range unlocks {
+		if unlock.Description == "" || (len(unlock.Items) == 0 && unlock.MaxHealth == 0) {
+			t.Errorf("Expected %s to describe a reward", unlock.Name)
+		}
+	}
+}
+
+func TestMetaProgressRecordAndPersist(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "saves", "meta.json")
+	meta, err := LoadMeta(path)
+	if err != nil || meta.Runs != 0 {
+		t.Fatal("Expected empty progress without a file")
+	}
+
+	unlocks := []Unlock{
+		{Name: "deep", Requires: UnlockRequirement{BestFloor: 3}},
+		{Name: "veteran", Requires: UnlockRequirement{Runs: 2, TotalKills: 4}},
+	}
+	run := NewRun(1)
+	run.Kills = 2
+	run.Descend(runFloorDepth * 2)
+	if got := meta.RecordRun(run, unlocks); len(got) != 1 || got[0].Name != "deep" {
+		t.Errorf("Expected reaching floor 3 to unlock deep, got %v", got)
+	}
+	if got := meta.RecordRun(NewRun(2), unlocks); len(got) != 0 {
+		t.Error("Expected veteran to need more kills")
+	}
+	run = NewRun(3)
+	run.Kills = 2
+	if got := meta.RecordRun(run, unlocks); len(got) != 1 || got[0].Name != "veteran" {
+		t.Errorf("Expected veteran after enough runs and kills, got %v", got)
+	}
+
+	if err := meta.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadMeta(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.Runs != 3 || loaded.BestFloor != 3 || loaded.TotalKills != 4 || !loaded.IsUnlocked("veteran") {
+		t.Errorf("Unexpected loaded progress %+v", loaded)
+	}
+
+	if err := os.WriteFile(path, []byte("{"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadMeta(path); err == nil {
+		t.Error("Expected corrupt progress to fail to load")
+	}
+}
+
+func TestUnlockRequirementDefaultsToMet(t *testing.T) {
+	meta := &MetaProgress{}
+	if !meta.Meets(UnlockRequirement{}) {
+		t.Error("Expected an empty requirement to be met")
+	}
+	if meta.Meets(UnlockRequirement{BossKills: 1}) {
+		t.Error("Expected boss requirement to need a boss kill")
+	}
+}
@@ -0,0 +1,45 @@
+package game
+
+import (
+	"image/color"
+
+	"mygo/internal/pkg/entity"
+	"mygo/internal/pkg/world"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+)
+
+// drawMob 绘制生物，受伤时闪红并在头顶显示血条
+func (g *Game) drawMob(screen *ebiten.Image, mob *entity.Mob) {
+	props := mob.Props()
+	screenX, screenY := g.camera.WorldToScreen(mob.X, mob.Y)
+	left, top := screenX-props.Width/2, screenY-props.Height/2
+
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Scale(props.Width/32, props.Height/32)
+	if mob.Facing < 0 {
+		// 朝左时水平翻转
+		op.GeoM.Scale(-1, 1)
+		op.GeoM.Translate(props.Width, 0)
+	}
+	op.GeoM.Translate(left, top)
+	if mob.HurtTimer > 0 {
+		op.ColorM.Scale(1, 0.4, 0.4, 1)
+	}
+	g.drawSpriteWithOp(screen, op, props.Sprite)
+
+	if mob.Health < mob.MaxHealth {
+		ebitenutil.DrawRect(screen, left, top-6, props.Width, 3, color.RGBA{60, 0, 0, 200})
+		ebitenutil.DrawRect(screen, left, top-6, props.Width*float64(mob.Health)/float64(mob.MaxHealth), 3, color.RGBA{220, 40, 40, 255})
+	}
+}
+
+// drawNightShade 根据天空亮度在画面上覆盖一层半透明的黑色，夜晚最暗
+func (g *Game) drawNightShade(screen *ebiten.Image) {
+	darkness := float64(world.MaxLight-g.world.SkyLight()) / world.MaxLight
+	if darkness <= 0 {
+		return
+	}
+	ebitenutil.DrawRect(screen, 0, 0, 800, 600, color.RGBA{0, 0, 20, uint8(darkness * 160)})
+}
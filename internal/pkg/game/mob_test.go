@@ -0,0 +1,96 @@
+package game
+
+import (
+	"testing"
+
+	"mygo/internal/pkg/entity"
+	"mygo/internal/pkg/world"
+)
+
+func TestWorldGenerationRecordsBiomes(t *testing.T) {
+	g := &Game{
+		world:  world.NewWorld(),
+		player: entity.NewPlayer(0, 0),
+	}
+	g.GenerateWorldTerrainWithNoise(NewPerlinNoise(12345))
+
+	if len(g.world.Biomes) != 600 {
+		t.Errorf("Expected a biome for each generated column, got %d", len(g.world.Biomes))
+	}
+	for x, biome := range g.world.Biomes {
+		if biome != world.BiomePlains && biome != world.BiomeDesert && biome != world.BiomeSnow {
+			t.Errorf("Unexpected biome %v at column %d", biome, x)
+		}
+	}
+}
+
+func TestMobsCanBeTargetedByItems(t *testing.T) {
+	g := newTestGame()
+	g.world.MobSpawning = false
+	mob := g.world.SpawnMob(entity.MobCritter, 2, 4)
+
+	if target, ok := g.world.EntityAt(2, 4); !ok || target != entity.Target(mob) {
+		t.Fatal("Expected the critter to be an item target")
+	}
+	// 没有攻击行为的物品不会攻击生物
+	g.player.GetInventory().SetSelectedSlot(0)
+	g.player.GetInventory().SetSlot(0, entity.ItemStack{Type: entity.Stick, Count: 1})
+	if g.attackAt(2, 4) {
+		t.Error("Expected a stick not to attack")
+	}
+}
+
+func TestWeaponsAttackMobs(t *testing.T) {
+	g := newTestGame()
+	g.world.MobSpawning = false
+	g.player.SetPosition(1*32, 4*32+16)
+	mob := g.world.SpawnMob(entity.MobCaveCrawler, 2, 4)
+	inventory := g.player.GetInventory()
+	inventory.SetSelectedSlot(0)
+
+	// 剑攻击鼠标下的生物
+	sword, _ := entity.ParseItemName("wooden_sword")
+	inventory.SetSlot(0, entity.ItemStack{Type: sword, Count: 1})
+	if !g.attackAt(2, 4) || mob.Health == mob.MaxHealth || mob.KnockbackTimer == 0 {
+		t.Fatal("Expected the sword to hit and knock back the crawler")
+	}
+
+	// 弓右键朝目标射箭
+	bow, _ := entity.ParseItemName("bow")
+	arrow, _ := entity.ParseItemName("arrow")
+	g.player.AttackTimer = 0
+	inventory.SetSlot(0, entity.ItemStack{Type: bow, Count: 1})
+	inventory.AddItem(arrow, 1)
+	if !g.useItemAt(-3, 2) || len(g.world.GetAllProjectiles()) != 1 {
+		t.Error("Expected the bow to fire an arrow")
+	}
+}
+
+func TestTrainingDummyAndDashStrikeToggle(t *testing.T) {
+	g := &Game{
+		world:  world.NewWorld(),
+		player: entity.NewPlayer(0, 0),
+	}
+	g.world.Player = g.player
+	g.GenerateWorldTerrainWithNoise(NewPerlinNoise(12345))
+	g.placeTrainingDummy()
+
+	targets := g.world.Damageables()
+	if len(targets) != 1 {
+		t.Fatalf("Expected a training dummy near the spawn, got %d targets", len(targets))
+	}
+	hitbox := targets[0].Hitbox()
+	bottomRow := int(hitbox.Bottom) / entity.BlockSize
+	if !g.world.IsSolidAt(trainingDummyOffset, bottomRow) || g.world.IsSolidAt(trainingDummyOffset, bottomRow-1) {
+		t.Error("Expected the dummy to stand on the ground")
+	}
+
+	g.toggleDashStrike()
+	if !g.player.DashStrike || g.notice == "" {
+		t.Error("Expected dash strike mode to be switched on with a notice")
+	}
+	g.toggleDashStrike()
+	if g.player.DashStrike {
+		t.Error("Expected dash strike mode to be switched off")
+	}
+}
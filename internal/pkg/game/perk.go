@@ -0,0 +1,176 @@
+package game
+
+import (
+	"fmt"
+	"image/color"
+	"math/rand"
+
+	"mygo/internal/pkg/entity"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// 天赋选择界面中卡片的位置和大小
+const (
+	perkCardX      = 85
+	perkCardY      = 220
+	perkCardWidth  = 200
+	perkCardHeight = 120
+	perkCardGap    = 15
+)
+
+// offerPerks 从天赋池中随机提供天赋让玩家选择，正在选择时排队等待下一次
+func (g *Game) offerPerks() {
+	if g.perkOffer != nil {
+		g.pendingPerks++
+		return
+	}
+	offer := entity.Perks.RollOffer(entity.PerkOfferSize, g.player, g.perkRng)
+	if len(offer) == 0 {
+		g.showNotice("No perks left to learn")
+		return
+	}
+	g.closeInventory()
+	g.mining.Reset()
+	g.perkOffer = offer
+}
+
+// choosePerk 选择提供的第index个天赋，有排队的天赋选择时继续提供
+func (g *Game) choosePerk(index int) bool {
+	if index < 0 || index >= len(g.perkOffer) {
+		return false
+	}
+	perk := g.perkOffer[index]
+	g.player.AddPerk(perk.Name)
+	g.perkOffer = nil
+	g.showNotice("Perk: " + perk.DisplayName)
+	if g.pendingPerks > 0 {
+		g.pendingPerks--
+		g.offerPerks()
+	}
+	return true
+}
+
+// useShrine 使用天赋祭坛：祭坛消失并提供天赋
+func (g *Game) useShrine(gridX, gridY int) {
+	g.world.ClearBlock(gridX, gridY)
+	g.offerPerks()
+}
+
+// updatePerkOffer 选择天赋时游戏暂停，按数字键或点击卡片选择，返回是否正在选择
+func (g *Game) updatePerkOffer() bool {
+	if g.perkOffer == nil {
+		return false
+	}
+	for i := range g.perkOffer {
+		if inpututil.IsKeyJustPressed(ebiten.Key1 + ebiten.Key(i)) {
+			g.choosePerk(i)
+			return true
+		}
+	}
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		if index, ok := perkCardAt(ebiten.CursorPosition()); ok {
+			g.choosePerk(index)
+		}
+	}
+	return true
+}
+
+// perkCardAt 获取屏幕坐标下的天赋卡片索引
+func perkCardAt(mx, my int) (int, bool) {
+	if my < perkCardY || my >= perkCardY+perkCardHeight || mx < perkCardX {
+		return -1, false
+	}
+	index := (mx - perkCardX) / (perkCardWidth + perkCardGap)
+	if index >= entity.PerkOfferSize || (mx-perkCardX)%(perkCardWidth+perkCardGap) >= perkCardWidth {
+		return -1, false
+	}
+	return index, true
+}
+
+// drawPerkOffer 绘制天赋选择界面：每张卡片显示名称、稀有度和效果，底色表示稀有度
+func (g *Game) drawPerkOffer(screen *ebiten.Image) {
+	if g.perkOffer == nil {
+		return
+	}
+	ebitenutil.DrawRect(screen, 0, 0, 800, 600, color.RGBA{0, 0, 0, 160})
+	ebitenutil.DebugPrintAt(screen, "Choose a perk (1-3 or click)", perkCardX, perkCardY-30)
+
+	for i, perk := range g.perkOffer {
+		x := perkCardX + i*(perkCardWidth+perkCardGap)
+		rarity := getRarityColor(perk.Rarity)
+		ebitenutil.DrawRect(screen, float64(x), perkCardY, perkCardWidth, perkCardHeight, color.RGBA{30, 30, 40, 230})
+		ebitenutil.DrawRect(screen, float64(x), perkCardY, perkCardWidth, 20, rarity)
+
+		lines := []string{
+			fmt.Sprintf("[%d] %s", i+1, perk.DisplayName),
+			"",
+			perk.Description,
+			"",
+			fmt.Sprintf("Owned: %d/%d", g.player.PerkStacks(perk.Name), perk.MaxStacks),
+		}
+		for j, line := range lines {
+			ebitenutil.DebugPrintAt(screen, line, x+6, perkCardY+3+j*16)
+		}
+	}
+}
+
+// drawStatsPanel 绘制能力面板：玩家当前的能力数值和选择的天赋
+func (g *Game) drawStatsPanel(screen *ebiten.Image) {
+	if !g.showStats {
+		return
+	}
+	stats := g.player.Stats()
+	lines := []string{
+		"STATS (Tab)",
+		fmt.Sprintf("Max health: %d", g.player.MaxHealth),
+		fmt.Sprintf("Speed: %.1f", stats.Speed),
+		fmt.Sprintf("Jumps: %d", stats.AirJumps),
+		fmt.Sprintf("Dash: %.0f x %d", stats.DashDistance, stats.DashDuration),
+		fmt.Sprintf("Mining speed: x%.2f", stats.MiningSpeed),
+		fmt.Sprintf("Magnet range: %.0f", g.world.MagnetRadius+stats.MagnetBonus),
+		fmt.Sprintf("Life steal: %.0f%%", stats.LifeSteal*100),
+		"",
+		"Perks:",
+	}
+	perks := 0
+	for _, perk := range entity.Perks.All() {
+		if stacks := g.player.PerkStacks(perk.Name); stacks > 0 {
+			lines = append(lines, fmt.Sprintf("  %s x%d", perk.DisplayName, stacks))
+			perks++
+		}
+	}
+	if perks == 0 {
+		lines = append(lines, "  none")
+	}
+
+	ebitenutil.DrawRect(screen, 580, 40, 210, float64(len(lines)*16+8), color.RGBA{0, 0, 0, 170})
+	for i, line := range lines {
+		ebitenutil.DebugPrintAt(screen, line, 588, 44+i*16)
+	}
+}
+
+// generateShrines 在较深的洞穴地面上零星生成天赋祭坛
+func (g *Game) generateShrines(noise *PerlinNoise) {
+	shrines := make([][2]int, 0)
+	for _, block := range g.generationBlocks() {
+		x, y := block.GetGridPosition()
+		if block.GetType() != entity.StoneBlock || y < 20 {
+			continue
+		}
+		if !g.world.IsBlockAt(x, y-1) && !g.world.IsBlockAt(x, y-2) &&
+			noise.Noise(float64(x)*1.3+7.1, float64(y)*1.3+2.9) > 0.6 {
+			shrines = append(shrines, [2]int{x, y - 1})
+		}
+	}
+	for _, pos := range shrines {
+		g.world.AddBlockWithType(pos[0], pos[1], entity.PerkShrineBlock)
+	}
+}
+
+// newPerkRng 创建一局游戏中抽取天赋使用的随机数，同一个种子提供相同的天赋
+func newPerkRng(seed int64) *rand.Rand {
+	return rand.New(rand.NewSource(seed))
+}
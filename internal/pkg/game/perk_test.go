@@ -0,0 +1,81 @@
+package game
+
+import (
+	"testing"
+
+	"mygo/internal/pkg/entity"
+)
+
+func TestNewFloorOffersPerks(t *testing.T) {
+	g := newTestGame()
+	g.run = NewRun(1)
+	g.run.Descend(g.playerGridY())
+
+	g.player.SetPosition(0, runFloorDepth*entity.BlockSize+16)
+	g.updateRun()
+	if len(g.perkOffer) != entity.PerkOfferSize {
+		t.Fatalf("Expected %d perks to be offered on a new floor, got %d", entity.PerkOfferSize, len(g.perkOffer))
+	}
+
+	// 选择天赋前再到达新的层时排队
+	g.player.SetPosition(0, 2*runFloorDepth*entity.BlockSize+16)
+	g.updateRun()
+	if g.pendingPerks != 1 {
+		t.Fatalf("Expected the second offer to be queued, got %d", g.pendingPerks)
+	}
+
+	chosen := g.perkOffer[1]
+	if !g.choosePerk(1) || g.player.PerkStacks(chosen.Name) != 1 {
+		t.Fatal("Expected the chosen perk to be added to the player")
+	}
+	if g.perkOffer == nil || g.pendingPerks != 0 {
+		t.Fatal("Expected the queued offer to open after choosing")
+	}
+	if g.choosePerk(entity.PerkOfferSize) {
+		t.Error("Expected choosing outside the offer to fail")
+	}
+	g.choosePerk(0)
+	if g.perkOffer != nil || len(g.player.Perks) != 2 {
+		t.Errorf("Expected both offers to be resolved, got perks %v", g.player.Perks)
+	}
+}
+
+func TestPerkShrine(t *testing.T) {
+	g := newTestGame()
+	g.world.AddBlockWithType(2, 4, entity.PerkShrineBlock)
+	g.player.SetPosition(0, 4*32+16)
+
+	if !g.useBlockAt(2, 4) {
+		t.Fatal("Expected the shrine to be usable")
+	}
+	if g.world.IsBlockAt(2, 4) {
+		t.Error("Expected the shrine to be used up")
+	}
+	if len(g.perkOffer) != entity.PerkOfferSize {
+		t.Errorf("Expected the shrine to offer perks, got %d", len(g.perkOffer))
+	}
+	if !entity.GetBlockProperties(entity.PerkShrineBlock).Unbreakable {
+		t.Error("Expected the shrine not to be mined")
+	}
+}
+
+func TestMiningPerkSpeedsUpMining(t *testing.T) {
+	mine := func(perks ...string) int {
+		g := newTestGame()
+		g.player.SetPosition(3*32+16, 1*32+16)
+		g.world.AddBlockWithType(3, 3, entity.StoneBlock)
+		for _, perk := range perks {
+			g.player.AddPerk(perk)
+		}
+		for frames := 1; frames < 1000; frames++ {
+			if g.mineAt(3, 3) {
+				return frames
+			}
+		}
+		t.Fatal("Expected the block to be mined")
+		return 0
+	}
+	if base, fast := mine(), mine("quick_hands", "quick_hands"); fast >= base {
+		t.Errorf("Expected the mining perk to mine faster, got %d frames vs %d", fast, base)
+	}
+}
@@ -0,0 +1,244 @@
+package game
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"math/rand"
+	"sort"
+
+	"mygo/internal/pkg/entity"
+	"mygo/internal/pkg/world"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+)
+
+const (
+	runFloorDepth = 12 // 一局游戏中每一层的深度（格）
+	runLootLines  = 6  // 结算界面最多列出的物品种类
+)
+
+// Run 一局游戏的记录：世界由种子生成，玩家不断向下深入，死亡时这一局结束并显示结算
+type Run struct {
+	Seed      int64
+	Floor     int                     // 到达过的最深的层，从1开始
+	MaxDepth  int                     // 到达过的最大深度（网格y）
+	Kills     int                     // 击杀的生物数量
+	BossKills int                     // 击败的首领数量
+	Frames    int                     // 这一局经过的帧数
+	Loot      map[entity.ItemType]int // 拾取的物品和数量
+	Over      bool                    // 玩家已经死亡，这一局结束
+}
+
+// NewRun 创建使用指定种子的一局游戏
+func NewRun(seed int64) *Run {
+	return &Run{Seed: seed, Floor: 1, Loot: make(map[entity.ItemType]int)}
+}
+
+// FloorAt 计算网格深度所在的层，地表及以上为第1层
+func FloorAt(gridY int) int {
+	if gridY < 0 {
+		return 1
+	}
+	return gridY/runFloorDepth + 1
+}
+
+// Descend 记录玩家到达的深度，第一次进入更深的层时返回true
+func (r *Run) Descend(gridY int) bool {
+	if gridY > r.MaxDepth {
+		r.MaxDepth = gridY
+	}
+	floor := FloorAt(gridY)
+	if floor <= r.Floor {
+		return false
+	}
+	r.Floor = floor
+	return true
+}
+
+// Record 根据世界事件记录击杀和拾取的物品
+func (r *Run) Record(event world.Event) {
+	switch event.Kind {
+	case world.EventMobKilled:
+		r.Kills++
+	case world.EventBossDefeated:
+		r.BossKills++
+	case world.EventItemPickedUp:
+		r.Loot[event.Item] += event.Count
+	}
+}
+
+// Duration 获取这一局经过的时间，格式为"分:秒"（每秒60帧）
+func (r *Run) Duration() string {
+	seconds := r.Frames / 60
+	return fmt.Sprintf("%02d:%02d", seconds/60, seconds%60)
+}
+
+// LootSummary 按数量从多到少列出拾取的物品，数量相同时按物品类型排列，最多列出limit种
+func (r *Run) LootSummary(limit int) []entity.ItemStack {
+	stacks := make([]entity.ItemStack, 0, len(r.Loot))
+	for itemType, count := range r.Loot {
+		stacks = append(stacks, entity.ItemStack{Type: itemType, Count: count})
+	}
+	sort.Slice(stacks, func(i, j int) bool {
+		if stacks[i].Count != stacks[j].Count {
+			return stacks[i].Count > stacks[j].Count
+		}
+		return stacks[i].Type < stacks[j].Type
+	})
+	if len(stacks) > limit {
+		stacks = stacks[:limit]
+	}
+	return stacks
+}
+
+// newRunSeed 随机选择一局游戏的种子
+func newRunSeed() int64 {
+	return int64(rand.Intn(1000000))
+}
+
+// startRun 用种子生成新的世界并开始一局游戏，已经解锁的内容在开始时生效
+func (g *Game) startRun(seed int64) {
+	if g.player != nil {
+		g.closeInventory()
+	}
+	g.mining.Reset()
+	g.lastPlacePos = [2]int{-1, -1}
+
+	g.generateWorld(seed)
+	g.placeTrainingDummy()
+	g.applyLoadout()
+	g.applyUnlocks()
+
+	g.run = NewRun(seed)
+	g.run.Descend(g.playerGridY())
+	g.runUnlocks = nil
+	g.perkRng = newPerkRng(seed)
+	g.perkOffer, g.pendingPerks = nil, 0
+	g.showNotice(fmt.Sprintf("Run started (seed %d)", seed))
+}
+
+// runLoadout 每一局开始时物品栏中的物品（沙盒模式的测试物品不会带进一局游戏），其他起始物品需要解锁
+func runLoadout() []entity.ItemStack {
+	return []entity.ItemStack{entity.NewToolStack(entity.WoodenPickaxe)}
+}
+
+// applyLoadout 清空物品栏并放入一局游戏的起始物品
+func (g *Game) applyLoadout() {
+	inventory := g.player.GetInventory()
+	inventory.Clear()
+	for _, stack := range runLoadout() {
+		inventory.AddStack(stack)
+	}
+	inventory.SetSelectedSlot(0)
+}
+
+// applyUnlocks 把已经解锁的起始物品放进物品栏，并加上额外的生命值
+func (g *Game) applyUnlocks() {
+	if g.meta == nil {
+		return
+	}
+	inventory := g.player.GetInventory()
+	for _, unlock := range g.unlocks {
+		if !g.meta.IsUnlocked(unlock.Name) {
+			continue
+		}
+		for _, stack := range unlock.Items {
+			inventory.AddItem(stack.Type, stack.Count)
+		}
+		g.player.MaxHealth += unlock.MaxHealth
+	}
+	g.player.Health = g.player.MaxHealth
+}
+
+// checkRunOver 玩家在一局游戏中死亡时结束这一局：记录跨局进度并保存，返回这一局是否已经结束
+func (g *Game) checkRunOver() bool {
+	if g.run == nil {
+		return false
+	}
+	if g.run.Over {
+		return true
+	}
+	if !g.player.IsDead() {
+		return false
+	}
+
+	g.run.Over = true
+	g.closeInventory()
+	g.mining.Reset()
+	if g.meta != nil {
+		g.runUnlocks = g.meta.RecordRun(g.run, g.unlocks)
+		if g.metaPath != "" {
+			if err := g.meta.Save(g.metaPath); err != nil {
+				g.showNotice("Saving progress failed: " + err.Error())
+			}
+		}
+	}
+	return true
+}
+
+// updateRun 推进这一局的计时，玩家进入更深的层时显示提示并提供天赋
+func (g *Game) updateRun() {
+	if g.run == nil || g.run.Over {
+		return
+	}
+	g.run.Frames++
+	if g.run.Descend(g.playerGridY()) {
+		g.showNotice(fmt.Sprintf("Floor %d", g.run.Floor))
+		g.offerPerks()
+	}
+}
+
+// playerGridY 获取玩家所在的网格深度
+func (g *Game) playerGridY() int {
+	_, y := g.player.GetPosition()
+	return int(math.Floor(y / entity.BlockSize))
+}
+
+// drawRunStatus 在屏幕右上角显示这一局的层数、击杀数和时间
+func (g *Game) drawRunStatus(screen *ebiten.Image) {
+	if g.run == nil || g.run.Over {
+		return
+	}
+	text := fmt.Sprintf("Floor %d  Kills %d  %s", g.run.Floor, g.run.Kills, g.run.Duration())
+	ebitenutil.DebugPrintAt(screen, text, 800-len(text)*6-10, 10)
+}
+
+// drawRunSummary 一局结束后显示结算：种子、深度、击杀、时间、拾取的物品和新解锁的内容
+func (g *Game) drawRunSummary(screen *ebiten.Image) {
+	if g.run == nil || !g.run.Over {
+		return
+	}
+	ebitenutil.DrawRect(screen, 0, 0, 800, 600, color.RGBA{0, 0, 0, 180})
+
+	lines := []string{
+		"RUN OVER",
+		"",
+		fmt.Sprintf("Seed: %d", g.run.Seed),
+		fmt.Sprintf("Deepest floor: %d (depth %d)", g.run.Floor, g.run.MaxDepth),
+		fmt.Sprintf("Kills: %d  Bosses: %d", g.run.Kills, g.run.BossKills),
+		fmt.Sprintf("Time: %s", g.run.Duration()),
+		fmt.Sprintf("Perks: %d", len(g.player.Perks)),
+		"",
+		"Loot:",
+	}
+	loot := g.run.LootSummary(runLootLines)
+	if len(loot) == 0 {
+		lines = append(lines, "  nothing")
+	}
+	for _, stack := range loot {
+		lines = append(lines, fmt.Sprintf("  %s x%d", entity.GetItemProperties(stack.Type).DisplayName, stack.Count))
+	}
+	if len(g.runUnlocks) > 0 {
+		lines = append(lines, "", "Unlocked:")
+		for _, unlock := range g.runUnlocks {
+			lines = append(lines, fmt.Sprintf("  %s - %s", unlock.DisplayName, unlock.Description))
+		}
+	}
+	lines = append(lines, "", "Press Enter to start a new run")
+
+	for i, line := range lines {
+		ebitenutil.DebugPrintAt(screen, line, 260, 120+i*16)
+	}
+}
@@ -0,0 +1,138 @@
+package game
+
+import (
+	"path/filepath"
+	"testing"
+
+	"mygo/internal/pkg/entity"
+	"mygo/internal/pkg/world"
+)
+
+func TestRunFloorsAndRecord(t *testing.T) {
+	run := NewRun(42)
+	if run.Descend(-3) || run.Floor != 1 {
+		t.Error("Expected the surface to be the first floor")
+	}
+	if !run.Descend(runFloorDepth*2) || run.Floor != 3 {
+		t.Errorf("Expected to reach floor 3, got %d", run.Floor)
+	}
+	if run.Descend(runFloorDepth) || run.Floor != 3 || run.MaxDepth != runFloorDepth*2 {
+		t.Error("Expected climbing back up to keep the deepest floor")
+	}
+
+	run.Record(world.Event{Kind: world.EventMobKilled})
+	run.Record(world.Event{Kind: world.EventMobKilled})
+	run.Record(world.Event{Kind: world.EventBossDefeated})
+	run.Record(world.Event{Kind: world.EventItemPickedUp, Item: entity.Dirt, Count: 2})
+	run.Record(world.Event{Kind: world.EventItemPickedUp, Item: entity.Coal, Count: 5})
+	run.Record(world.Event{Kind: world.EventItemPickedUp, Item: entity.Dirt, Count: 1})
+	if run.Kills != 2 || run.BossKills != 1 {
+		t.Errorf("Expected 2 kills and 1 boss, got %d and %d", run.Kills, run.BossKills)
+	}
+	loot := run.LootSummary(1)
+	if len(loot) != 1 || loot[0].Type != entity.Coal || loot[0].Count != 5 {
+		t.Errorf("Expected coal to lead the loot summary, got %v", loot)
+	}
+
+	run.Frames = 60*125 + 30
+	if run.Duration() != "02:05" {
+		t.Errorf("Expected 02:05, got %s", run.Duration())
+	}
+}
+
+func TestStartRunIsSeededAndAppliesUnlocks(t *testing.T) {
+	torch, _ := entity.ParseItemName("torch")
+	newRunGame := func() *Game {
+		g := newTestGame()
+		g.meta = &MetaProgress{Unlocked: []string{"kit"}}
+		g.unlocks = []Unlock{
+			{Name: "kit", Items: []entity.ItemStack{{Type: torch, Count: 3}}, MaxHealth: 4},
+			{Name: "locked", Items: []entity.ItemStack{{Type: entity.Coal, Count: 1}}},
+		}
+		g.startRun(2024)
+		return g
+	}
+
+	g := newRunGame()
+	if g.run == nil || g.run.Seed != 2024 || g.run.Over {
+		t.Fatal("Expected a run with the given seed")
+	}
+	if g.player != g.world.Player {
+		t.Error("Expected the game to follow the new world's player")
+	}
+	inventory := g.player.GetInventory()
+	if inventory.CountItem(torch) != 3 || inventory.CountItem(entity.Coal) != 0 {
+		t.Error("Expected only unlocked starting items")
+	}
+	// 沙盒模式的测试物品不会带进一局游戏
+	if inventory.CountItem(entity.Stone) != 0 || inventory.CountItem(entity.SwiftnessPotion) != 0 {
+		t.Error("Expected the run to start from the run loadout")
+	}
+	if slot := inventory.GetSlot(0); slot.Type != entity.WoodenPickaxe || slot.Durability == 0 {
+		t.Errorf("Expected the run to start with a wooden pickaxe, got %v", slot)
+	}
+	if g.player.MaxHealth != entity.PlayerMaxHealth+4 || g.player.Health != g.player.MaxHealth {
+		t.Errorf("Expected bonus health, got %d/%d", g.player.Health, g.player.MaxHealth)
+	}
+
+	// 同一个种子生成相同的世界
+	other := newRunGame()
+	if len(other.world.Blocks) != len(g.world.Blocks) {
+		t.Fatal("Expected the same seed to generate the same world")
+	}
+	for key, block := range g.world.Blocks {
+		if otherBlock, exists := other.world.Blocks[key]; !exists || otherBlock.GetType() != block.GetType() {
+			t.Fatalf("Expected the same block at %s", key)
+		}
+	}
+}
+
+func TestRunEndsOnDeathAndRecordsProgress(t *testing.T) {
+	g := newTestGame()
+	g.meta = &MetaProgress{}
+	g.metaPath = filepath.Join(t.TempDir(), "meta.json")
+	g.unlocks = []Unlock{
+		{Name: "first", Requires: UnlockRequirement{Runs: 1}},
+		{Name: "hunter", Requires: UnlockRequirement{TotalKills: 5}},
+	}
+	g.run = NewRun(7)
+	g.run.Kills = 3
+	g.run.Descend(runFloorDepth)
+
+	if g.checkRunOver() {
+		t.Fatal("Expected the run to continue while the player is alive")
+	}
+	g.player.TakeDamage(g.player.Health, entity.DamageFall)
+	if !g.checkRunOver() || !g.run.Over {
+		t.Fatal("Expected death to end the run")
+	}
+	if len(g.runUnlocks) != 1 || g.runUnlocks[0].Name != "first" {
+		t.Errorf("Expected the first run to unlock only \"first\", got %v", g.runUnlocks)
+	}
+
+	// 永久死亡：世界不再更新，玩家不会重生
+	if !g.player.IsDead() {
+		t.Error("Expected the player to stay dead")
+	}
+
+	saved, err := LoadMeta(g.metaPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.Runs != 1 || saved.BestFloor != 2 || saved.TotalKills != 3 || !saved.IsUnlocked("first") {
+		t.Errorf("Unexpected saved progress %+v", saved)
+	}
+}
+
+func TestRunDisablesSaveAndLoad(t *testing.T) {
+	g := newTestGame()
+	g.run = NewRun(1)
+	g.saveWorld()
+	if g.notice != "Saving is disabled during a run" {
+		t.Errorf("Expected saving to be refused during a run, got %q", g.notice)
+	}
+	g.loadWorld()
+	if g.notice != "Loading is disabled during a run" {
+		t.Errorf("Expected loading to be refused during a run, got %q", g.notice)
+	}
+}
@@ -0,0 +1,61 @@
+package game
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+)
+
+const (
+	// savePath 世界存档的文件路径
+	savePath = "saves/world.json"
+	// noticeDisplay 提示信息的显示帧数
+	noticeDisplay = 120
+)
+
+// saveWorld 把世界（包括箱子、熔炉等方块的状态）保存到存档文件，一局游戏中不能保存
+func (g *Game) saveWorld() {
+	if g.run != nil {
+		g.showNotice("Saving is disabled during a run")
+		return
+	}
+	if err := g.world.Save(savePath); err != nil {
+		g.showNotice("Save failed: " + err.Error())
+		return
+	}
+	g.showNotice("World saved")
+}
+
+// loadWorld 从存档文件加载世界，加载前关闭打开的界面；一局游戏中不能加载，避免绕过永久死亡
+func (g *Game) loadWorld() {
+	if g.run != nil {
+		g.showNotice("Loading is disabled during a run")
+		return
+	}
+	g.closeInventory()
+	g.mining.Reset()
+	if err := g.world.Load(savePath); err != nil {
+		g.showNotice("Load failed: " + err.Error())
+		return
+	}
+	g.camera.SetTarget(g.player.GetPosition())
+	g.showNotice("World loaded")
+}
+
+// showNotice 在屏幕上方显示一段时间的提示信息
+func (g *Game) showNotice(text string) {
+	g.notice = text
+	g.noticeTimer = noticeDisplay
+}
+
+// drawNotice 绘制提示信息
+func (g *Game) drawNotice(screen *ebiten.Image) {
+	if g.noticeTimer <= 0 {
+		return
+	}
+	width := len(g.notice)*6 + 12
+	x := (800 - width) / 2
+	ebitenutil.DrawRect(screen, float64(x), 40, float64(width), 18, color.RGBA{20, 20, 20, 200})
+	ebitenutil.DebugPrintAt(screen, g.notice, x+6, 41)
+}
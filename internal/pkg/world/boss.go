@@ -0,0 +1,166 @@
+package world
+
+import (
+	"math"
+
+	"mygo/internal/pkg/entity"
+)
+
+// Arena 首领竞技场：玩家进入时生成首领，战斗期间用屏障封住边界上的所有出口
+type Arena struct {
+	Left, Top, Right, Bottom int    // 竞技场内部的网格范围（包含边界）
+	Boss                     string // 玩家进入时生成的首领，为空表示只能通过召唤开始战斗
+	Summoned                 bool   // 由召唤物品生成的竞技场，战斗结束后被移除
+	Active                   bool   // 战斗是否正在进行
+	Defeated                 bool   // 首领是否已经被击败，击败后不会再生成
+	barriers                 []entity.GridPos
+}
+
+// Contains 检查网格位置是否在竞技场内部
+func (a *Arena) Contains(x, y int) bool {
+	return x >= a.Left && x <= a.Right && y >= a.Top && y <= a.Bottom
+}
+
+// Barriers 获取战斗期间放置的屏障位置
+func (a *Arena) Barriers() []entity.GridPos {
+	return a.barriers
+}
+
+// AddArena 添加首领竞技场到世界（例如世界生成的地牢）
+func (w *World) AddArena(arena *Arena) {
+	w.Arenas = append(w.Arenas, arena)
+}
+
+// SummonBoss 在网格位置（玩家脚下）周围生成竞技场并开始与首领的战斗，已经有首领战在进行时返回false
+func (w *World) SummonBoss(name string, x, y int) bool {
+	props, exists := entity.Bosses.Get(name)
+	if !exists || w.Boss != nil {
+		return false
+	}
+	left := x - props.ArenaWidth/2
+	arena := &Arena{
+		Left:     left,
+		Top:      y - props.ArenaHeight + 1,
+		Right:    left + props.ArenaWidth - 1,
+		Bottom:   y,
+		Summoned: true,
+	}
+	if _, started := w.StartBossFight(name, arena); !started {
+		return false
+	}
+	w.AddArena(arena)
+	return true
+}
+
+// StartBossFight 在竞技场中生成首领并封闭竞技场：首领出现在竞技场中间一列最低的空位上
+// 已经有首领战在进行或竞技场中放不下首领时返回false
+func (w *World) StartBossFight(name string, arena *Arena) (*entity.Boss, bool) {
+	if w.Boss != nil {
+		return nil, false
+	}
+	centerX := (float64(arena.Left+arena.Right)/2 + 0.5) * entity.BlockSize
+	boss, exists := entity.NewBoss(name, centerX, 0)
+	if !exists {
+		return nil, false
+	}
+	boss.SetWorld(w)
+
+	fits := false
+	height := boss.Props().Height
+	for y := arena.Bottom; y >= arena.Top && !fits; y-- {
+		boss.Y = float64(y+1)*entity.BlockSize - height/2
+		fits = boss.Fits()
+	}
+	if !fits {
+		return nil, false
+	}
+
+	w.Boss = boss
+	w.bossArena = arena
+	arena.Active = true
+	w.lockArena(arena)
+	w.emit(Event{Kind: EventBossStarted, Boss: name, X: boss.X, Y: boss.Y})
+	return boss, true
+}
+
+// lockArena 在竞技场边界外一圈的空位上放置屏障，封住所有出口
+func (w *World) lockArena(arena *Arena) {
+	arena.barriers = arena.barriers[:0]
+	for y := arena.Top - 1; y <= arena.Bottom+1; y++ {
+		for x := arena.Left - 1; x <= arena.Right+1; x++ {
+			if arena.Contains(x, y) || w.IsBlockAt(x, y) {
+				continue
+			}
+			w.AddBlockWithType(x, y, entity.ArenaBarrierBlock)
+			arena.barriers = append(arena.barriers, entity.GridPos{X: x, Y: y})
+		}
+	}
+}
+
+// unlockArena 移除竞技场的屏障
+func (w *World) unlockArena(arena *Arena) {
+	for _, pos := range arena.barriers {
+		if block, exists := w.GetBlock(pos.X, pos.Y); exists && block.GetType() == entity.ArenaBarrierBlock {
+			w.ClearBlock(pos.X, pos.Y)
+		}
+	}
+	arena.barriers = nil
+	arena.Active = false
+}
+
+// endBossFight 结束当前的首领战：解除封闭，召唤生成的竞技场被移除
+func (w *World) endBossFight() {
+	arena := w.bossArena
+	w.unlockArena(arena)
+	if arena.Summoned {
+		for i, other := range w.Arenas {
+			if other == arena {
+				w.Arenas = append(w.Arenas[:i], w.Arenas[i+1:]...)
+				break
+			}
+		}
+	}
+	w.Boss, w.bossArena = nil, nil
+}
+
+// resetBossFight 玩家在首领战中死亡：首领消失，竞技场可以重新挑战
+func (w *World) resetBossFight() {
+	if w.Boss == nil {
+		return
+	}
+	boss := w.Boss
+	w.endBossFight()
+	w.emit(Event{Kind: EventBossReset, Boss: boss.Name, X: boss.X, Y: boss.Y})
+}
+
+// defeatBoss 首领被击败：在首领的位置掉落战利品，解除封闭并记录事件
+func (w *World) defeatBoss() {
+	boss, arena := w.Boss, w.bossArena
+	for _, stack := range boss.Props().RollLoot(w.rng) {
+		w.dropStack(stack, boss.X, boss.Y)
+	}
+	arena.Defeated = true
+	w.endBossFight()
+	w.emit(Event{Kind: EventBossDefeated, Boss: boss.Name, X: boss.X, Y: boss.Y})
+}
+
+// updateBoss 玩家进入地牢竞技场时开始首领战，更新正在战斗的首领，首领死亡时结束战斗
+func (w *World) updateBoss() {
+	if w.Boss == nil {
+		playerX, playerY := w.Player.GetPosition()
+		x := int(math.Floor(playerX / entity.BlockSize))
+		y := int(math.Floor(playerY / entity.BlockSize))
+		for _, arena := range w.Arenas {
+			if arena.Boss != "" && !arena.Defeated && arena.Contains(x, y) {
+				w.StartBossFight(arena.Boss, arena)
+				break
+			}
+		}
+		return
+	}
+
+	w.Boss.Update(w.Player)
+	if w.Boss.Dead {
+		w.defeatBoss()
+	}
+}
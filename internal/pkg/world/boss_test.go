@@ -0,0 +1,153 @@
+package world
+
+import (
+	"testing"
+
+	"mygo/internal/pkg/entity"
+)
+
+func TestSummonBossLocksArena(t *testing.T) {
+	w := newSpawnTestWorld()
+	if w.SummonBoss("no_such_boss", 0, 4) {
+		t.Fatal("Expected unknown bosses not to be summoned")
+	}
+	if !w.SummonBoss("slime_king", 0, 4) || w.Boss == nil || len(w.Arenas) != 1 {
+		t.Fatal("Expected the slime king to be summoned")
+	}
+	if w.SummonBoss("slime_king", 0, 4) {
+		t.Error("Expected only one boss fight at a time")
+	}
+
+	arena := w.Arenas[0]
+	if !arena.Active || !arena.Contains(0, 4) || arena.Right-arena.Left+1 != w.Boss.Props().ArenaWidth {
+		t.Errorf("Expected an active arena around the player, got %+v", arena)
+	}
+	if !w.Boss.Fits() || w.Boss.GridPos().Y != 4 {
+		t.Errorf("Expected the boss to stand on the floor, feet at %v", w.Boss.GridPos())
+	}
+
+	// 地面以外的边界都被屏障封住，屏障无法破坏
+	barrierX := arena.Left - 1
+	if block, exists := w.GetBlock(barrierX, 0); !exists || block.GetType() != entity.ArenaBarrierBlock {
+		t.Fatal("Expected the arena wall to be sealed")
+	}
+	if block, _ := w.GetBlock(barrierX, 5); block.GetType() != entity.StoneBlock {
+		t.Error("Expected existing blocks on the border to be kept")
+	}
+	w.RemoveBlock(barrierX, 0)
+	if !w.IsSolidAt(barrierX, 0) || len(w.GetAllItems()) != 0 {
+		t.Error("Expected barriers to be unbreakable")
+	}
+
+	events := w.PollEvents()
+	if len(events) != 1 || events[0].Kind != EventBossStarted || events[0].Boss != "slime_king" {
+		t.Errorf("Expected a boss started event, got %v", events)
+	}
+	if len(w.PollEvents()) != 0 {
+		t.Error("Expected polled events to be cleared")
+	}
+
+	// 首领可以被攻击
+	if target, ok := w.EntityAt(w.Boss.GridPos().X, w.Boss.GridPos().Y); !ok || target != w.Boss {
+		t.Error("Expected the boss to be found at its position")
+	}
+	found := false
+	for _, target := range w.Damageables() {
+		found = found || target == w.Boss
+	}
+	if !found {
+		t.Error("Expected the boss to be damageable")
+	}
+}
+
+func TestDefeatingBossDropsLootAndUnlocksArena(t *testing.T) {
+	w := newSpawnTestWorld()
+	w.SummonBoss("slime_king", 0, 4)
+	arena := w.Arenas[0]
+	barriers := arena.Barriers()
+	w.PollEvents()
+
+	w.Boss.Hit(w.Boss.MaxHealth, 0, 0)
+	w.Update()
+
+	if w.Boss != nil || len(w.Arenas) != 0 || !arena.Defeated || arena.Active {
+		t.Fatal("Expected the fight to end and the summoned arena to be removed")
+	}
+	for _, pos := range barriers {
+		if w.IsBlockAt(pos.X, pos.Y) {
+			t.Fatalf("Expected barrier at %v to be removed", pos)
+		}
+	}
+	ingot, _ := entity.ParseItemName("iron_ingot")
+	dropped := 0
+	for _, item := range w.GetAllItems() {
+		if item.GetItemType() == ingot {
+			dropped += item.GetCount()
+		}
+	}
+	if dropped < 4 {
+		t.Errorf("Expected iron ingots to drop, got %d", dropped)
+	}
+	events := w.PollEvents()
+	if len(events) != 1 || events[0].Kind != EventBossDefeated {
+		t.Errorf("Expected a boss defeated event, got %v", events)
+	}
+}
+
+func TestDungeonArenaStartsOnEntryAndResetsOnDeath(t *testing.T) {
+	w := newSpawnTestWorld()
+	w.AddArena(&Arena{Left: 10, Top: 0, Right: 20, Bottom: 4, Boss: "stone_golem"})
+	w.Player.SetSpawnPoint(16, 4*32+16)
+
+	w.Update()
+	if w.Boss != nil {
+		t.Fatal("Expected no fight before the player enters the arena")
+	}
+
+	w.Player.SetPosition(12*32, 4*32+16)
+	w.Update()
+	if w.Boss == nil || w.Boss.Name != "stone_golem" || !w.Arenas[0].Active {
+		t.Fatal("Expected entering the arena to start the fight")
+	}
+
+	w.Player.TakeDamage(w.Player.MaxHealth, entity.DamageVoid)
+	w.Update()
+	arena := w.Arenas[0]
+	if w.Boss != nil || arena.Active || arena.Defeated || len(arena.Barriers()) != 0 {
+		t.Fatal("Expected the fight to reset when the player dies")
+	}
+	if w.IsBlockAt(9, 2) {
+		t.Error("Expected the arena to be unlocked")
+	}
+	events := w.PollEvents()
+	if len(events) != 2 || events[1].Kind != EventBossReset {
+		t.Errorf("Expected started and reset events, got %v", events)
+	}
+}
+
+func TestSaveSkipsBarriersAndKeepsDungeonArenas(t *testing.T) {
+	w := newSpawnTestWorld()
+	w.AddArena(&Arena{Left: 10, Top: 0, Right: 20, Bottom: 4, Boss: "stone_golem", Defeated: true})
+	w.SummonBoss("slime_king", 0, 4)
+
+	data, err := w.Marshal()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	loaded := NewWorld()
+	if err := loaded.Unmarshal(data); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	for _, block := range loaded.GetAllBlocks() {
+		if block.GetType() == entity.ArenaBarrierBlock {
+			t.Fatal("Expected barriers not to be saved")
+		}
+	}
+	if loaded.Boss != nil || len(loaded.Arenas) != 1 {
+		t.Fatalf("Expected only the dungeon arena to be saved, got %d arenas", len(loaded.Arenas))
+	}
+	if arena := loaded.Arenas[0]; arena.Boss != "stone_golem" || !arena.Defeated || arena.Right != 20 {
+		t.Errorf("Unexpected loaded arena %+v", arena)
+	}
+}
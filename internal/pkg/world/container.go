@@ -0,0 +1,48 @@
+package world
+
+import (
+	"math/rand"
+
+	"mygo/internal/pkg/entity"
+)
+
+// GetContainer 获取指定网格位置方块的物品容器
+func (w *World) GetContainer(x, y int) (*entity.Container, bool) {
+	block, exists := w.GetBlock(x, y)
+	if !exists || block.Container == nil {
+		return nil, false
+	}
+	return block.Container, true
+}
+
+// PlaceChest 在指定位置放置一个箱子，并用战利品填充（供生成的建筑和地牢使用）
+// 位置已有方块时不放置；放不下的战利品会被丢弃
+func (w *World) PlaceChest(x, y int, loot []entity.ItemStack, rng *rand.Rand) bool {
+	if w.IsBlockAt(x, y) {
+		return false
+	}
+	w.AddBlockWithType(x, y, entity.ChestBlock)
+	return w.FillContainer(x, y, loot, rng)
+}
+
+// FillContainer 把战利品随机放入指定位置方块的容器中
+func (w *World) FillContainer(x, y int, loot []entity.ItemStack, rng *rand.Rand) bool {
+	container, exists := w.GetContainer(x, y)
+	if !exists {
+		return false
+	}
+	container.Fill(loot, rng)
+	return true
+}
+
+// spillContainer 清空容器，把其中的物品作为掉落物撒在指定位置
+func (w *World) spillContainer(container *entity.Container, x, y float64) {
+	w.spillStacks(container.Clear(), x, y)
+}
+
+// spillStacks 把物品堆叠作为掉落物撒在指定位置
+func (w *World) spillStacks(stacks []entity.ItemStack, x, y float64) {
+	for _, stack := range stacks {
+		w.dropStack(stack, x, y)
+	}
+}
@@ -0,0 +1,38 @@
+package world
+
+import (
+	"math/rand"
+	"testing"
+
+	"mygo/internal/pkg/entity"
+)
+
+func TestWorldChestSpillsContentsWhenBroken(t *testing.T) {
+	w := NewWorld()
+	if !w.PlaceChest(2, 3, []entity.ItemStack{{Type: entity.Rope, Count: 5}, {Type: entity.IronAxe, Count: 1}}, rand.New(rand.NewSource(7))) {
+		t.Fatal("Expected chest to be placed")
+	}
+	if w.PlaceChest(2, 3, nil, rand.New(rand.NewSource(7))) {
+		t.Error("Expected chest not to be placed on an occupied position")
+	}
+
+	container, ok := w.GetContainer(2, 3)
+	if !ok || container.CountItem(entity.Rope) != 5 {
+		t.Fatal("Expected chest to be filled with loot")
+	}
+
+	w.RemoveBlock(2, 3)
+	counts := make(map[entity.ItemType]int)
+	for _, item := range w.GetAllItems() {
+		counts[item.GetItemType()] += item.GetCount()
+		if item.GetItemType() == entity.IronAxe && item.Durability == 0 {
+			t.Error("Expected spilled tool to keep its durability")
+		}
+	}
+	if counts[entity.Chest] != 1 || counts[entity.Rope] != 5 || counts[entity.IronAxe] != 1 {
+		t.Errorf("Expected chest and its contents to drop, got %v", counts)
+	}
+	if _, ok := w.GetContainer(2, 3); ok {
+		t.Error("Expected container to be gone with the block")
+	}
+}
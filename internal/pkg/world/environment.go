@@ -0,0 +1,118 @@
+package world
+
+import (
+	"math"
+
+	"mygo/internal/pkg/entity"
+)
+
+const (
+	DayLength        = 36000 // 一天的帧数（10分钟），前半天是白天，后半天是夜晚
+	MaxLight         = 15    // 最大亮度
+	NightSkyLight    = 4     // 夜晚的天空亮度
+	TorchLight       = 14    // 火把所在格的亮度，每远离一格减1
+	SkyScanHeight    = 64    // 向上检查天空是否被遮挡的格数
+	DefaultBiome     = BiomePlains
+	dawnDuskDuration = 1800 // 日出和日落时天空亮度渐变的帧数
+)
+
+// Biome 生物群落，由地形生成时按列记录
+type Biome int
+
+const (
+	BiomePlains Biome = iota // 草地
+	BiomeDesert              // 沙漠
+	BiomeSnow                // 雪原
+)
+
+// String 返回生物群落的名称
+func (b Biome) String() string {
+	switch b {
+	case BiomePlains:
+		return "Plains"
+	case BiomeDesert:
+		return "Desert"
+	case BiomeSnow:
+		return "Snow"
+	}
+	return "Unknown"
+}
+
+// SetBiome 设置某一列的生物群落
+func (w *World) SetBiome(x int, biome Biome) {
+	w.Biomes[x] = biome
+}
+
+// BiomeAt 获取某一列的生物群落，没有记录的列视为草地
+func (w *World) BiomeAt(x int) Biome {
+	if biome, exists := w.Biomes[x]; exists {
+		return biome
+	}
+	return DefaultBiome
+}
+
+// TimeOfDay 获取一天中的时刻（帧），0到DayLength
+func (w *World) TimeOfDay() int {
+	return w.Time % DayLength
+}
+
+// IsNight 返回当前是否是夜晚
+func (w *World) IsNight() bool {
+	return w.TimeOfDay() >= DayLength/2
+}
+
+// SkyLight 获取当前的天空亮度，日出和日落时在白天和夜晚的亮度之间渐变
+func (w *World) SkyLight() int {
+	t := w.TimeOfDay()
+	var daylight float64
+	switch {
+	case t < DayLength/2-dawnDuskDuration:
+		daylight = 1
+	case t < DayLength/2:
+		daylight = float64(DayLength/2-t) / dawnDuskDuration
+	case t < DayLength-dawnDuskDuration:
+		daylight = 0
+	default:
+		daylight = float64(t-(DayLength-dawnDuskDuration)) / dawnDuskDuration
+	}
+	return NightSkyLight + int(math.Round(daylight*(MaxLight-NightSkyLight)))
+}
+
+// IsSkyVisible 检查网格位置上方是否没有实心方块遮挡
+func (w *World) IsSkyVisible(x, y int) bool {
+	for checkY := y - 1; checkY >= y-SkyScanHeight; checkY-- {
+		if w.IsSolidAt(x, checkY) {
+			return false
+		}
+	}
+	return true
+}
+
+// LightAt 获取网格位置的亮度：露天的位置受天空照亮，附近的火把按距离提供亮度，取两者中较亮的
+func (w *World) LightAt(x, y int) int {
+	light := 0
+	if !w.IsSolidAt(x, y) && w.IsSkyVisible(x, y) {
+		light = w.SkyLight()
+	}
+
+	for dy := -TorchLight; dy <= TorchLight; dy++ {
+		for dx := -TorchLight; dx <= TorchLight; dx++ {
+			distance := abs(dx) + abs(dy)
+			if TorchLight-distance <= light {
+				continue
+			}
+			if block, exists := w.GetBlock(x+dx, y+dy); exists && block.GetType() == entity.TorchBlock {
+				light = TorchLight - distance
+			}
+		}
+	}
+	return light
+}
+
+// abs 整数绝对值
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
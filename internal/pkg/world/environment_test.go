@@ -0,0 +1,64 @@
+package world
+
+import (
+	"testing"
+
+	"mygo/internal/pkg/entity"
+)
+
+func TestDayNightCycle(t *testing.T) {
+	w := NewWorld()
+	if w.IsNight() || w.SkyLight() != MaxLight {
+		t.Error("Expected the world to start at bright day")
+	}
+
+	w.Time = DayLength/2 + dawnDuskDuration
+	if !w.IsNight() || w.SkyLight() != NightSkyLight {
+		t.Errorf("Expected dark night, got light %d", w.SkyLight())
+	}
+
+	// 日落时亮度在白天和夜晚之间
+	w.Time = DayLength/2 - dawnDuskDuration/2
+	if light := w.SkyLight(); light <= NightSkyLight || light >= MaxLight {
+		t.Errorf("Expected dusk light between night and day, got %d", light)
+	}
+
+	// 时间按天循环
+	w.Time = 3*DayLength + 10
+	if w.TimeOfDay() != 10 || w.IsNight() {
+		t.Error("Expected time of day to wrap every day")
+	}
+}
+
+func TestLightLevels(t *testing.T) {
+	w := NewWorld()
+	w.AddBlockWithType(0, 0, entity.StoneBlock)
+
+	if w.LightAt(1, 1) != MaxLight {
+		t.Error("Expected open sky to be fully lit during the day")
+	}
+	if w.LightAt(0, 1) != 0 {
+		t.Error("Expected a covered cell to be dark")
+	}
+
+	w.AddBlockWithType(3, 1, entity.TorchBlock)
+	if w.LightAt(0, 1) != TorchLight-3 {
+		t.Errorf("Expected torch light to fade with distance, got %d", w.LightAt(0, 1))
+	}
+
+	w.Time = DayLength/2 + dawnDuskDuration
+	if w.LightAt(-20, 1) != NightSkyLight {
+		t.Error("Expected open sky to be dim at night")
+	}
+}
+
+func TestBiomes(t *testing.T) {
+	w := NewWorld()
+	if w.BiomeAt(7) != DefaultBiome {
+		t.Error("Expected unknown columns to use the default biome")
+	}
+	w.SetBiome(7, BiomeDesert)
+	if w.BiomeAt(7) != BiomeDesert || BiomeDesert.String() != "Desert" {
+		t.Error("Expected biome to be recorded per column")
+	}
+}
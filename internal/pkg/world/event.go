@@ -0,0 +1,36 @@
+package world
+
+import "mygo/internal/pkg/entity"
+
+// EventKind 世界事件的种类
+type EventKind int
+
+const (
+	EventBossStarted  EventKind = iota // 首领战开始，竞技场被封闭
+	EventBossDefeated                  // 首领被击败，掉落战利品，竞技场解除封闭
+	EventBossReset                     // 玩家在首领战中死亡，首领消失，竞技场解除封闭
+	EventMobKilled                     // 生物死亡并从世界中移除
+	EventItemPickedUp                  // 玩家拾取了掉落物
+)
+
+// Event 世界在更新中发生的事件，由游戏在每帧取出后显示提示等
+type Event struct {
+	Kind  EventKind
+	Boss  string          // 相关首领的名称
+	Mob   entity.MobKind  // 被击杀的生物种类
+	Item  entity.ItemType // 拾取的物品
+	Count int             // 拾取的数量
+	X, Y  float64         // 事件发生的位置
+}
+
+// emit 记录一个事件
+func (w *World) emit(event Event) {
+	w.Events = append(w.Events, event)
+}
+
+// PollEvents 取出并清空上次调用以来发生的所有事件
+func (w *World) PollEvents() []Event {
+	events := w.Events
+	w.Events = nil
+	return events
+}
@@ -0,0 +1,42 @@
+package world
+
+import (
+	"math"
+
+	"mygo/internal/pkg/entity"
+)
+
+// blockLootTable 破坏方块时使用的战利品表名称
+func blockLootTable(blockType entity.BlockType) string {
+	return "blocks/" + entity.GetBlockName(blockType)
+}
+
+// mobLootTable 生物死亡时使用的战利品表名称
+func mobLootTable(kind entity.MobKind) string {
+	return "mobs/" + entity.GetMobName(kind)
+}
+
+// lootContext 创建网格位置的战利品环境：深度、所在的生物群落和使用的工具，随机数来自世界
+func (w *World) lootContext(x, y int, tool entity.ToolKind) entity.LootContext {
+	return entity.LootContext{Tool: tool, Depth: y, Biome: w.BiomeAt(x).String(), Rng: w.rng}
+}
+
+// dropLoot 抽取战利品表并在(x, y)生成掉落物，战利品表不存在时返回false
+func (w *World) dropLoot(table string, x, y float64, ctx entity.LootContext) bool {
+	stacks, exists := entity.LootTables.Roll(table, ctx)
+	if !exists {
+		return false
+	}
+	for _, stack := range stacks {
+		w.dropStack(stack, x, y)
+	}
+	return true
+}
+
+// dropMobLoot 被击杀的生物按战利品表在原地掉落物品
+func (w *World) dropMobLoot(mob *entity.Mob) {
+	x, y := mob.GetPosition()
+	gridX := int(math.Floor(x / entity.BlockSize))
+	gridY := int(math.Floor(y / entity.BlockSize))
+	w.dropLoot(mobLootTable(mob.Kind), x, y, w.lootContext(gridX, gridY, entity.ToolNone))
+}
@@ -0,0 +1,103 @@
+package world
+
+import (
+	"testing"
+
+	"mygo/internal/pkg/entity"
+)
+
+// useLootTables 在测试期间替换全局战利品表
+func useLootTables(t *testing.T, data string) {
+	registry, err := entity.ParseLootTables([]byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	previous := entity.LootTables
+	entity.LootTables = registry
+	t.Cleanup(func() { entity.LootTables = previous })
+}
+
+// itemCounts 统计世界中各种掉落物的数量
+func itemCounts(w *World) map[entity.ItemType]int {
+	counts := make(map[entity.ItemType]int)
+	for _, item := range w.GetAllItems() {
+		counts[item.GetItemType()] += item.Count
+	}
+	return counts
+}
+
+func TestBreakBlockUsesLootTable(t *testing.T) {
+	useLootTables(t, `{"tables": [{"name": "blocks/stone", "pools": [
+		{"entries": [{"item": "stone"}]},
+		{"conditions": {"tools": ["pickaxe"]}, "entries": [{"item": "coal", "min": 2, "max": 2}]},
+		{"conditions": {"min_depth": 20}, "entries": [{"item": "iron_ingot"}]}
+	]}]}`)
+
+	w := NewWorld()
+	w.AddBlockWithType(0, 0, entity.StoneBlock)
+	w.AddBlockWithType(1, 0, entity.StoneBlock)
+	w.AddBlockWithType(2, 30, entity.StoneBlock)
+	w.AddBlockWithType(3, 0, entity.DirtBlock)
+
+	w.BreakBlock(0, 0, entity.ToolNone)
+	w.BreakBlock(1, 0, entity.ToolPickaxe)
+	w.BreakBlock(2, 30, entity.ToolNone)
+	w.RemoveBlock(3, 0) // 没有战利品表的方块掉落对应的物品
+
+	counts := itemCounts(w)
+	if counts[entity.Stone] != 3 || counts[entity.Coal] != 2 || counts[entity.IronIngot] != 1 || counts[entity.Dirt] != 1 {
+		t.Errorf("Unexpected drops %v", counts)
+	}
+}
+
+func TestBreakBlockLootIsSeeded(t *testing.T) {
+	drops := func() map[entity.ItemType]int {
+		w := NewWorld()
+		w.SetSeed(11)
+		for x := 0; x < 100; x++ {
+			w.AddBlockWithType(x, 0, entity.LeavesBlock)
+			w.BreakBlock(x, 0, entity.ToolAxe)
+		}
+		return itemCounts(w)
+	}
+	a, b := drops(), drops()
+	if a[entity.Leaves] != 100 {
+		t.Errorf("Expected every leaves block to drop leaves, got %d", a[entity.Leaves])
+	}
+	if len(a) < 2 {
+		t.Errorf("Expected leaves to occasionally drop extras, got %v", a)
+	}
+	for item, count := range a {
+		if b[item] != count {
+			t.Errorf("Expected the same seed to drop the same loot, got %v and %v", a, b)
+			break
+		}
+	}
+}
+
+func TestDropsScatterWithWorldRng(t *testing.T) {
+	drop := func() *entity.ItemEntity {
+		w := NewWorld()
+		w.SetSeed(3)
+		return w.dropStack(entity.ItemStack{Type: entity.Rope, Count: 2}, 0, 0)
+	}
+	a, b := drop(), drop()
+	if a.VX != b.VX || a.VY != b.VY {
+		t.Errorf("Expected the same seed to scatter drops the same way, got (%v, %v) and (%v, %v)", a.VX, a.VY, b.VX, b.VY)
+	}
+}
+
+func TestKilledMobDropsLoot(t *testing.T) {
+	useLootTables(t, `{"tables": [{"name": "mobs/slime", "pools": [{"entries": [{"item": "rope", "min": 3, "max": 3}]}]}]}`)
+
+	w := NewWorld()
+	w.MobSpawning = false
+	w.Player.SetPosition(0, 0)
+	mob := w.SpawnMob(entity.MobSlime, 10, 0)
+	mob.TakeDamage(mob.MaxHealth)
+	w.Update()
+
+	if counts := itemCounts(w); counts[entity.Rope] != 3 {
+		t.Errorf("Expected killed slime to drop 3 rope, got %v", counts)
+	}
+}
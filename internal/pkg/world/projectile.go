@@ -0,0 +1,37 @@
+package world
+
+import "mygo/internal/pkg/entity"
+
+// AddProjectile 添加投射物到世界
+func (w *World) AddProjectile(projectile *entity.Projectile) {
+	projectile.SetWorld(w)
+	w.Entities.Add(projectile)
+}
+
+// GetAllProjectiles 获取所有投射物
+func (w *World) GetAllProjectiles() []*entity.Projectile {
+	projectiles := make([]*entity.Projectile, 0)
+	for _, e := range w.Entities.All() {
+		if projectile, ok := e.(*entity.Projectile); ok {
+			projectiles = append(projectiles, projectile)
+		}
+	}
+	return projectiles
+}
+
+// pickupProjectiles 玩家靠近插在方块上的投射物时回收为物品（物品栏放不下时留在原处）
+// 投射物的飞行和命中由实体管理器更新，敌对投射物只会命中玩家
+func (w *World) pickupProjectiles() {
+	playerX, playerY := w.Player.GetPosition()
+	reach := entity.PlayerSize + 2*entity.ProjectilePickupRange
+	for _, e := range w.Entities.QueryRect(entity.NewHitbox(playerX, playerY, reach+2, reach+2)) {
+		projectile, ok := e.(*entity.Projectile)
+		if !ok || !projectile.CanPickup(w.Player) {
+			continue
+		}
+		itemType, ok := projectile.Props().ItemType()
+		if ok && w.Player.GetInventory().AddItem(itemType, 1) == 0 {
+			w.Entities.Remove(projectile)
+		}
+	}
+}
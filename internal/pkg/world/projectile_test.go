@@ -0,0 +1,64 @@
+package world
+
+import (
+	"testing"
+
+	"mygo/internal/pkg/entity"
+)
+
+func TestWorldProjectilesHitMobsAndAreRecovered(t *testing.T) {
+	w := newSpawnTestWorld()
+	arrow, _ := entity.ParseItemName("arrow")
+
+	// 射向生物的箭命中后消失
+	mob := w.SpawnMob(entity.MobCaveCrawler, 6, 4)
+	w.AddProjectile(entity.NewProjectile(entity.ProjectileArrow, 4*32, mob.Y, 12, 0, 4, 3))
+	for i := 0; i < 10; i++ {
+		w.Update()
+	}
+	if mob.Health != mob.MaxHealth-4 || len(w.GetAllProjectiles()) != 0 {
+		t.Fatalf("Expected the arrow to hit the crawler, health %d projectiles %d", mob.Health, len(w.GetAllProjectiles()))
+	}
+
+	// 插在玩家脚下地面上的箭被回收为物品
+	w.AddProjectile(entity.NewProjectile(entity.ProjectileArrow, 20, 4*32+20, 0, 10, 4, 3))
+	for i := 0; i < 5 && len(w.GetAllProjectiles()) > 0; i++ {
+		w.Update()
+	}
+	if len(w.GetAllProjectiles()) != 0 || w.Player.GetInventory().CountItem(arrow) != 1 {
+		t.Error("Expected the stuck arrow to be picked up")
+	}
+
+	// 物品栏满时箭留在原处
+	inventory := w.Player.GetInventory()
+	for i := range inventory.Slots {
+		inventory.Slots[i] = entity.ItemStack{Type: entity.Bomb, Count: entity.GetMaxStackSize(entity.Bomb)}
+	}
+	w.AddProjectile(entity.NewProjectile(entity.ProjectileArrow, 20, 4*32+20, 0, 10, 4, 3))
+	for i := 0; i < 5; i++ {
+		w.Update()
+	}
+	if len(w.GetAllProjectiles()) != 1 || !w.GetAllProjectiles()[0].Stuck {
+		t.Error("Expected the arrow to stay stuck when the inventory is full")
+	}
+}
+
+func TestWorldHostileProjectilesOnlyHitPlayer(t *testing.T) {
+	w := newSpawnTestWorld()
+	mob := w.SpawnMob(entity.MobCaveCrawler, 3, 4)
+	_, playerY := w.Player.GetPosition()
+
+	// 从生物身后射向玩家的敌对箭穿过生物命中玩家
+	arrow := entity.NewProjectile(entity.ProjectileArrow, 5*32, playerY, -12, 0, 3, 2)
+	arrow.Hostile = true
+	w.AddProjectile(arrow)
+	for i := 0; i < 20 && len(w.GetAllProjectiles()) > 0; i++ {
+		w.Update()
+	}
+	if mob.Health != mob.MaxHealth {
+		t.Error("Expected the hostile arrow to pass through the mob")
+	}
+	if w.Player.Health != w.Player.MaxHealth-3 || len(w.GetAllProjectiles()) != 0 {
+		t.Errorf("Expected the hostile arrow to hit the player, health %d", w.Player.Health)
+	}
+}
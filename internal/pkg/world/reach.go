@@ -0,0 +1,95 @@
+package world
+
+import (
+	"math"
+
+	"mygo/internal/pkg/entity"
+)
+
+// Raycast 沿线段(x0, y0)-(x1, y1)逐格前进，返回遇到的第一个实心方块的网格坐标
+// 非实心方块（梯子、藤蔓等）不会遮挡视线
+func (w *World) Raycast(x0, y0, x1, y1 float64) (int, int, bool) {
+	gridX := int(math.Floor(x0 / entity.BlockSize))
+	gridY := int(math.Floor(y0 / entity.BlockSize))
+	endX := int(math.Floor(x1 / entity.BlockSize))
+	endY := int(math.Floor(y1 / entity.BlockSize))
+
+	dx, dy := x1-x0, y1-y0
+	stepX, stepY := 1, 1
+	if dx < 0 {
+		stepX = -1
+	}
+	if dy < 0 {
+		stepY = -1
+	}
+
+	// 沿每个轴到达下一条网格线所需的参数t，以及跨过一整格所需的t
+	tMaxX, tDeltaX := math.Inf(1), math.Inf(1)
+	if dx != 0 {
+		nextX := float64(gridX) * entity.BlockSize
+		if stepX > 0 {
+			nextX += entity.BlockSize
+		}
+		tMaxX = (nextX - x0) / dx
+		tDeltaX = entity.BlockSize / math.Abs(dx)
+	}
+	tMaxY, tDeltaY := math.Inf(1), math.Inf(1)
+	if dy != 0 {
+		nextY := float64(gridY) * entity.BlockSize
+		if stepY > 0 {
+			nextY += entity.BlockSize
+		}
+		tMaxY = (nextY - y0) / dy
+		tDeltaY = entity.BlockSize / math.Abs(dy)
+	}
+
+	for {
+		if w.IsSolidAt(gridX, gridY) {
+			return gridX, gridY, true
+		}
+		if gridX == endX && gridY == endY {
+			return 0, 0, false
+		}
+		if tMaxX < tMaxY {
+			if tMaxX > 1 {
+				return 0, 0, false
+			}
+			gridX += stepX
+			tMaxX += tDeltaX
+		} else {
+			if tMaxY > 1 {
+				return 0, 0, false
+			}
+			gridY += stepY
+			tMaxY += tDeltaY
+		}
+	}
+}
+
+// CanReach 检查玩家能否操作指定网格位置：在触及距离内，且视线没有被其他实心方块挡住
+func (w *World) CanReach(gridX, gridY int) bool {
+	centerX := (float64(gridX) + 0.5) * entity.BlockSize
+	centerY := (float64(gridY) + 0.5) * entity.BlockSize
+	playerX, playerY := w.Player.GetPosition()
+
+	if math.Hypot(centerX-playerX, centerY-playerY) > w.Player.Reach {
+		return false
+	}
+
+	hitX, hitY, hit := w.Raycast(playerX, playerY, centerX, centerY)
+	return !hit || (hitX == gridX && hitY == gridY)
+}
+
+// IsOccupied 检查网格位置是否与玩家、首领、生物或训练假人等有碰撞的实体重叠，
+// 用于阻止把实体卡进方块里；掉落物和插在方块上的投射物不会阻挡放置
+func (w *World) IsOccupied(gridX, gridY int) bool {
+	left := float64(gridX) * entity.BlockSize
+	top := float64(gridY) * entity.BlockSize
+	cell := entity.Hitbox{Left: left, Top: top, Right: left + entity.BlockSize, Bottom: top + entity.BlockSize}
+
+	playerX, playerY := w.Player.GetPosition()
+	if entity.NewHitbox(playerX, playerY, entity.PlayerSize, entity.PlayerSize).Overlaps(cell) {
+		return true
+	}
+	return len(w.DamageablesIn(cell)) > 0
+}
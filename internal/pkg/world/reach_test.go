@@ -0,0 +1,82 @@
+package world
+
+import (
+	"testing"
+
+	"mygo/internal/pkg/entity"
+)
+
+func TestRaycastStopsAtFirstSolidBlock(t *testing.T) {
+	w := NewWorld()
+	w.AddBlockWithType(3, 0, entity.StoneBlock)
+	w.AddBlockWithType(5, 0, entity.StoneBlock)
+	w.AddBlockWithType(1, 0, entity.LadderBlock)
+
+	x, y, hit := w.Raycast(16, 16, 6*32+16, 16)
+	if !hit || x != 3 || y != 0 {
+		t.Errorf("Expected ray to hit (3, 0), got (%d, %d) hit=%v", x, y, hit)
+	}
+
+	if _, _, hit := w.Raycast(16, 16, 2*32+16, 16); hit {
+		t.Error("Expected non-solid blocks not to block the ray")
+	}
+}
+
+func TestCanReach(t *testing.T) {
+	w := NewWorld()
+	w.Player.SetPosition(16, 16)
+	w.AddBlockWithType(2, 0, entity.StoneBlock)
+	w.AddBlockWithType(3, 0, entity.StoneBlock)
+
+	if !w.CanReach(2, 0) {
+		t.Error("Expected first visible block to be reachable")
+	}
+	if w.CanReach(3, 0) {
+		t.Error("Expected block behind another block to be unreachable")
+	}
+	if w.CanReach(0, 10) {
+		t.Error("Expected block beyond reach distance to be unreachable")
+	}
+
+	w.Player.Reach = 20 * entity.BlockSize
+	if !w.CanReach(0, 10) {
+		t.Error("Expected a longer reach to allow distant targets")
+	}
+}
+
+func TestIsOccupied(t *testing.T) {
+	w := NewWorld()
+	w.Player.SetPosition(16, 16)
+	if !w.IsOccupied(0, 0) {
+		t.Error("Expected the player's cell to be occupied")
+	}
+	if w.IsOccupied(2, 0) {
+		t.Error("Expected an empty cell not to be occupied")
+	}
+
+	w.AddItem(&entity.ItemEntity{X: 2*32 + 16, Y: 16, ItemType: entity.Stone, Count: 1})
+	if w.IsOccupied(2, 0) {
+		t.Error("Expected a dropped item not to block placement")
+	}
+	arrow := entity.NewProjectile(entity.ProjectileArrow, 3*32+16, 16, 0, 0, 1, 0)
+	arrow.Stuck = true
+	w.AddProjectile(arrow)
+	if w.IsOccupied(3, 0) {
+		t.Error("Expected a stuck projectile not to block placement")
+	}
+
+	w.MobSpawning = false
+	w.SpawnMob(entity.MobSlime, 5, 5)
+	if !w.IsOccupied(5, 5) {
+		t.Error("Expected a cell with a mob to be occupied")
+	}
+	w.AddEntity(entity.NewTrainingDummy(8*32+16, 16))
+	if !w.IsOccupied(8, 0) {
+		t.Error("Expected a cell with the training dummy to be occupied")
+	}
+	boss, _ := entity.NewBoss("slime_king", 12*32+16, 16)
+	w.Boss = boss
+	if !w.IsOccupied(12, 0) {
+		t.Error("Expected a cell with the boss to be occupied")
+	}
+}
@@ -0,0 +1,224 @@
+package world
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"mygo/internal/pkg/entity"
+)
+
+// saveVersion 存档格式的版本号：版本2起物品和方块类型按名称保存，版本1按编号保存
+const saveVersion = 2
+
+// oldestSaveVersion 仍然可以读取的最旧存档版本
+const oldestSaveVersion = 1
+
+// worldSave 世界存档：方块（包括容器和方块实体的状态）、玩家、时间、生物群落和地牢竞技场
+// 掉落物、生物和正在进行的首领战不会被保存，竞技场的屏障也不会被保存
+type worldSave struct {
+	Version int           `json:"version"`
+	Time    int           `json:"time"`
+	Biomes  map[int]Biome `json:"biomes,omitempty"`
+	Player  playerSave    `json:"player"`
+	Blocks  []blockSave   `json:"blocks"`
+	Arenas  []arenaSave   `json:"arenas,omitempty"`
+}
+
+// arenaSave 地牢竞技场存档（召唤生成的竞技场只在战斗期间存在，不保存）
+type arenaSave struct {
+	Left     int    `json:"left"`
+	Top      int    `json:"top"`
+	Right    int    `json:"right"`
+	Bottom   int    `json:"bottom"`
+	Boss     string `json:"boss"`
+	Defeated bool   `json:"defeated,omitempty"`
+}
+
+// playerSave 玩家存档，天赋按名称保存
+type playerSave struct {
+	X            float64            `json:"x"`
+	Y            float64            `json:"y"`
+	SpawnX       float64            `json:"spawn_x"`
+	SpawnY       float64            `json:"spawn_y"`
+	Health       int                `json:"health"`
+	SelectedSlot int                `json:"selected_slot"`
+	Inventory    []entity.ItemStack `json:"inventory"`
+	Perks        []string           `json:"perks,omitempty"`
+}
+
+// blockSave 方块存档，容器的物品和方块实体的状态与方块一起保存
+type blockSave struct {
+	X         int                `json:"x"`
+	Y         int                `json:"y"`
+	Type      entity.BlockType   `json:"type"`
+	Container []entity.ItemStack `json:"container,omitempty"`
+	Entity    json.RawMessage    `json:"entity,omitempty"`
+}
+
+// Marshal 把世界编码为存档数据
+func (w *World) Marshal() ([]byte, error) {
+	inventory := w.Player.GetInventory()
+	save := worldSave{
+		Version: saveVersion,
+		Time:    w.Time,
+		Biomes:  w.Biomes,
+		Player: playerSave{
+			X:            w.Player.X,
+			Y:            w.Player.Y,
+			SpawnX:       w.Player.SpawnX,
+			SpawnY:       w.Player.SpawnY,
+			Health:       w.Player.Health,
+			SelectedSlot: inventory.GetSelectedSlot(),
+			Inventory:    inventory.Slots,
+			Perks:        w.Player.Perks,
+		},
+		Blocks: make([]blockSave, 0, len(w.Blocks)),
+	}
+
+	for key, block := range w.Blocks {
+		if block.GetType() == entity.ArenaBarrierBlock {
+			continue
+		}
+		x, y := block.GetGridPosition()
+		entry := blockSave{X: x, Y: y, Type: block.GetType()}
+		if block.Container != nil {
+			entry.Container = block.Container.Slots
+		}
+		if blockEntity, exists := w.BlockEntities[key]; exists {
+			data, err := json.Marshal(blockEntity)
+			if err != nil {
+				return nil, fmt.Errorf("block entity at %s: %w", key, err)
+			}
+			entry.Entity = data
+		}
+		save.Blocks = append(save.Blocks, entry)
+	}
+
+	for _, arena := range w.Arenas {
+		if arena.Summoned {
+			continue
+		}
+		save.Arenas = append(save.Arenas, arenaSave{
+			Left:     arena.Left,
+			Top:      arena.Top,
+			Right:    arena.Right,
+			Bottom:   arena.Bottom,
+			Boss:     arena.Boss,
+			Defeated: arena.Defeated,
+		})
+	}
+
+	// 按位置排序，保证同一个世界的存档内容相同
+	sort.Slice(save.Blocks, func(i, j int) bool {
+		if save.Blocks[i].Y != save.Blocks[j].Y {
+			return save.Blocks[i].Y < save.Blocks[j].Y
+		}
+		return save.Blocks[i].X < save.Blocks[j].X
+	})
+
+	return json.Marshal(save)
+}
+
+// Unmarshal 从存档数据恢复世界，替换当前所有的方块、方块实体、掉落物、生物、投射物和竞技场（训练假人等其他实体保留），正在进行的首领战被取消
+// 存档数据先全部解码到临时的方块表中，全部成功后才替换世界的状态，出错时世界保持不变
+func (w *World) Unmarshal(data []byte) error {
+	var save worldSave
+	if err := json.Unmarshal(data, &save); err != nil {
+		return err
+	}
+	if save.Version < oldestSaveVersion || save.Version > saveVersion {
+		return fmt.Errorf("unsupported save version %d", save.Version)
+	}
+
+	blocks := make(map[string]*entity.Block, len(save.Blocks))
+	blockEntities := make(map[string]entity.BlockEntity)
+	for _, entry := range save.Blocks {
+		key := blockKey(entry.X, entry.Y)
+		block := entity.NewBlockWithType(entry.X, entry.Y, entry.Type)
+		if block.Container != nil {
+			copy(block.Container.Slots, entry.Container)
+		}
+		blocks[key] = block
+
+		blockEntity, ok := entity.NewBlockEntity(entry.Type)
+		if !ok {
+			continue
+		}
+		if len(entry.Entity) > 0 {
+			if err := json.Unmarshal(entry.Entity, blockEntity); err != nil {
+				return fmt.Errorf("block entity at %d,%d: %w", entry.X, entry.Y, err)
+			}
+		}
+		blockEntities[key] = blockEntity
+	}
+
+	arenas := make([]*Arena, 0, len(save.Arenas))
+	for _, arena := range save.Arenas {
+		arenas = append(arenas, &Arena{
+			Left:     arena.Left,
+			Top:      arena.Top,
+			Right:    arena.Right,
+			Bottom:   arena.Bottom,
+			Boss:     arena.Boss,
+			Defeated: arena.Defeated,
+		})
+	}
+	biomes := make(map[int]Biome, len(save.Biomes))
+	for x, biome := range save.Biomes {
+		biomes[x] = biome
+	}
+
+	// 解码全部成功，替换世界的状态
+	w.Blocks = blocks
+	w.BlockEntities = blockEntities
+	for _, e := range w.Entities.All() {
+		switch e.(type) {
+		case *entity.ItemEntity, *entity.Mob, *entity.Projectile:
+			w.Entities.Remove(e)
+		}
+	}
+	w.Entities.Flush()
+	w.Biomes = biomes
+	w.Paths.Clear()
+	w.Arenas = arenas
+	w.Boss, w.bossArena = nil, nil
+	w.Time = save.Time
+
+	inventory := w.Player.GetInventory()
+	inventory.Clear()
+	copy(inventory.Slots, save.Player.Inventory)
+	inventory.SetSelectedSlot(save.Player.SelectedSlot)
+	w.Player.SetPosition(save.Player.X, save.Player.Y)
+	w.Player.VX, w.Player.VY = 0, 0
+	w.hasSafePos = false
+	w.Player.SetSpawnPoint(save.Player.SpawnX, save.Player.SpawnY)
+	if save.Player.Health > 0 {
+		w.Player.Health = save.Player.Health
+	}
+	w.Player.Perks = append([]string(nil), save.Player.Perks...)
+	return nil
+}
+
+// Save 把世界保存到文件，目录不存在时自动创建
+func (w *World) Save(path string) error {
+	data, err := w.Marshal()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// Load 从文件加载世界
+func (w *World) Load(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return w.Unmarshal(data)
+}
@@ -0,0 +1,154 @@
+package world
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"mygo/internal/pkg/entity"
+)
+
+func TestWorldSaveRoundTrip(t *testing.T) {
+	w := NewWorld()
+	w.AddBlockWithType(0, 5, entity.StoneBlock)
+	w.AddBlockWithType(1, 5, entity.FurnaceBlock)
+	w.PlaceChest(2, 4, []entity.ItemStack{{Type: entity.Rope, Count: 7}}, rand.New(rand.NewSource(3)))
+
+	blockEntity, _ := w.GetBlockEntity(1, 5)
+	furnace := blockEntity.(*entity.FurnaceEntity)
+	furnace.Slots[entity.FurnaceInput] = entity.ItemStack{Type: entity.IronOre, Count: 4}
+	furnace.Slots[entity.FurnaceFuel] = entity.ItemStack{Type: entity.Coal, Count: 2}
+	for i := 0; i < 50; i++ {
+		furnace.Tick()
+	}
+
+	w.Player.SetPosition(40, 60)
+	w.Player.GetInventory().SetSlot(4, entity.ItemStack{Type: entity.IronIngot, Count: 9})
+	w.Player.AddPerk("magnet")
+	w.Player.AddPerk("magnet")
+	w.AddItem(entity.NewItemEntity(0, 0, entity.Dirt, 1))
+	w.SpawnMob(entity.MobSlime, 0, 4)
+	w.SetBiome(-3, BiomeSnow)
+	w.Time = DayLength + 123
+
+	data, err := w.Marshal()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	loaded := NewWorld()
+	if err := loaded.Unmarshal(data); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if loaded.Time != w.Time || loaded.BiomeAt(-3) != BiomeSnow || len(loaded.GetAllMobs()) != 0 {
+		t.Error("Expected time and biomes to be saved, but not mobs")
+	}
+	if len(loaded.Blocks) != 3 || len(loaded.GetAllItems()) != 0 {
+		t.Errorf("Expected 3 blocks and no drops, got %d blocks and %d drops", len(loaded.Blocks), len(loaded.GetAllItems()))
+	}
+	container, ok := loaded.GetContainer(2, 4)
+	if !ok || container.CountItem(entity.Rope) != 7 {
+		t.Error("Expected chest contents to be restored")
+	}
+
+	loadedEntity, ok := loaded.GetBlockEntity(1, 5)
+	if !ok {
+		t.Fatal("Expected furnace block entity to be restored")
+	}
+	loadedFurnace := loadedEntity.(*entity.FurnaceEntity)
+	if loadedFurnace.CookTime != furnace.CookTime || loadedFurnace.BurnTime != furnace.BurnTime {
+		t.Error("Expected furnace progress to be restored")
+	}
+	if loadedFurnace.Slots != furnace.Slots {
+		t.Error("Expected furnace slots to be restored")
+	}
+
+	x, y := loaded.Player.GetPosition()
+	if x != 40 || y != 60 || loaded.Player.GetInventory().GetSlot(4).Count != 9 {
+		t.Error("Expected player position and inventory to be restored")
+	}
+	if loaded.Player.PerkStacks("magnet") != 2 {
+		t.Errorf("Expected player perks to be restored, got %v", loaded.Player.Perks)
+	}
+}
+
+func TestWorldUnmarshalErrors(t *testing.T) {
+	w := NewWorld()
+	if err := w.Unmarshal([]byte("not json")); err == nil {
+		t.Error("Expected error for invalid data")
+	}
+	if err := w.Unmarshal([]byte(`{"version": 99}`)); err == nil {
+		t.Error("Expected error for unsupported version")
+	}
+}
+
+func TestWorldUnmarshalVersion1(t *testing.T) {
+	// 版本1的存档按编号保存物品和方块类型
+	data := fmt.Sprintf(`{"version": 1, "time": 10, "player": {"x": 40, "y": 60, "health": 50,
+		"inventory": [{"Type": %d, "Count": 5}]},
+		"blocks": [{"x": 0, "y": 5, "type": %d}, {"x": 1, "y": 5, "type": %d, "container": [{"Type": %d, "Count": 2}]}]}`,
+		entity.Rope, entity.StoneBlock, entity.ChestBlock, entity.Coal)
+
+	w := NewWorld()
+	if err := w.Unmarshal([]byte(data)); err != nil {
+		t.Fatalf("Expected a version 1 save to load, got %v", err)
+	}
+	if block, ok := w.GetBlock(0, 5); !ok || block.GetType() != entity.StoneBlock {
+		t.Error("Expected numeric block types to be restored")
+	}
+	if container, ok := w.GetContainer(1, 5); !ok || container.CountItem(entity.Coal) != 2 {
+		t.Error("Expected chest contents to be restored")
+	}
+	if slot := w.Player.GetInventory().GetSlot(0); slot.Type != entity.Rope || slot.Count != 5 {
+		t.Errorf("Expected numeric item types to be restored, got %v", slot)
+	}
+}
+
+func TestWorldUnmarshalErrorKeepsWorld(t *testing.T) {
+	w := NewWorld()
+	w.MobSpawning = false
+	w.AddBlockWithType(0, 5, entity.StoneBlock)
+	w.SpawnMob(entity.MobSlime, 2, 4)
+	w.Time = 500
+
+	good, err := NewWorld().Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var save map[string]interface{}
+	if err := json.Unmarshal(good, &save); err != nil {
+		t.Fatal(err)
+	}
+	// 第二个方块的方块实体数据损坏
+	save["time"] = 42
+	save["blocks"] = []interface{}{
+		map[string]interface{}{"x": 7, "y": 7, "type": blockTypeJSON(t, entity.StoneBlock)},
+		map[string]interface{}{"x": 8, "y": 7, "type": blockTypeJSON(t, entity.FurnaceBlock), "entity": "broken"},
+	}
+	data, err := json.Marshal(save)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := w.Unmarshal(data); err == nil {
+		t.Fatal("Expected error for a broken block entity")
+	}
+	if !w.IsBlockAt(0, 5) || w.IsBlockAt(7, 7) || len(w.GetAllMobs()) != 1 || w.Time != 500 {
+		t.Error("Expected a failed load to leave the world unchanged")
+	}
+}
+
+// blockTypeJSON 获取方块类型在存档中的JSON表示
+func blockTypeJSON(t *testing.T, blockType entity.BlockType) interface{} {
+	data, err := json.Marshal(blockType)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		t.Fatal(err)
+	}
+	return value
+}
@@ -0,0 +1,187 @@
+package world
+
+import (
+	"math"
+	"math/rand"
+
+	"mygo/internal/pkg/entity"
+)
+
+const (
+	MaxMobs          = 12 // 世界中同时存在的生物上限
+	SpawnInterval    = 60 // 两次尝试生成生物之间的帧数
+	SpawnAttempts    = 4  // 每次尝试生成时随机选择的位置数量
+	SpawnMinDistance = 20 // 生成位置与玩家的最小距离（格），避免生物出现在视野中
+	SpawnMaxDistance = 40 // 生成位置与玩家的最大距离（格）
+	DespawnDistance  = 56 // 生物离玩家超过该距离（格）时被移除
+	SpawnSearchDepth = 8  // 从随机位置向下寻找可站立地面的最大格数
+)
+
+// TimeRule 生成规则对时间的要求
+type TimeRule int
+
+const (
+	AnyTime   TimeRule = iota // 任何时间
+	DayOnly                   // 只在白天
+	NightOnly                 // 只在夜晚
+)
+
+// SpawnRule 生物的生成规则：位置的亮度、生物群落、深度和当前时间都满足时才会生成
+type SpawnRule struct {
+	Kind     entity.MobKind
+	Biomes   []Biome // 允许的生物群落，为空表示不限
+	MinLight int
+	MaxLight int
+	MinY     int // 允许的网格深度范围（y越大越深）
+	MaxY     int
+	Time     TimeRule
+	Weight   int // 多条规则都满足时被选中的权重
+}
+
+// SpawnRules 默认的生物生成规则
+var SpawnRules = []SpawnRule{
+	// 史莱姆在夜晚出现在草地和雪原的地表
+	{Kind: entity.MobSlime, Biomes: []Biome{BiomePlains, BiomeSnow}, MinLight: 0, MaxLight: 7, MinY: -64, MaxY: 20, Time: NightOnly, Weight: 10},
+	// 洞穴爬虫生活在黑暗的地下深处，不受时间影响
+	{Kind: entity.MobCaveCrawler, MinLight: 0, MaxLight: 4, MinY: 25, MaxY: 64, Time: AnyTime, Weight: 8},
+	// 小动物在白天出现在明亮的草地上
+	{Kind: entity.MobCritter, Biomes: []Biome{BiomePlains}, MinLight: 12, MaxLight: MaxLight, MinY: -64, MaxY: 20, Time: DayOnly, Weight: 6},
+}
+
+// Allows 检查规则是否允许在网格位置（生物脚下方块的上方一格）生成生物
+func (r SpawnRule) Allows(w *World, x, y int) bool {
+	if y < r.MinY || y > r.MaxY {
+		return false
+	}
+	switch r.Time {
+	case DayOnly:
+		if w.IsNight() {
+			return false
+		}
+	case NightOnly:
+		if !w.IsNight() {
+			return false
+		}
+	}
+	if len(r.Biomes) > 0 {
+		allowed := false
+		for _, biome := range r.Biomes {
+			if w.BiomeAt(x) == biome {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return false
+		}
+	}
+	light := w.LightAt(x, y)
+	return light >= r.MinLight && light <= r.MaxLight
+}
+
+// SpawnMob 在网格位置生成生物，生物站在该格的底部
+func (w *World) SpawnMob(kind entity.MobKind, x, y int) *entity.Mob {
+	props := entity.GetMobProperties(kind)
+	centerX := (float64(x) + 0.5) * entity.BlockSize
+	centerY := float64(y+1)*entity.BlockSize - props.Height/2
+	mob := entity.NewMob(kind, centerX, centerY)
+	w.AddMob(mob)
+	return mob
+}
+
+// AddMob 添加生物到世界
+func (w *World) AddMob(mob *entity.Mob) {
+	mob.SetWorld(w)
+	w.Entities.Add(mob)
+}
+
+// canSpawnAt 检查网格位置是否可以站立一个生物：脚下是实心方块，所在格和上方一格都是空的
+func (w *World) canSpawnAt(x, y int) bool {
+	return w.IsSolidAt(x, y+1) && !w.IsBlockAt(x, y) && !w.IsBlockAt(x, y-1)
+}
+
+// TrySpawnAt 按生成规则在网格位置尝试生成生物，返回生成的生物
+func (w *World) TrySpawnAt(x, y int, rng *rand.Rand) (*entity.Mob, bool) {
+	if !w.canSpawnAt(x, y) {
+		return nil, false
+	}
+
+	allowed := make([]SpawnRule, 0, len(SpawnRules))
+	total := 0
+	for _, rule := range SpawnRules {
+		if rule.Weight > 0 && rule.Allows(w, x, y) {
+			allowed = append(allowed, rule)
+			total += rule.Weight
+		}
+	}
+	if total == 0 {
+		return nil, false
+	}
+
+	pick := rng.Intn(total)
+	for _, rule := range allowed {
+		if pick < rule.Weight {
+			return w.SpawnMob(rule.Kind, x, y), true
+		}
+		pick -= rule.Weight
+	}
+	return nil, false
+}
+
+// spawnMobs 在玩家周围的环形区域内随机选择位置尝试生成生物
+func (w *World) spawnMobs() {
+	if !w.MobSpawning || len(w.GetAllMobs()) >= MaxMobs || w.Time%SpawnInterval != 0 {
+		return
+	}
+
+	playerX, playerY := w.Player.GetPosition()
+	centerX := int(math.Floor(playerX / entity.BlockSize))
+	centerY := int(math.Floor(playerY / entity.BlockSize))
+	for i := 0; i < SpawnAttempts; i++ {
+		dx := SpawnMinDistance + w.rng.Intn(SpawnMaxDistance-SpawnMinDistance+1)
+		if w.rng.Intn(2) == 0 {
+			dx = -dx
+		}
+		dy := w.rng.Intn(2*SpawnMaxDistance+1) - SpawnMaxDistance
+		x := centerX + dx
+		y, found := w.findGround(x, centerY+dy)
+		if !found {
+			continue
+		}
+		if _, spawned := w.TrySpawnAt(x, y, w.rng); spawned {
+			return
+		}
+	}
+}
+
+// findGround 从网格位置向下寻找第一个可以站立的位置
+func (w *World) findGround(x, y int) (int, bool) {
+	for depth := 0; depth < SpawnSearchDepth; depth++ {
+		if w.canSpawnAt(x, y+depth) {
+			return y + depth, true
+		}
+	}
+	return y, false
+}
+
+// despawnMobs 移除离玩家太远的生物，死亡的生物由实体管理器移除
+func (w *World) despawnMobs() {
+	playerX, playerY := w.Player.GetPosition()
+	despawn := float64(DespawnDistance * entity.BlockSize)
+	for _, mob := range w.GetAllMobs() {
+		if math.Abs(mob.X-playerX) > despawn || math.Abs(mob.Y-playerY) > despawn {
+			w.Entities.Remove(mob)
+		}
+	}
+}
+
+// GetAllMobs 获取所有活着的生物
+func (w *World) GetAllMobs() []*entity.Mob {
+	mobs := make([]*entity.Mob, 0)
+	for _, e := range w.Entities.All() {
+		if mob, ok := e.(*entity.Mob); ok {
+			mobs = append(mobs, mob)
+		}
+	}
+	return mobs
+}
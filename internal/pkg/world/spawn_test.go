@@ -0,0 +1,111 @@
+package world
+
+import (
+	"math/rand"
+	"testing"
+
+	"mygo/internal/pkg/entity"
+)
+
+// newSpawnTestWorld 创建只有一行地面（y=5）的世界，玩家站在x=0处
+func newSpawnTestWorld() *World {
+	w := NewWorld()
+	w.MobSpawning = false
+	for x := -60; x <= 60; x++ {
+		w.AddBlockWithType(x, 5, entity.StoneBlock)
+	}
+	w.Player.SetPosition(16, 4*32+16)
+	return w
+}
+
+func TestSpawnRulesDependOnEnvironment(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	// 白天明亮的草地上生成小动物
+	w := newSpawnTestWorld()
+	if mob, ok := w.TrySpawnAt(30, 4, rng); !ok || mob.Kind != entity.MobCritter {
+		t.Fatal("Expected a critter on a bright plains day")
+	}
+	if mob := w.GetAllMobs()[0]; mob.Y != 5*32-entity.GetMobProperties(entity.MobCritter).Height/2 {
+		t.Errorf("Expected mob to stand on the ground, got y=%v", mob.Y)
+	}
+
+	// 沙漠里白天什么也不生成
+	w.SetBiome(31, BiomeDesert)
+	if _, ok := w.TrySpawnAt(31, 4, rng); ok {
+		t.Error("Expected no spawns in a desert during the day")
+	}
+
+	// 夜晚的地表生成史莱姆
+	w.Time = DayLength/2 + dawnDuskDuration
+	if mob, ok := w.TrySpawnAt(32, 4, rng); !ok || mob.Kind != entity.MobSlime {
+		t.Error("Expected a slime at night")
+	}
+
+	// 地下深处的黑暗中生成洞穴爬虫，火把照亮后不再生成
+	for x := 38; x <= 42; x++ {
+		w.AddBlockWithType(x, 30, entity.StoneBlock)
+		w.AddBlockWithType(x, 26, entity.StoneBlock)
+	}
+	if mob, ok := w.TrySpawnAt(40, 29, rng); !ok || mob.Kind != entity.MobCaveCrawler {
+		t.Error("Expected a cave crawler deep underground")
+	}
+	w.AddBlockWithType(42, 29, entity.TorchBlock)
+	if _, ok := w.TrySpawnAt(39, 29, rng); ok {
+		t.Error("Expected torch light to prevent cave spawns")
+	}
+
+	// 没有可以站立的地面时不生成
+	if _, ok := w.TrySpawnAt(30, 0, rng); ok {
+		t.Error("Expected no spawn in mid air")
+	}
+}
+
+func TestWorldSpawnsAndDespawnsMobs(t *testing.T) {
+	w := newSpawnTestWorld()
+	w.MobSpawning = true
+	for i := 0; i < SpawnInterval*100 && len(w.GetAllMobs()) == 0; i++ {
+		w.Update()
+	}
+	if len(w.GetAllMobs()) == 0 {
+		t.Fatal("Expected the world to spawn mobs around the player")
+	}
+
+	w.MobSpawning = false
+	w.Player.SetPosition(float64(DespawnDistance+SpawnMaxDistance+10)*32, 4*32+16)
+	w.Update()
+	if len(w.GetAllMobs()) != 0 {
+		t.Error("Expected mobs far from the player to despawn")
+	}
+}
+
+func TestEntityAtFindsMobs(t *testing.T) {
+	w := newSpawnTestWorld()
+	mob := w.SpawnMob(entity.MobSlime, 3, 4)
+	if target, ok := w.EntityAt(3, 4); !ok || target != entity.Target(mob) {
+		t.Error("Expected the slime to be found in its cell")
+	}
+	if _, ok := w.EntityAt(3, 3); ok {
+		t.Error("Expected no entity above the slime")
+	}
+}
+
+func TestMobAIUsesWorldRng(t *testing.T) {
+	run := func() []float64 {
+		w := newSpawnTestWorld()
+		w.SetSeed(5)
+		mob := w.SpawnMob(entity.MobCritter, 40, 4)
+		positions := make([]float64, 0)
+		for i := 0; i < 600; i++ {
+			w.Update()
+			positions = append(positions, mob.X)
+		}
+		return positions
+	}
+	a, b := run(), run()
+	for i := range a {
+		if a[i] != b[i] {
+			t.Fatalf("Expected the same seed to move the mob the same way, differs at frame %d", i)
+		}
+	}
+}
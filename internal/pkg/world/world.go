@@ -2,21 +2,71 @@ package world
 
 import (
 	"fmt"
+	"math"
+	"math/rand"
 	"mygo/internal/pkg/entity"
 )
 
+const (
+	InventoryFullDisplay = 60 // 拾取失败后"物品栏已满"提示的显示帧数
+	LoadedRadius         = 64 // 以玩家为中心的加载半径（格），范围内的方块实体才会更新
+)
+
+// DeathMode 玩家死亡时物品栏的处理方式
+type DeathMode int
+
+const (
+	DeathDropInventory  DeathMode = iota // 在死亡位置掉落所有物品
+	DeathClearInventory                  // 直接清空物品栏
+)
+
+// deathModeNames 死亡模式在设置（命令行参数）中使用的名称
+var deathModeNames = map[string]DeathMode{
+	"drop":  DeathDropInventory,
+	"clear": DeathClearInventory,
+}
+
+// ParseDeathMode 根据名称查找死亡模式
+func ParseDeathMode(name string) (DeathMode, bool) {
+	mode, ok := deathModeNames[name]
+	return mode, ok
+}
+
 // World represents the game world
 type World struct {
-	Player *entity.Player
-	Blocks map[string]*entity.Block
-	Items  []*entity.ItemEntity // 掉落物列表
+	Player        *entity.Player
+	Blocks        map[string]*entity.Block
+	BlockEntities map[string]entity.BlockEntity // 按位置存放的方块实体（熔炉等）
+	Entities      *entity.EntityManager         // 掉落物、生物、投射物和训练假人等实体
+	Arenas        []*Arena                      // 首领竞技场
+	Boss          *entity.Boss                  // 正在战斗的首领，没有首领战时为nil
+	Events        []Event                       // 尚未被取出的事件
+	Biomes        map[int]Biome                 // 每一列的生物群落
+	Paths         *entity.PathCache             // 寻路结果缓存，方块变化时失效
+	Time          int                           // 世界经过的帧数，决定昼夜
+	DeathMode     DeathMode                     // 玩家死亡时的物品处理方式
+	ItemLifetime  int                           // 掉落物的存活时间（帧）
+	MagnetRadius  float64                       // 掉落物飞向玩家的吸附半径，0表示不吸附
+	MobSpawning   bool                          // 是否按生成规则自动生成生物
+	FullTimer     int                           // 物品栏已满提示的剩余显示帧数
+	rng           *rand.Rand                    // 生物生成、首领战利品和战利品表使用的随机数
+	bossArena     *Arena                        // 正在进行首领战的竞技场
+	safeX, safeY  float64                       // 玩家最后站在地面上的位置
+	hasSafePos    bool                          // 是否记录了玩家最后站立的位置
 }
 
 // NewWorld creates a new world
 func NewWorld() *World { 
 	world := &World{
-		Blocks: make(map[string]*entity.Block),
-		Items:  make([]*entity.ItemEntity, 0),
+		Blocks:        make(map[string]*entity.Block),
+		BlockEntities: make(map[string]entity.BlockEntity),
+		Entities:      entity.NewEntityManager(),
+		Biomes:        make(map[int]Biome),
+		Paths:         entity.NewPathCache(),
+		ItemLifetime:  entity.ItemLifetime,
+		MagnetRadius:  entity.ItemMagnetRange,
+		MobSpawning:   true,
+		rng:           rand.New(rand.NewSource(rand.Int63())),
 	}
 	
 	// 创建玩家并设置世界引用
@@ -26,6 +76,16 @@ func NewWorld() *World {
 	return world
 }
 
+// SetSeed 设置世界随机数（生物生成和首领战利品）的种子，同一个种子的一局游戏可以重现
+func (w *World) SetSeed(seed int64) {
+	w.rng = rand.New(rand.NewSource(seed))
+}
+
+// Rng 获取世界的随机数（实现entity.EntityWorld），生物的AI和掉落物弹出的速度使用它
+func (w *World) Rng() *rand.Rand {
+	return w.rng
+}
+
 // AddBlock adds a block to the world
 func (w *World) AddBlock(x, y int) {
 	w.AddBlockWithType(x, y, entity.StoneBlock)
@@ -36,14 +96,27 @@ func (w *World) AddBlockWithType(x, y int, blockType entity.BlockType) {
 	key := blockKey(x, y)
 	if _, exists := w.Blocks[key]; !exists {
 		w.Blocks[key] = entity.NewBlockWithType(x, y, blockType)
+		
+		// 需要随时间工作的方块创建对应的方块实体
+		if blockEntity, ok := entity.NewBlockEntity(blockType); ok {
+			w.BlockEntities[key] = blockEntity
+		}
+		w.Paths.Invalidate(x, y)
 	}
 }
 
 // RemoveBlock removes a block from the world and creates a drop item
+// 无法破坏的方块（竞技场屏障）不会被移除
 func (w *World) RemoveBlock(x, y int) {
+	w.BreakBlock(x, y, entity.ToolNone)
+}
+
+// BreakBlock 用指定种类的工具破坏方块，方块有战利品表时按战利品表掉落物品，
+// 否则掉落方块对应的物品
+func (w *World) BreakBlock(x, y int, tool entity.ToolKind) {
 	key := blockKey(x, y)
 	block, exists := w.Blocks[key]
-	if !exists {
+	if !exists || entity.GetBlockProperties(block.GetType()).Unbreakable {
 		return
 	}
 	
@@ -53,12 +126,36 @@ func (w *World) RemoveBlock(x, y int) {
 	itemX := blockX + float64(entity.BlockSize)/2
 	itemY := blockY + float64(entity.BlockSize)/2
 	blockType := block.GetType()
-	item := entity.NewItemEntityFromBlock(itemX, itemY, blockType, 1)
-	item.SetWorld(w)
-	w.Items = append(w.Items, item)
+	// 没有战利品表的方块掉落放置它的物品，没有对应物品时不掉落
+	if !w.dropLoot(blockLootTable(blockType), itemX, itemY, w.lootContext(x, y, tool)) {
+		if itemType, ok := entity.GetBlockItem(blockType); ok {
+			w.dropStack(entity.ItemStack{Type: itemType, Count: 1}, itemX, itemY)
+		}
+	}
+	
+	// 带容器的方块（如箱子）把里面的物品撒出来
+	if block.Container != nil {
+		w.spillContainer(block.Container, itemX, itemY)
+	}
+	if blockEntity, exists := w.BlockEntities[key]; exists {
+		w.spillStacks(blockEntity.Clear(), itemX, itemY)
+		delete(w.BlockEntities, key)
+	}
 	
 	// 移除方块
 	delete(w.Blocks, key)
+	w.Paths.Invalidate(x, y)
+}
+
+// ClearBlock 直接移除方块，不产生掉落物（用于世界生成和解除竞技场屏障）
+func (w *World) ClearBlock(x, y int) {
+	key := blockKey(x, y)
+	if _, exists := w.Blocks[key]; !exists {
+		return
+	}
+	delete(w.Blocks, key)
+	delete(w.BlockEntities, key)
+	w.Paths.Invalidate(x, y)
 }
 
 // GetBlock returns a block at the specified position
@@ -83,59 +180,316 @@ func (w *World) IsBlockAt(x, y int) bool {
 	return exists
 }
 
+// IsSolidAt 检查指定网格位置是否有实心方块（可攀爬方块等不计入碰撞）
+func (w *World) IsSolidAt(x, y int) bool {
+	return entity.IsSolidBlockAt(w, x, y)
+}
+
+// PlaceRope 在指定位置放置绳索
+// 绳索只能向下延伸：目标位置已有绳索时，沿绳索向下找到第一个空位继续延伸；
+// 新的绳索段上方必须有方块或绳索作为悬挂点。返回实际放置的位置以及是否放置成功
+func (w *World) PlaceRope(x, y int) (int, int, bool) {
+	// 沿已有绳索向下找到末端
+	for {
+		block, exists := w.GetBlock(x, y)
+		if !exists {
+			break
+		}
+		if block.GetType() != entity.RopeBlock {
+			return x, y, false
+		}
+		y++
+	}
+
+	// 绳索需要悬挂在上方的方块上
+	if !w.IsBlockAt(x, y-1) {
+		return x, y, false
+	}
+
+	w.AddBlockWithType(x, y, entity.RopeBlock)
+	return x, y, true
+}
+
+// FindPath 在世界中寻路，结果会被缓存直到附近的方块发生变化
+func (w *World) FindPath(start, goal entity.GridPos, rules entity.MoveRules) ([]entity.GridPos, bool) {
+	return w.Paths.FindPath(w, start, goal, rules)
+}
+
 // AddItem 添加掉落物到世界
 func (w *World) AddItem(item *entity.ItemEntity) {
 	item.SetWorld(w)
-	w.Items = append(w.Items, item)
+	item.Lifetime = w.ItemLifetime
+	w.Entities.Add(item)
+}
+
+// dropStack 在指定位置生成物品堆叠的掉落物（保留工具的耐久度），弹出的速度使用世界的随机数
+func (w *World) dropStack(stack entity.ItemStack, x, y float64) *entity.ItemEntity {
+	item := entity.NewItemEntity(x, y, stack.Type, stack.Count)
+	item.Durability = stack.Durability
+	item.Scatter(w.rng)
+	w.AddItem(item)
+	return item
+}
+
+// ThrowStack 从玩家位置朝目标点丢出物品堆叠
+func (w *World) ThrowStack(stack entity.ItemStack, targetX, targetY float64) *entity.ItemEntity {
+	playerX, playerY := w.Player.GetPosition()
+	item := entity.NewThrownItemEntity(playerX, playerY, stack, targetX-playerX, targetY-playerY)
+	w.AddItem(item)
+	return item
 }
 
 // GetAllItems 获取所有掉落物
 func (w *World) GetAllItems() []*entity.ItemEntity {
-	return w.Items
+	items := make([]*entity.ItemEntity, 0)
+	for _, e := range w.Entities.All() {
+		if item, ok := e.(*entity.ItemEntity); ok {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// GetPlayer 获取玩家（实体更新时使用）
+func (w *World) GetPlayer() *entity.Player {
+	return w.Player
 }
 
 // Update 更新世界状态
 func (w *World) Update() {
-	// 更新所有掉落物
-	for i := len(w.Items) - 1; i >= 0; i-- {
-		item := w.Items[i]
-		item.Update()
-		
-		// 检查是否可以被玩家拾取
-		playerX, playerY := w.Player.GetPosition()
-		if item.TryPickup(playerX, playerY) {
-			// 拾取物品
-			w.Player.GetInventory().AddItem(item.GetItemType(), item.GetCount())
-			
-			// 从世界中移除掉落物
-			w.Items = append(w.Items[:i], w.Items[i+1:]...)
+	// 玩家死亡时处理物品并重生
+	if w.Player.IsDead() {
+		w.handlePlayerDeath()
+	}
+	
+	// 记录玩家最后站立的位置，掉入虚空死亡时物品掉落在这里
+	if w.Player.IsOnGround() {
+		w.safeX, w.safeY = w.Player.GetPosition()
+		w.hasSafePos = true
+	}
+	
+	// 推进昼夜时间
+	w.Time++
+	
+	// 更新加载范围内的方块实体
+	w.tickBlockEntities()
+	
+	// 移除离玩家太远的生物，吸附半径内的掉落物飞向玩家
+	w.despawnMobs()
+	w.attractItems()
+	
+	// 更新所有实体（掉落物、生物、投射物、训练假人）
+	w.Entities.Update(w)
+	
+	// 进入竞技场时开始首领战，更新正在战斗的首领
+	w.updateBoss()
+	
+	// 合并相互靠近的相同掉落物
+	w.mergeItems()
+	
+	if w.FullTimer > 0 {
+		w.FullTimer--
+	}
+	
+	// 拾取玩家附近的掉落物，回收插在方块上的投射物
+	w.pickupItems()
+	w.pickupProjectiles()
+	
+	// 按生成规则生成新的生物
+	w.spawnMobs()
+	
+	// 释放死亡、过期和被拾取的实体，被击杀的生物按战利品表掉落物品并记录事件
+	for _, e := range w.Entities.Flush() {
+		if mob, ok := e.(*entity.Mob); ok && mob.Dead {
+			w.dropMobLoot(mob)
+			w.emit(Event{Kind: EventMobKilled, Mob: mob.Kind, X: mob.X, Y: mob.Y})
+		}
+	}
+}
+
+// GetBlockEntity 获取指定网格位置的方块实体
+func (w *World) GetBlockEntity(x, y int) (entity.BlockEntity, bool) {
+	blockEntity, exists := w.BlockEntities[blockKey(x, y)]
+	return blockEntity, exists
+}
+
+// AddEntity 添加实体（例如训练假人）到世界，返回实体的ID
+func (w *World) AddEntity(e entity.Entity) entity.EntityID {
+	return w.Entities.Add(e)
+}
+
+// EntityAt 获取位于指定网格内的实体，生物和首领的碰撞箱与网格重叠即可，其他实体看中心位置；
+// 掉落物和投射物不会被选中
+func (w *World) EntityAt(x, y int) (entity.Target, bool) {
+	cell := entity.NewHitbox((float64(x)+0.5)*entity.BlockSize, (float64(y)+0.5)*entity.BlockSize, entity.BlockSize, entity.BlockSize)
+	if w.Boss != nil && w.Boss.Hitbox().Overlaps(cell) {
+		return w.Boss, true
+	}
+	for _, e := range w.Entities.QueryRect(cell) {
+		switch target := e.(type) {
+		case *entity.ItemEntity, *entity.Projectile:
 			continue
+		case *entity.Mob:
+			if target.OverlapsCell(x, y) {
+				return target, true
+			}
+		default:
+			tx, ty := target.GetPosition()
+			if int(math.Floor(tx/entity.BlockSize)) == x && int(math.Floor(ty/entity.BlockSize)) == y {
+				return target, true
+			}
 		}
-		
-		// 检查是否过期
-		if item.IsExpired() {
-			// 从世界中移除掉落物
-			w.Items = append(w.Items[:i], w.Items[i+1:]...)
+	}
+	return nil, false
+}
+
+// Damageables 获取所有可以被伤害的实体：正在战斗的首领、活着的生物和实现了entity.Damageable的其他实体（例如训练假人）
+func (w *World) Damageables() []entity.Damageable {
+	return w.damageables(w.Entities.All(), func(entity.Hitbox) bool { return true })
+}
+
+// DamageablesIn 获取碰撞箱与范围重叠的可以被伤害的实体
+func (w *World) DamageablesIn(area entity.Hitbox) []entity.Damageable {
+	return w.damageables(w.Entities.QueryRect(area), area.Overlaps)
+}
+
+// damageables 从实体中挑出可以被伤害的实体，首领满足条件时排在最前面
+func (w *World) damageables(entities []entity.Entity, include func(entity.Hitbox) bool) []entity.Damageable {
+	targets := make([]entity.Damageable, 0, len(entities)+1)
+	if w.Boss != nil && !w.Boss.Dead && include(w.Boss.Hitbox()) {
+		targets = append(targets, w.Boss)
+	}
+	for _, e := range entities {
+		if damageable, ok := e.(entity.Damageable); ok {
+			targets = append(targets, damageable)
+		}
+	}
+	return targets
+}
+
+// IsLoaded 检查指定网格位置是否在玩家周围的加载范围内
+func (w *World) IsLoaded(x, y int) bool {
+	playerX, playerY := w.Player.GetPosition()
+	dx := math.Abs(float64(x) - math.Floor(playerX/entity.BlockSize))
+	dy := math.Abs(float64(y) - math.Floor(playerY/entity.BlockSize))
+	return dx <= LoadedRadius && dy <= LoadedRadius
+}
+
+// tickBlockEntities 更新加载范围内的所有方块实体，界面是否打开不影响更新
+func (w *World) tickBlockEntities() {
+	for key, blockEntity := range w.BlockEntities {
+		block, exists := w.Blocks[key]
+		if !exists {
+			delete(w.BlockEntities, key)
+			continue
+		}
+		if w.IsLoaded(block.GetGridPosition()) {
+			blockEntity.Tick()
 		}
 	}
 }
 
+// IsInventoryFull 返回最近是否因为物品栏已满而无法拾取掉落物
+func (w *World) IsInventoryFull() bool {
+	return w.FullTimer > 0
+}
+
+// mergeItems 将距离很近的相同掉落物合并为一个堆叠，先加入世界的掉落物吸收后加入的
+func (w *World) mergeItems() {
+	for _, item := range w.GetAllItems() {
+		if !w.Entities.Has(item) {
+			continue
+		}
+		id, _ := w.Entities.ID(item)
+		for _, e := range w.Entities.QueryRadius(item.X, item.Y, entity.ItemMergeRange) {
+			other, ok := e.(*entity.ItemEntity)
+			if !ok {
+				continue
+			}
+			if otherID, _ := w.Entities.ID(other); otherID > id && item.TryMerge(other) {
+				w.Entities.Remove(other)
+			}
+		}
+	}
+}
+
+// attractItems 拾取延迟结束后，吸附半径内的掉落物在本帧飞向玩家（物品栏放不下时不吸附）
+func (w *World) attractItems() {
+	if w.MagnetRadius <= 0 {
+		return
+	}
+	inventory := w.Player.GetInventory()
+	playerX, playerY := w.Player.GetPosition()
+	for _, e := range w.Entities.QueryRadius(playerX, playerY, w.magnetRadius()) {
+		item, ok := e.(*entity.ItemEntity)
+		if ok && item.CanPickup() && inventory.CanAccept(item.GetItemType()) && w.isInMagnetRange(item, playerX, playerY) {
+			item.Attract(playerX, playerY)
+		}
+	}
+}
+
+// pickupItems 拾取玩家拾取范围内的掉落物，放不下的部分留在地上
+func (w *World) pickupItems() {
+	inventory := w.Player.GetInventory()
+	playerX, playerY := w.Player.GetPosition()
+	for _, e := range w.Entities.QueryRadius(playerX, playerY, entity.ItemPickupRange) {
+		item, ok := e.(*entity.ItemEntity)
+		if !ok || !item.CanPickup() || !item.TryPickup(playerX, playerY) {
+			continue
+		}
+		leftover := inventory.AddStack(entity.ItemStack{Type: item.GetItemType(), Count: item.GetCount(), Durability: item.Durability})
+		if picked := item.GetCount() - leftover; picked > 0 {
+			w.emit(Event{Kind: EventItemPickedUp, Item: item.GetItemType(), Count: picked, X: item.X, Y: item.Y})
+		}
+		if leftover > 0 {
+			item.Count = leftover
+			w.FullTimer = InventoryFullDisplay
+		} else {
+			w.Entities.Remove(item)
+		}
+	}
+}
+
+// isInMagnetRange 检查掉落物是否在玩家的吸附半径内
+func (w *World) isInMagnetRange(item *entity.ItemEntity, playerX, playerY float64) bool {
+	dx := item.X - playerX
+	dy := item.Y - playerY
+	radius := w.magnetRadius()
+	return dx*dx+dy*dy <= radius*radius
+}
+
+// magnetRadius 获取实际的吸附半径：世界的吸附半径加上玩家天赋的加成
+func (w *World) magnetRadius() float64 {
+	return w.MagnetRadius + w.Player.Stats().MagnetBonus
+}
+
+// handlePlayerDeath 根据死亡模式掉落或清空玩家物品栏，然后让玩家重生；正在进行的首领战被重置
+func (w *World) handlePlayerDeath() {
+	w.resetBossFight()
+
+	dropX, dropY := w.deathDropPosition()
+	stacks := w.Player.GetInventory().Clear()
+
+	if w.DeathMode == DeathDropInventory {
+		w.spillStacks(stacks, dropX, dropY)
+	}
+
+	w.Player.Respawn()
+}
+
+// deathDropPosition 死亡时物品掉落的位置：通常是死亡位置；掉入虚空死亡时物品会跟着落入虚空，
+// 改为掉在玩家最后站立的位置，没有记录时掉在重生点
+func (w *World) deathDropPosition() (float64, float64) {
+	if w.Player.LastDamageSource != entity.DamageVoid {
+		return w.Player.GetPosition()
+	}
+	if w.hasSafePos {
+		return w.safeX, w.safeY
+	}
+	return w.Player.SpawnX, w.Player.SpawnY
+}
+
 // blockKey generates a unique key for a block position
 func blockKey(x, y int) string {
 	return fmt.Sprintf("%d,%d", x, y)
 }
-
-// getBlockDropItemType 根据方块类型获取掉落物品类型
-func getBlockDropItemType(blockType entity.BlockType) entity.ItemType {
-	// 由于GrassBlock已合并到DirtBlock中，需要特殊处理
-	if blockType == entity.StoneBlock {
-		return entity.Stone
-	} else if blockType == entity.DirtBlock || blockType == entity.GrassBlock {
-		return entity.Dirt // 泥土方块和草方块都掉落泥土物品
-	} else if blockType == entity.WoodBlock {
-		return entity.Wood
-	} else if blockType == entity.LeavesBlock {
-		return entity.Leaves
-	}
-	return entity.Stone // 默认为石头
-}
\ No newline at end of file
@@ -1,10 +1,25 @@
 package world
 
 import (
+	"os"
 	"testing"
 	"mygo/internal/pkg/entity"
 )
 
+// TestMain 测试前加载物品、首领和战利品表数据文件，与游戏启动时一致
+func TestMain(m *testing.M) {
+	if err := entity.LoadItems("../../../data/items.json"); err != nil {
+		panic(err)
+	}
+	if err := entity.LoadBosses("../../../data/bosses.json"); err != nil {
+		panic(err)
+	}
+	if err := entity.LoadLootTables("../../../data/loot_tables.json"); err != nil {
+		panic(err)
+	}
+	os.Exit(m.Run())
+}
+
 func TestNewWorld(t *testing.T) {
 	world := NewWorld()
 	
@@ -186,4 +201,391 @@ func TestWorldBlockOperationsAtNegativePositions(t *testing.T) {
 	if world.IsBlockAt(-1, -2) {
 		t.Error("Expected no block at position (-1, -2) after removal")
 	}
-}
\ No newline at end of file
+}
+func TestWorldPlaceRope(t *testing.T) {
+	world := NewWorld()
+
+	// 没有悬挂点时不能放置
+	if _, _, placed := world.PlaceRope(1, 1); placed {
+		t.Error("Expected rope placement without anchor to fail")
+	}
+
+	// 悬挂在方块下方
+	world.AddBlock(1, 0)
+	x, y, placed := world.PlaceRope(1, 1)
+	if !placed || x != 1 || y != 1 {
+		t.Errorf("Expected rope at (1, 1), got (%d, %d) placed=%v", x, y, placed)
+	}
+
+	// 点击已有绳索时向下延伸
+	_, y, placed = world.PlaceRope(1, 1)
+	if !placed || y != 2 {
+		t.Errorf("Expected rope to extend down to y=2, got y=%d placed=%v", y, placed)
+	}
+
+	block, exists := world.GetBlock(1, 2)
+	if !exists || block.GetType() != entity.RopeBlock {
+		t.Error("Expected rope block at (1, 2)")
+	}
+
+	// 绳索不是实心方块
+	if world.IsSolidAt(1, 2) {
+		t.Error("Expected rope not to be solid")
+	}
+
+	// 不能在其他方块上延伸
+	if _, _, placed := world.PlaceRope(1, 0); placed {
+		t.Error("Expected rope placement on a stone block to fail")
+	}
+}
+
+func TestWorldPlayerDeathDropsInventory(t *testing.T) {
+	world := NewWorld()
+	world.Player.SetSpawnPoint(0, -100)
+	world.Player.SetPosition(320, 0)
+	world.Player.TakeDamage(100, entity.DamageHazard)
+
+	world.Update()
+
+	if world.Player.IsDead() {
+		t.Error("Expected player to respawn after death")
+	}
+
+	if world.Player.GetInventory().GetSlot(0).Type != entity.Air {
+		t.Error("Expected inventory to be emptied on death")
+	}
+
+	// 物品掉落在死亡位置
+	if len(world.GetAllItems()) == 0 {
+		t.Fatal("Expected inventory items to be dropped on death")
+	}
+	for _, item := range world.GetAllItems() {
+		if x, _ := item.GetPosition(); x < 200 {
+			t.Errorf("Expected dropped item near death position, got x=%f", x)
+		}
+	}
+}
+
+func TestWorldPlayerDeathClearsInventory(t *testing.T) {
+	world := NewWorld()
+	world.DeathMode = DeathClearInventory
+	world.Player.TakeDamage(100, entity.DamageHazard)
+
+	world.Update()
+
+	if world.Player.IsDead() {
+		t.Error("Expected player to respawn after death")
+	}
+
+	if len(world.GetAllItems()) != 0 {
+		t.Errorf("Expected no dropped items in clear mode, got %d", len(world.GetAllItems()))
+	}
+
+	if world.Player.GetInventory().GetSlot(0).Type != entity.Air {
+		t.Error("Expected inventory to be cleared on death")
+	}
+}
+
+func TestWorldVoidDeathDropsAtLastSafePosition(t *testing.T) {
+	world := NewWorld()
+	world.AddBlockWithType(10, 5, entity.StoneBlock)
+	world.Player.SetSpawnPoint(0, -100)
+	world.Player.SetPosition(10*32+16, 4*32+16)
+	world.Player.SetOnGround(true)
+	world.Update()
+
+	// 掉入虚空死亡，物品掉在最后站立的位置而不是虚空中
+	world.Player.SetOnGround(false)
+	world.Player.SetPosition(10*32+16, entity.VoidDepth+500)
+	world.Player.TakeDamage(100, entity.DamageVoid)
+	world.Update()
+
+	if len(world.GetAllItems()) == 0 {
+		t.Fatal("Expected inventory items to be dropped on death")
+	}
+	for _, item := range world.GetAllItems() {
+		if _, y := item.GetPosition(); y > 6*32 {
+			t.Errorf("Expected items to drop at the last safe position, got y=%f", y)
+		}
+	}
+}
+
+func TestWorldVoidDeathWithoutSafePositionDropsAtSpawn(t *testing.T) {
+	world := NewWorld()
+	world.Player.SetSpawnPoint(0, -100)
+	world.Player.SetOnGround(false)
+	world.Player.SetPosition(320, entity.VoidDepth+500)
+	world.Player.TakeDamage(100, entity.DamageVoid)
+	world.Update()
+
+	for _, item := range world.GetAllItems() {
+		if x, y := item.GetPosition(); x > 100 || y > 0 {
+			t.Errorf("Expected items to drop at the spawn point, got (%f, %f)", x, y)
+		}
+	}
+}
+
+func TestParseDeathMode(t *testing.T) {
+	if mode, ok := ParseDeathMode("clear"); !ok || mode != DeathClearInventory {
+		t.Error("Expected clear to select the clear death mode")
+	}
+	if mode, ok := ParseDeathMode("drop"); !ok || mode != DeathDropInventory {
+		t.Error("Expected drop to select the drop death mode")
+	}
+	if _, ok := ParseDeathMode("keep"); ok {
+		t.Error("Expected error for unknown death mode")
+	}
+}
+
+func TestWorldItemPickupDelayAndMagnet(t *testing.T) {
+	world := NewWorld()
+	world.Player.SetPosition(0, 0)
+	world.Player.GetInventory().Clear()
+
+	// 出现在玩家身上的掉落物在拾取延迟内不会被拾取
+	world.AddItem(entity.NewItemEntity(0, 0, entity.Dirt, 1))
+	world.Update()
+	if len(world.GetAllItems()) != 1 {
+		t.Fatal("Expected drop to stay in the world during its pickup delay")
+	}
+
+	// 吸附半径内的掉落物飞向玩家并被拾取
+	world.GetAllItems()[0].X = entity.ItemMagnetRange - 10
+	world.GetAllItems()[0].PickupDelay = 0
+	for i := 0; i < 60 && len(world.GetAllItems()) > 0; i++ {
+		world.Update()
+	}
+	if len(world.GetAllItems()) != 0 {
+		t.Fatal("Expected drop in magnet range to be pulled in and picked up")
+	}
+	if world.Player.GetInventory().GetSlot(0).Type != entity.Dirt {
+		t.Error("Expected picked up dirt in the inventory")
+	}
+}
+
+func TestWorldMergesDropsAndUsesLifetimeSetting(t *testing.T) {
+	world := NewWorld()
+	world.ItemLifetime = 42
+	world.Player.SetPosition(10000, 0)
+
+	world.AddItem(&entity.ItemEntity{X: 0, Y: 0, ItemType: entity.Stone, Count: 2})
+	world.AddItem(&entity.ItemEntity{X: 5, Y: 0, ItemType: entity.Stone, Count: 3})
+	world.AddItem(&entity.ItemEntity{X: 5, Y: 0, ItemType: entity.Wood, Count: 1})
+	if world.GetAllItems()[0].Lifetime != 42 {
+		t.Errorf("Expected lifetime from world setting, got %d", world.GetAllItems()[0].Lifetime)
+	}
+
+	world.Update()
+	if len(world.GetAllItems()) != 2 {
+		t.Fatalf("Expected identical drops to merge, got %d items", len(world.GetAllItems()))
+	}
+	if world.GetAllItems()[0].Count != 5 {
+		t.Errorf("Expected merged stack of 5, got %d", world.GetAllItems()[0].Count)
+	}
+}
+
+func TestWorldPartialPickupWithFullInventory(t *testing.T) {
+	world := NewWorld()
+	world.Player.SetPosition(0, 0)
+	inventory := world.Player.GetInventory()
+	for i := 0; i < entity.TotalSlotCount; i++ {
+		inventory.SetSlot(i, entity.ItemStack{Type: entity.Dirt, Count: 64})
+	}
+	inventory.SetSlot(0, entity.ItemStack{Type: entity.Stone, Count: 62})
+
+	world.AddItem(&entity.ItemEntity{X: 0, Y: 0, ItemType: entity.Stone, Count: 5})
+	world.AddItem(&entity.ItemEntity{X: 40, Y: 0, ItemType: entity.Wood, Count: 1})
+	world.Update()
+
+	items := world.GetAllItems()
+	if len(items) != 2 {
+		t.Fatalf("Expected drops that don't fit to stay in the world, got %d", len(items))
+	}
+	for _, item := range items {
+		if item.ItemType == entity.Stone && item.Count != 3 {
+			t.Errorf("Expected ground stack to shrink to 3, got %d", item.Count)
+		}
+		if item.ItemType == entity.Wood && item.X != 40 {
+			t.Error("Expected drops that can't be picked up not to be pulled in")
+		}
+	}
+	if inventory.GetSlot(0).Count != 64 {
+		t.Errorf("Expected partial pickup to fill the stack, got %d", inventory.GetSlot(0).Count)
+	}
+	if !world.IsInventoryFull() {
+		t.Error("Expected full inventory indicator after a blocked pickup")
+	}
+}
+
+func TestWorldTicksLoadedBlockEntities(t *testing.T) {
+	world := NewWorld()
+	world.Player.SetPosition(0, -200) // 避免玩家落地前受到影响
+	world.AddBlockWithType(2, 0, entity.FurnaceBlock)
+	world.AddBlockWithType(LoadedRadius+10, 0, entity.FurnaceBlock)
+
+	near, _ := world.GetBlockEntity(2, 0)
+	far, _ := world.GetBlockEntity(LoadedRadius+10, 0)
+	for _, blockEntity := range []entity.BlockEntity{near, far} {
+		furnace := blockEntity.(*entity.FurnaceEntity)
+		furnace.Slots[entity.FurnaceInput] = entity.ItemStack{Type: entity.Stone, Count: 1}
+		furnace.Slots[entity.FurnaceFuel] = entity.ItemStack{Type: entity.Coal, Count: 1}
+	}
+
+	for i := 0; i < entity.SmeltTime; i++ {
+		world.Update()
+	}
+	if near.(*entity.FurnaceEntity).Slots[entity.FurnaceOutput].Type != entity.SmoothStone {
+		t.Error("Expected loaded furnace to smelt stone into smooth stone")
+	}
+	if far.(*entity.FurnaceEntity).IsBurning() {
+		t.Error("Expected furnace outside the loaded area not to tick")
+	}
+
+	// 破坏熔炉时撒出其中的物品并移除方块实体
+	world.RemoveBlock(2, 0)
+	if _, exists := world.GetBlockEntity(2, 0); exists {
+		t.Error("Expected block entity to be removed with the block")
+	}
+	found := false
+	for _, item := range world.GetAllItems() {
+		if item.GetItemType() == entity.SmoothStone {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("Expected furnace output to be spilled")
+	}
+}
+
+func TestWorldPathCacheInvalidation(t *testing.T) {
+	world := NewWorld()
+	for x := -10; x <= 40; x++ {
+		world.AddBlockWithType(x, 5, entity.StoneBlock)
+	}
+	rules := entity.MoveRules{Height: 1, MaxJump: 2, MaxDrop: 4}
+	start, goal := entity.GridPos{X: 0, Y: 4}, entity.GridPos{X: 6, Y: 4}
+
+	if _, found := world.FindPath(start, goal, rules); !found {
+		t.Fatal("Expected a path along the ground")
+	}
+	if world.Paths.Len() != 1 {
+		t.Fatalf("Expected the path to be cached, got %d entries", world.Paths.Len())
+	}
+
+	// 远处的方块变化不影响缓存
+	world.AddBlockWithType(30, 4, entity.StoneBlock)
+	world.RemoveBlock(30, 4)
+	if world.Paths.Len() != 1 {
+		t.Error("Expected distant block changes to keep the cached path")
+	}
+
+	// 在路径上砌一堵跳不过去的墙
+	for y := 1; y <= 4; y++ {
+		world.AddBlockWithType(3, y, entity.StoneBlock)
+	}
+	if world.Paths.Len() != 0 {
+		t.Error("Expected placing blocks near the path to invalidate it")
+	}
+	if _, found := world.FindPath(start, goal, rules); found {
+		t.Error("Expected the wall to block the path")
+	}
+
+	// 挖开墙后重新找到路径
+	world.RemoveBlock(3, 4)
+	world.RemoveBlock(3, 3)
+	if _, found := world.FindPath(start, goal, rules); !found {
+		t.Error("Expected removing blocks to invalidate the failed result")
+	}
+}
+
+func TestWorldDamageables(t *testing.T) {
+	world := NewWorld()
+	world.MobSpawning = false
+	mob := world.SpawnMob(entity.MobSlime, 3, 0)
+	dummy := entity.NewTrainingDummy(0, 0)
+	world.AddEntity(dummy)
+
+	if targets := world.Damageables(); len(targets) != 2 {
+		t.Fatalf("Expected the mob and the dummy to be damageable, got %d", len(targets))
+	}
+	mob.Dead = true
+	if targets := world.Damageables(); len(targets) != 1 || targets[0] != entity.Damageable(dummy) {
+		t.Error("Expected dead mobs to be excluded")
+	}
+
+	// 世界更新时假人的受击闪烁结束
+	dummy.Hit(1, 0, 0)
+	for i := 0; i < entity.DummyHurtDuration; i++ {
+		world.Update()
+	}
+	if dummy.HurtTimer != 0 {
+		t.Error("Expected the world to update the dummy")
+	}
+}
+
+func TestWorldEntitiesShareManager(t *testing.T) {
+	world := NewWorld()
+	world.MobSpawning = false
+	world.Player.SetPosition(0, 0)
+
+	item := entity.NewItemEntity(2000, 0, entity.Dirt, 1)
+	world.AddItem(item)
+	mob := world.SpawnMob(entity.MobSlime, 3, 0)
+	far := world.SpawnMob(entity.MobSlime, DespawnDistance+10, 0)
+	projectile := entity.NewProjectile(entity.ProjectileArrow, 5000, 0, 0, 0, 1, 0)
+	world.AddProjectile(projectile)
+
+	itemID, _ := world.Entities.ID(item)
+	mobID, _ := world.Entities.ID(mob)
+	if itemID == mobID || world.Entities.Len() != 4 {
+		t.Fatalf("Expected items, mobs and projectiles in one manager, got %d", world.Entities.Len())
+	}
+
+	// 附近的查询只返回附近的实体
+	nearby := world.Entities.QueryRadius(3*32+16, 0, 64)
+	if len(nearby) != 1 || nearby[0] != entity.Entity(mob) {
+		t.Fatalf("Expected only the nearby mob, got %d entities", len(nearby))
+	}
+
+	// 离玩家太远的生物被移除，其他实体保留原来的ID
+	world.Update()
+	if world.Entities.Has(far) {
+		t.Error("Expected far mob to despawn")
+	}
+	if got, exists := world.Entities.Get(mobID); !exists || got != entity.Entity(mob) {
+		t.Error("Expected the nearby mob to keep its ID")
+	}
+	if len(world.GetAllItems()) != 1 || len(world.GetAllMobs()) != 1 || len(world.GetAllProjectiles()) != 1 {
+		t.Error("Expected typed accessors to read from the manager")
+	}
+}
+
+func TestWorldEmitsKillAndPickupEvents(t *testing.T) {
+	world := NewWorld()
+	world.MobSpawning = false
+	world.Player.SetPosition(0, 0)
+	world.Player.GetInventory().Clear()
+
+	mob := world.SpawnMob(entity.MobSlime, 3, 0)
+	mob.TakeDamage(mob.MaxHealth)
+	item := entity.NewItemEntity(0, 0, entity.Coal, 3)
+	world.AddItem(item)
+	item.PickupDelay = 0
+	world.Update()
+
+	var killed, picked bool
+	for _, event := range world.PollEvents() {
+		switch event.Kind {
+		case EventMobKilled:
+			killed = event.Mob == entity.MobSlime
+		case EventItemPickedUp:
+			picked = event.Item == entity.Coal && event.Count == 3
+		}
+	}
+	if !killed || !picked {
+		t.Errorf("Expected kill and pickup events, got killed=%v picked=%v", killed, picked)
+	}
+	if len(world.PollEvents()) != 0 {
+		t.Error("Expected events to be cleared after polling")
+	}
+}